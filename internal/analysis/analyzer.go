@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
 	"github.com/BlagoCuljak/ApiPosture.Go/internal/classification"
 	"github.com/BlagoCuljak/ApiPosture.Go/internal/config"
 	"github.com/BlagoCuljak/ApiPosture.Go/internal/discovery"
@@ -32,7 +33,17 @@ func NewProjectAnalyzer(cfg *config.Config) *ProjectAnalyzer {
 		loader:      NewSourceLoader(),
 		discoverers: discovery.AllDiscoverers(),
 		classifier:  classification.NewClassifier(),
-		ruleEngine:  rules.NewEngine(cfg.GetActiveRules()),
+		ruleEngine:  rules.NewEngineWithSettings(cfg.GetActiveRules(), ruleSettings(cfg)),
+	}
+}
+
+// ruleSettings maps the analysis section of the configuration to rule settings.
+func ruleSettings(cfg *config.Config) rules.Settings {
+	return rules.Settings{
+		RateLimitMiddleware: cfg.Analysis.RateLimitMiddleware,
+		PrivilegedFields:    cfg.Analysis.PrivilegedFields,
+		PrincipalAccessors:  cfg.Analysis.PrincipalAccessors,
+		AuthFunctions:       cfg.Analysis.AuthFunctions,
 	}
 }
 
@@ -52,20 +63,43 @@ func (a *ProjectAnalyzer) Analyze(path string) (*models.ScanResult, error) {
 	}
 	result.FilesScanned = files
 
-	// Scan each file
+	// Scan each file, keeping parsed sources for project-level rules
+	sources := astutil.NewSourceSet(absPath)
 	for _, file := range files {
-		a.scanFile(file, result)
+		a.scanFile(file, result, sources)
 	}
 
+	// Drop pprof and expvar routes when http.DefaultServeMux is never served
+	result.Endpoints = discovery.PruneUnservedImplicitEndpoints(result.Endpoints, sources)
+
+	// Tag WebSocket and SSE endpoints, whose handlers may be declared in other files
+	discovery.TagEndpointKinds(result.Endpoints, sources)
+
+	// Record reverse proxies and their upstreams, which may also be declared in other files
+	discovery.TagProxyEndpoints(result.Endpoints, sources)
+
+	// Record file upload handlers and the body size limits that apply to them
+	discovery.TagUploadEndpoints(result.Endpoints, sources)
+
+	// Treat read endpoints whose handlers change state as write endpoints
+	discovery.TagStateChangingEndpoints(result.Endpoints, sources, a.config.Analysis.MutatingFunctions)
+
+	// Count IP allowlist middleware as authentication before classification
+	discovery.TagIPAllowlistEndpoints(result.Endpoints, sources)
+
+	// Webhook signature verification authenticates the sender
+	discovery.TagWebhookEndpoints(result.Endpoints, sources, a.config.Analysis.WebhookVerifiers)
+
 	// Classify all endpoints
 	a.classifier.ClassifyAll(result.Endpoints)
 
-	// Run security rules
+	// Run security rules, then project-level rules that compare endpoints with each other
 	findings := a.ruleEngine.EvaluateAll(result.Endpoints)
+	findings = append(findings, a.ruleEngine.EvaluateProject(result, sources)...)
 
 	// Apply suppressions
 	for _, finding := range findings {
-		suppressed, reason := a.config.IsSuppressed(finding.RuleID, finding.Endpoint.FullRoute())
+		suppressed, reason := a.config.IsSuppressed(finding.RuleID, finding.Route(), finding.File())
 		if suppressed {
 			finding.Suppressed = true
 			finding.SuppressionReason = reason
@@ -104,12 +138,13 @@ func (a *ProjectAnalyzer) getFiles(path string) ([]string, error) {
 }
 
 // scanFile scans a single file for endpoints.
-func (a *ProjectAnalyzer) scanFile(filePath string, result *models.ScanResult) {
+func (a *ProjectAnalyzer) scanFile(filePath string, result *models.ScanResult, sources *astutil.SourceSet) {
 	source, errStr := a.loader.TryParseFile(filePath)
 	if errStr != "" {
 		result.ParseErrors[filePath] = errStr
 		return
 	}
+	sources.Add(source)
 
 	// Try each discoverer
 	for _, disc := range a.discoverers {
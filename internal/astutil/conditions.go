@@ -0,0 +1,97 @@
+package astutil
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// ExprString returns the source representation of an expression (e.g., "cfg.AuthEnabled").
+func ExprString(expr ast.Expr) string {
+	if expr == nil {
+		return ""
+	}
+	return types.ExprString(expr)
+}
+
+// conditionGuard is a source range that only executes when condition holds.
+type conditionGuard struct {
+	pos       token.Pos
+	end       token.Pos
+	condition string
+}
+
+// ConditionIndex records which source ranges are guarded by if/switch conditions.
+type ConditionIndex struct {
+	guards []conditionGuard
+}
+
+// NewConditionIndex collects the guarded branches of all if and switch statements in a node.
+func NewConditionIndex(node ast.Node) *ConditionIndex {
+	idx := &ConditionIndex{}
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.IfStmt:
+			cond := ExprString(stmt.Cond)
+			idx.add(stmt.Body, cond)
+			if stmt.Else != nil {
+				idx.add(stmt.Else, "!("+cond+")")
+			}
+		case *ast.SwitchStmt:
+			tag := ExprString(stmt.Tag)
+			for _, s := range stmt.Body.List {
+				clause, ok := s.(*ast.CaseClause)
+				if !ok {
+					continue
+				}
+				idx.add(clause, caseCondition(tag, clause))
+			}
+		}
+		return true
+	})
+
+	return idx
+}
+
+// add registers a guarded range.
+func (c *ConditionIndex) add(node ast.Node, condition string) {
+	c.guards = append(c.guards, conditionGuard{
+		pos:       node.Pos(),
+		end:       node.End(),
+		condition: condition,
+	})
+}
+
+// ConditionFor returns the conditions guarding a node, outermost first and joined with " && ".
+// Returns an empty string if the node executes unconditionally.
+func (c *ConditionIndex) ConditionFor(node ast.Node) string {
+	var conds []string
+	for _, g := range c.guards {
+		if node.Pos() >= g.pos && node.End() <= g.end {
+			conds = append(conds, g.condition)
+		}
+	}
+	return strings.Join(conds, " && ")
+}
+
+// caseCondition builds a readable condition for a switch case clause.
+func caseCondition(tag string, clause *ast.CaseClause) string {
+	if len(clause.List) == 0 {
+		if tag == "" {
+			return "default"
+		}
+		return tag + " == default"
+	}
+
+	parts := make([]string, 0, len(clause.List))
+	for _, expr := range clause.List {
+		if tag == "" {
+			parts = append(parts, ExprString(expr))
+		} else {
+			parts = append(parts, tag+" == "+ExprString(expr))
+		}
+	}
+	return strings.Join(parts, " || ")
+}
@@ -5,6 +5,7 @@ import (
 	"go/token"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 // GetCallName returns the name of a function call (e.g., "r.GET" or "http.HandleFunc").
@@ -220,3 +221,27 @@ func FindMethodCalls(node ast.Node, methodName string) []*ast.CallExpr {
 func GetLineNumber(fset *token.FileSet, node ast.Node) int {
 	return fset.Position(node.Pos()).Line
 }
+
+// SplitWords splits a Go identifier into lowercase words at case changes and underscores,
+// keeping acronyms together (APIKey -> api, key).
+func SplitWords(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := 0
+	for i := 1; i <= len(runes); i++ {
+		boundary := i == len(runes) || runes[i] == '_'
+		if !boundary && unicode.IsUpper(runes[i]) {
+			// Lower to upper (passwordHash), or the last upper of an acronym before a lower (APIKey)
+			boundary = unicode.IsLower(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i+1]))
+		}
+		if !boundary {
+			continue
+		}
+		if word := strings.Trim(string(runes[start:i]), "_"); word != "" {
+			words = append(words, strings.ToLower(word))
+		}
+		start = i
+	}
+	return words
+}
@@ -71,9 +71,7 @@ func (l *SourceLoader) ParseContent(path, content string) (*ParsedSource, error)
 		if imp.Name != nil {
 			alias = imp.Name.Name
 		} else {
-			// Use last part of path as default alias
-			parts := strings.Split(importPath, "/")
-			alias = parts[len(parts)-1]
+			alias = defaultImportName(importPath)
 		}
 		source.Imports[importPath] = alias
 	}
@@ -110,3 +108,55 @@ func (s *ParsedSource) HasImportPrefix(prefix string) bool {
 func (s *ParsedSource) GetImportAlias(importPath string) string {
 	return s.Imports[importPath]
 }
+
+// IsPackageRef returns true if an identifier refers to an imported package: it is the name of an
+// import and does not resolve to a declaration of the file.
+func (s *ParsedSource) IsPackageRef(ident *ast.Ident) bool {
+	if ident.Obj != nil {
+		return false
+	}
+	for _, alias := range s.Imports {
+		if alias == ident.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// NodeText returns the source text of a node.
+func (s *ParsedSource) NodeText(node ast.Node) string {
+	start := s.FileSet.Position(node.Pos()).Offset
+	end := s.FileSet.Position(node.End()).Offset
+	if start < 0 || end > len(s.Content) || start > end {
+		return ""
+	}
+	return s.Content[start:end]
+}
+
+// defaultImportName returns the name an import is referenced by when it has no alias:
+// the last path element, skipping a major version suffix ("github.com/go-chi/jwtauth/v5" -> "jwtauth",
+// "gopkg.in/yaml.v2" -> "yaml").
+func defaultImportName(importPath string) string {
+	parts := strings.Split(importPath, "/")
+	name := parts[len(parts)-1]
+	if len(parts) > 1 && isMajorVersion(name) {
+		name = parts[len(parts)-2]
+	}
+	if idx := strings.LastIndex(name, "."); idx > 0 && isMajorVersion(name[idx+1:]) {
+		name = name[:idx]
+	}
+	return name
+}
+
+// isMajorVersion returns true for module major version path elements such as "v2".
+func isMajorVersion(element string) bool {
+	if len(element) < 2 || element[0] != 'v' {
+		return false
+	}
+	for _, r := range element[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
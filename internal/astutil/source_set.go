@@ -0,0 +1,118 @@
+package astutil
+
+import (
+	"go/ast"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SourceSet contains all parsed source files of a scanned project.
+type SourceSet struct {
+	// Root is the scanned project root.
+	Root string
+
+	// Files contains the parsed sources in the order they were added.
+	Files []*ParsedSource
+
+	byPath map[string]*ParsedSource
+
+	// types caches the type information of checked packages by directory.
+	types map[string]*PackageTypes
+}
+
+// NewSourceSet creates an empty SourceSet for a project root.
+func NewSourceSet(root string) *SourceSet {
+	return &SourceSet{
+		Root:   root,
+		Files:  []*ParsedSource{},
+		byPath: make(map[string]*ParsedSource),
+	}
+}
+
+// Add adds a parsed source to the set.
+func (s *SourceSet) Add(source *ParsedSource) {
+	if source == nil {
+		return
+	}
+	if _, exists := s.byPath[source.FilePath]; exists {
+		return
+	}
+	s.Files = append(s.Files, source)
+	s.byPath[source.FilePath] = source
+}
+
+// Get returns the parsed source for a file path, or nil if it was not scanned.
+func (s *SourceSet) Get(path string) *ParsedSource {
+	return s.byPath[path]
+}
+
+// Package returns all sources in the same directory (Go package) as the given file.
+func (s *SourceSet) Package(path string) []*ParsedSource {
+	dir := filepath.Dir(path)
+
+	var pkg []*ParsedSource
+	for _, source := range s.Files {
+		if filepath.Dir(source.FilePath) == dir {
+			pkg = append(pkg, source)
+		}
+	}
+
+	sort.Slice(pkg, func(i, j int) bool {
+		return pkg[i].FilePath < pkg[j].FilePath
+	})
+	return pkg
+}
+
+// PackageForImport returns the sources of the scanned package an import path refers to,
+// matched by the package directory relative to the root. Returns nil for external packages.
+func (s *SourceSet) PackageForImport(importPath string) []*ParsedSource {
+	for _, source := range s.Files {
+		rel, err := filepath.Rel(s.Root, filepath.Dir(source.FilePath))
+		if err != nil || rel == "." {
+			continue
+		}
+		if strings.HasSuffix(importPath, "/"+filepath.ToSlash(rel)) {
+			return s.Package(source.FilePath)
+		}
+	}
+	return nil
+}
+
+// ResolveFunc finds the declaration of a function referenced by name from a file.
+// A plain name is looked up in the file's package, "pkg.Name" in the imported package,
+// and "recv.Name" as a method of any type in the file's package.
+// Returns nil if the declaration is not part of the scanned sources.
+func (s *SourceSet) ResolveFunc(fromPath, name string) (*ParsedSource, *ast.FuncDecl) {
+	from := s.Get(fromPath)
+	if from == nil || name == "" {
+		return nil, nil
+	}
+
+	pkg := s.Package(fromPath)
+	wantMethod := false
+
+	if idx := strings.LastIndex(name, "."); idx > 0 {
+		qualifier := name[:idx]
+		name = name[idx+1:]
+		wantMethod = true
+
+		for importPath, alias := range from.Imports {
+			if alias == qualifier {
+				pkg = s.PackageForImport(importPath)
+				wantMethod = false
+				break
+			}
+		}
+	}
+
+	for _, source := range pkg {
+		for _, fn := range FindFuncDecls(source.AST) {
+			if fn.Name.Name == name && (fn.Recv != nil) == wantMethod {
+				return source, fn
+			}
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,85 @@
+package astutil
+
+import (
+	"go/ast"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultImportName(t *testing.T) {
+	tests := []struct {
+		importPath string
+		expected   string
+	}{
+		{"net/http", "http"},
+		{"fmt", "fmt"},
+		{"github.com/gin-gonic/gin", "gin"},
+		{"github.com/golang-jwt/jwt/v5", "jwt"},
+		{"github.com/labstack/echo/v4/middleware", "middleware"},
+		{"github.com/gofiber/fiber/v2", "fiber"},
+		{"gopkg.in/yaml.v3", "yaml"},
+		{"gopkg.in/square/go-jose.v2", "go-jose"},
+		{"github.com/v2fly/v2ray-core", "v2ray-core"},
+		{"github.com/example/api/v2beta", "v2beta"},
+		{"github.com/example/v", "v"},
+		{"example.com/pkg.v", "pkg.v"},
+		{"v2", "v2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.importPath, func(t *testing.T) {
+			assert.Equal(t, tt.expected, defaultImportName(tt.importPath))
+		})
+	}
+}
+
+func TestParseContent_ImportAliases(t *testing.T) {
+	source, err := NewSourceLoader().ParseContent("main.go", `package main
+
+import (
+	"github.com/golang-jwt/jwt/v5"
+	yaml "gopkg.in/yaml.v3"
+	"gopkg.in/ini.v1"
+)
+`)
+	require.NoError(t, err)
+
+	assert.Equal(t, "jwt", source.GetImportAlias("github.com/golang-jwt/jwt/v5"))
+	assert.Equal(t, "yaml", source.GetImportAlias("gopkg.in/yaml.v3"))
+	assert.Equal(t, "ini", source.GetImportAlias("gopkg.in/ini.v1"))
+}
+
+func TestParsedSource_IsPackageRef(t *testing.T) {
+	source, err := NewSourceLoader().ParseContent("main.go", `package main
+
+import (
+	"os"
+	store "example.com/app/storage"
+)
+
+func export(db *DB) {
+	os.Create("/tmp/export.csv")
+	db.Create(&User{})
+	store.Save()
+	os := "linux"
+	os.Len()
+}
+`)
+	require.NoError(t, err)
+
+	refs := make(map[string][]bool)
+	ast.Inspect(source.AST, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				refs[ident.Name] = append(refs[ident.Name], source.IsPackageRef(ident))
+			}
+		}
+		return true
+	})
+
+	assert.Equal(t, []bool{true, false}, refs["os"])
+	assert.Equal(t, []bool{false}, refs["db"])
+	assert.Equal(t, []bool{true}, refs["store"])
+}
@@ -0,0 +1,98 @@
+package astutil
+
+import (
+	"go/ast"
+	"go/types"
+	"path/filepath"
+)
+
+// PackageTypes is the type information of a scanned package.
+type PackageTypes struct {
+	// Package is the checked package.
+	Package *types.Package
+
+	// Info holds the types of the package's expressions and the objects of its identifiers.
+	Info *types.Info
+}
+
+// TypeOf returns the type of an expression, or nil if it could not be determined.
+func (p *PackageTypes) TypeOf(expr ast.Expr) types.Type {
+	if p == nil {
+		return nil
+	}
+	return p.Info.TypeOf(expr)
+}
+
+// ObjectOf returns the object an identifier declares or refers to, or nil if it could not be resolved.
+func (p *PackageTypes) ObjectOf(ident *ast.Ident) types.Object {
+	if p == nil {
+		return nil
+	}
+	return p.Info.ObjectOf(ident)
+}
+
+// Types type-checks the package of a scanned file and returns its type information. Packages of
+// the scanned project are checked from source; other imports are empty, so type errors are ignored
+// and only types declared in the project are complete. Results are cached per package.
+func (s *SourceSet) Types(path string) *PackageTypes {
+	if s.Get(path) == nil {
+		return nil
+	}
+	if s.types == nil {
+		s.types = make(map[string]*PackageTypes)
+	}
+	return s.checkPackage(filepath.Dir(path), s.Package(path))
+}
+
+// checkPackage type-checks the sources of a package directory.
+func (s *SourceSet) checkPackage(dir string, sources []*ParsedSource) *PackageTypes {
+	if checked, ok := s.types[dir]; ok {
+		return checked
+	}
+	if len(sources) == 0 {
+		return nil
+	}
+	// An import cycle sees the package as not yet checked
+	s.types[dir] = nil
+
+	// Files of another package in the same directory (package main_test) are left out
+	name := sources[0].AST.Name.Name
+	var files []*ast.File
+	for _, source := range sources {
+		if source.AST.Name.Name == name {
+			files = append(files, source.AST)
+		}
+	}
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	config := &types.Config{
+		Importer: sourceImporter{s},
+		Error:    func(error) {},
+	}
+	pkg, _ := config.Check(dir, sources[0].FileSet, files, info)
+
+	checked := &PackageTypes{Package: pkg, Info: info}
+	s.types[dir] = checked
+	return checked
+}
+
+// sourceImporter imports the packages of a SourceSet from source and any other package as empty.
+type sourceImporter struct {
+	sources *SourceSet
+}
+
+// Import returns the package for an import path.
+func (i sourceImporter) Import(path string) (*types.Package, error) {
+	if pkg := i.sources.PackageForImport(path); len(pkg) > 0 {
+		if checked := i.sources.checkPackage(filepath.Dir(pkg[0].FilePath), pkg); checked != nil && checked.Package != nil {
+			return checked.Package, nil
+		}
+	}
+	empty := types.NewPackage(path, defaultImportName(path))
+	empty.MarkComplete()
+	return empty, nil
+}
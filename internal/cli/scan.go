@@ -174,6 +174,9 @@ func filterFindingsBySeverity(result *models.ScanResult, minSeverity models.Seve
 	result.Findings = filtered
 }
 
+// applyFilters applies the endpoint filters to endpoints and findings.
+// Project-level findings are not tied to an endpoint, so endpoint filters keep them;
+// only the rule filter applies to them.
 func applyFilters(result *models.ScanResult) {
 	// Filter by classification
 	if len(classification) > 0 {
@@ -192,7 +195,7 @@ func applyFilters(result *models.ScanResult) {
 
 		filteredFindings := make([]*models.Finding, 0)
 		for _, f := range result.Findings {
-			if classSet[string(f.Endpoint.Classification)] {
+			if f.IsProjectLevel() || classSet[string(f.Endpoint.Classification)] {
 				filteredFindings = append(filteredFindings, f)
 			}
 		}
@@ -219,6 +222,10 @@ func applyFilters(result *models.ScanResult) {
 
 		filteredFindings := make([]*models.Finding, 0)
 		for _, f := range result.Findings {
+			if f.IsProjectLevel() {
+				filteredFindings = append(filteredFindings, f)
+				continue
+			}
 			for _, m := range f.Endpoint.Methods {
 				if methodSet[string(m)] {
 					filteredFindings = append(filteredFindings, f)
@@ -241,7 +248,7 @@ func applyFilters(result *models.ScanResult) {
 
 		filteredFindings := make([]*models.Finding, 0)
 		for _, f := range result.Findings {
-			if strings.Contains(f.Endpoint.FullRoute(), routeContains) {
+			if f.IsProjectLevel() || strings.Contains(f.Endpoint.FullRoute(), routeContains) {
 				filteredFindings = append(filteredFindings, f)
 			}
 		}
@@ -265,7 +272,7 @@ func applyFilters(result *models.ScanResult) {
 
 		filteredFindings := make([]*models.Finding, 0)
 		for _, f := range result.Findings {
-			if frameworkSet[string(f.Endpoint.Framework)] {
+			if f.IsProjectLevel() || frameworkSet[string(f.Endpoint.Framework)] {
 				filteredFindings = append(filteredFindings, f)
 			}
 		}
@@ -299,11 +306,11 @@ func sortFindings(result *models.ScanResult) {
 		case "severity":
 			less = result.Findings[i].Severity.Order() < result.Findings[j].Severity.Order()
 		case "route":
-			less = result.Findings[i].Endpoint.FullRoute() < result.Findings[j].Endpoint.FullRoute()
+			less = result.Findings[i].Route() < result.Findings[j].Route()
 		case "method":
-			less = result.Findings[i].Endpoint.DisplayMethods() < result.Findings[j].Endpoint.DisplayMethods()
+			less = result.Findings[i].DisplayMethods() < result.Findings[j].DisplayMethods()
 		case "classification":
-			less = string(result.Findings[i].Endpoint.Classification) < string(result.Findings[j].Endpoint.Classification)
+			less = findingClassification(result.Findings[i]) < findingClassification(result.Findings[j])
 		default:
 			less = result.Findings[i].Severity.Order() < result.Findings[j].Severity.Order()
 		}
@@ -314,3 +321,12 @@ func sortFindings(result *models.ScanResult) {
 		return less
 	})
 }
+
+// findingClassification returns the classification of a finding's endpoint,
+// or an empty string for project-level findings.
+func findingClassification(f *models.Finding) string {
+	if f.IsProjectLevel() {
+		return ""
+	}
+	return string(f.Endpoint.Classification)
+}
@@ -13,29 +13,39 @@ import (
 type SuppressionConfig struct {
 	RuleID       string `yaml:"rule"`
 	RoutePattern string `yaml:"route"`
+	FilePattern  string `yaml:"file"`
 	Reason       string `yaml:"reason"`
 }
 
 // Matches returns true if this suppression matches a finding.
-func (s *SuppressionConfig) Matches(ruleID, route string) bool {
+// Project-level findings have no route and can only be matched by rule and file.
+func (s *SuppressionConfig) Matches(ruleID, route, file string) bool {
 	if s.RuleID != ruleID && s.RuleID != "*" {
 		return false
 	}
 
-	if s.RoutePattern != "" {
-		re, err := regexp.Compile(s.RoutePattern)
-		if err != nil {
-			// Invalid regex, treat as literal match
-			return route == s.RoutePattern || contains(route, s.RoutePattern)
-		}
-		if !re.MatchString(route) {
-			return false
-		}
+	if s.RoutePattern != "" && !matchesPattern(s.RoutePattern, route) {
+		return false
+	}
+
+	if s.FilePattern != "" && !matchesPattern(s.FilePattern, file) {
+		return false
 	}
 
 	return true
 }
 
+// matchesPattern matches a value against a regex pattern, falling back to a
+// literal substring match if the pattern is not a valid regex.
+func matchesPattern(pattern, value string) bool {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		// Invalid regex, treat as literal match
+		return value == pattern || contains(value, pattern)
+	}
+	return re.MatchString(value)
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsSubstr(s, substr))
 }
@@ -66,6 +76,9 @@ type Config struct {
 	// AuthPatterns contains custom auth dependency patterns
 	AuthPatterns []string `yaml:"auth_patterns"`
 
+	// Analysis contains project-specific names used by the rules
+	Analysis AnalysisConfig `yaml:"analysis"`
+
 	// MinSeverity is the minimum severity to report
 	MinSeverity string `yaml:"min_severity"`
 }
@@ -76,6 +89,28 @@ type RulesConfig struct {
 	Disabled []string `yaml:"disabled"`
 }
 
+// AnalysisConfig contains project-specific middleware and function names
+// that the rules cannot recognise on their own.
+type AnalysisConfig struct {
+	// RateLimitMiddleware contains names of custom rate-limiting middleware
+	RateLimitMiddleware []string `yaml:"rate_limit_middleware"`
+
+	// PrivilegedFields contains names of struct fields that must not be bound from request bodies
+	PrivilegedFields []string `yaml:"privileged_fields"`
+
+	// PrincipalAccessors contains names of functions returning the current user
+	PrincipalAccessors []string `yaml:"principal_accessors"`
+
+	// MutatingFunctions contains names of functions that change state, for GET handlers calling them
+	MutatingFunctions []string `yaml:"mutating_functions"`
+
+	// AuthFunctions contains names of functions that check credentials, such as API key validators
+	AuthFunctions []string `yaml:"auth_functions"`
+
+	// WebhookVerifiers contains names of functions that verify webhook signatures
+	WebhookVerifiers []string `yaml:"webhook_verifiers"`
+}
+
 // NewConfig creates a new Config with default values.
 func NewConfig() *Config {
 	return &Config{
@@ -135,9 +170,9 @@ func (c *Config) IsRuleEnabled(ruleID string) bool {
 
 // IsSuppressed checks if a finding should be suppressed.
 // Returns (is_suppressed, reason).
-func (c *Config) IsSuppressed(ruleID, route string) (bool, string) {
+func (c *Config) IsSuppressed(ruleID, route, file string) (bool, string) {
 	for _, s := range c.Suppressions {
-		if s.Matches(ruleID, route) {
+		if s.Matches(ruleID, route, file) {
 			return true, s.Reason
 		}
 	}
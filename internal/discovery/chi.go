@@ -2,6 +2,7 @@ package discovery
 
 import (
 	"go/ast"
+	"go/token"
 	"strings"
 
 	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
@@ -48,8 +49,9 @@ func (d *ChiDiscoverer) CanHandle(source *astutil.ParsedSource) bool {
 func (d *ChiDiscoverer) Discover(source *astutil.ParsedSource) ([]*models.Endpoint, error) {
 	var endpoints []*models.Endpoint
 
-	// Find router groups (Route with subrouter)
-	groups := d.findGroups(source)
+	// Find router scopes (Route/Group callbacks) and the Use() middleware registered in each,
+	// so that sub-routers inherit their parent's prefix and middleware
+	scopes := d.findScopes(source)
 
 	// Find all route registrations
 	ast.Inspect(source.AST, func(n ast.Node) bool {
@@ -58,10 +60,11 @@ func (d *ChiDiscoverer) Discover(source *astutil.ParsedSource) ([]*models.Endpoi
 			return true
 		}
 
-		endpoint := d.extractEndpoint(call, source, groups)
+		endpoint := d.extractEndpoint(call, source, scopes)
 		if endpoint != nil {
 			endpoints = append(endpoints, endpoint)
 		}
+		endpoints = append(endpoints, d.implicitEndpoints(call, source, scopes)...)
 
 		return true
 	})
@@ -69,65 +72,170 @@ func (d *ChiDiscoverer) Discover(source *astutil.ParsedSource) ([]*models.Endpoi
 	return endpoints, nil
 }
 
-// ChiGroupInfo stores information about a Chi router group.
-type ChiGroupInfo struct {
-	Prefix     string
-	Middleware []string
-	VarName    string
+// chiScope is a function body in which router variables are registered: either a
+// named function or a Route()/Group() callback that receives a sub-router.
+type chiScope struct {
+	pos, end token.Pos
+
+	// routerVar is the sub-router parameter of a callback; empty for named functions.
+	routerVar string
+
+	// parentVar is the router the sub-router was created from.
+	parentVar string
+
+	// prefix is the path prefix of a Route() callback.
+	prefix string
+
+	parent     *chiScope
+	use        map[string][]string
+	conditions middlewareConditions
 }
 
-// findGroups finds all Chi Route() and Group() calls.
-func (d *ChiDiscoverer) findGroups(source *astutil.ParsedSource) map[string]*ChiGroupInfo {
-	groups := make(map[string]*ChiGroupInfo)
+// chiScopes indexes the router scopes of a file.
+type chiScopes struct {
+	list []*chiScope
+}
+
+// findScopes finds all router scopes of a file and the Use() middleware registered in each.
+// Middleware registered inside an if or switch branch is also recorded with its guard condition.
+func (d *ChiDiscoverer) findScopes(source *astutil.ParsedSource) *chiScopes {
+	scopes := &chiScopes{}
+	scopes.add(&chiScope{pos: source.AST.Pos(), end: source.AST.End()})
 
 	ast.Inspect(source.AST, func(n ast.Node) bool {
-		// Look for r.Route("/prefix", func(r chi.Router) { ... })
-		call, ok := n.(*ast.CallExpr)
-		if !ok {
-			return true
+		switch node := n.(type) {
+		case *ast.FuncDecl:
+			if node.Body != nil {
+				scopes.add(&chiScope{pos: node.Body.Pos(), end: node.Body.End()})
+			}
+		case *ast.CallExpr:
+			callName := astutil.GetCallName(node)
+			if !strings.HasSuffix(callName, ".Route") && !strings.HasSuffix(callName, ".Group") {
+				return true
+			}
+			prefix := ""
+			if strings.HasSuffix(callName, ".Route") && len(node.Args) > 0 {
+				prefix = astutil.GetStringValue(node.Args[0])
+			}
+			for _, arg := range node.Args {
+				funcLit, ok := arg.(*ast.FuncLit)
+				if !ok || funcLit.Type.Params == nil || len(funcLit.Type.Params.List) == 0 {
+					continue
+				}
+				param := funcLit.Type.Params.List[0]
+				if len(param.Names) == 0 {
+					continue
+				}
+				scopes.add(&chiScope{
+					pos:       funcLit.Body.Pos(),
+					end:       funcLit.Body.End(),
+					routerVar: param.Names[0].Name,
+					parentVar: strings.SplitN(callName, ".", 2)[0],
+					prefix:    prefix,
+				})
+			}
 		}
+		return true
+	})
 
-		callName := astutil.GetCallName(call)
-		if !strings.HasSuffix(callName, ".Route") && !strings.HasSuffix(callName, ".Group") {
-			return true
-		}
+	for _, s := range scopes.list {
+		s.parent = scopes.innermost(s.pos, s.end, s)
+	}
 
-		if len(call.Args) < 2 {
+	guards := astutil.NewConditionIndex(source.AST)
+	ast.Inspect(source.AST, func(n ast.Node) bool {
+		stmt, ok := n.(*ast.ExprStmt)
+		if !ok {
 			return true
 		}
-
-		prefix := astutil.GetStringValue(call.Args[0])
-
-		// Check if second arg is a function that takes a router parameter
-		funcLit, ok := call.Args[1].(*ast.FuncLit)
+		call, ok := stmt.X.(*ast.CallExpr)
 		if !ok {
 			return true
 		}
-
-		// Get the router parameter name
-		if funcLit.Type.Params == nil || len(funcLit.Type.Params.List) == 0 {
+		callName := astutil.GetCallName(call)
+		if !strings.HasSuffix(callName, ".Use") {
 			return true
 		}
-
-		param := funcLit.Type.Params.List[0]
-		if len(param.Names) == 0 {
+		parts := strings.SplitN(callName, ".", 2)
+		if len(parts) < 2 {
 			return true
 		}
-
-		routerVarName := param.Names[0].Name
-		groups[routerVarName] = &ChiGroupInfo{
-			Prefix:  prefix,
-			VarName: routerVarName,
+		receiverVar := parts[0]
+		owner := scopes.owner(scopes.innermost(stmt.Pos(), stmt.End(), nil), receiverVar)
+		condition := guards.ConditionFor(stmt)
+		for _, arg := range call.Args {
+			if fn := d.extractHandlerName(arg); fn != "" {
+				owner.use[receiverVar] = append(owner.use[receiverVar], fn)
+				owner.conditions.record(receiverVar, fn, condition)
+			}
 		}
-
 		return true
 	})
 
-	return groups
+	return scopes
+}
+
+// add registers a scope.
+func (s *chiScopes) add(scope *chiScope) {
+	scope.use = make(map[string][]string)
+	scope.conditions = make(middlewareConditions)
+	s.list = append(s.list, scope)
+}
+
+// innermost returns the smallest scope containing the range, ignoring exclude.
+func (s *chiScopes) innermost(pos, end token.Pos, exclude *chiScope) *chiScope {
+	var best *chiScope
+	for _, scope := range s.list {
+		if scope == exclude || pos < scope.pos || end > scope.end {
+			continue
+		}
+		if best == nil || scope.end-scope.pos < best.end-best.pos {
+			best = scope
+		}
+	}
+	return best
+}
+
+// owner walks up from a scope to the one that declares a router variable:
+// the callback whose parameter it is, or else the enclosing named function.
+func (s *chiScopes) owner(scope *chiScope, routerVar string) *chiScope {
+	for scope.parent != nil && scope.routerVar != "" && scope.routerVar != routerVar {
+		scope = scope.parent
+	}
+	return scope
+}
+
+// prefix returns the full path prefix of a router variable in a scope.
+func (s *chiScopes) prefix(scope *chiScope, routerVar string) string {
+	if scope.routerVar != routerVar || scope.parent == nil {
+		return ""
+	}
+	parent := s.prefix(s.owner(scope.parent, scope.parentVar), scope.parentVar)
+	return strings.TrimSuffix(parent, "/") + scope.prefix
+}
+
+// middleware returns the Use() middleware applied to a router variable in a scope,
+// including the middleware inherited from parent routers, outermost first.
+func (s *chiScopes) middleware(scope *chiScope, routerVar string) ([]string, map[string]string) {
+	var inherited []string
+	conditions := make(map[string]string)
+
+	if scope.routerVar == routerVar && scope.parent != nil {
+		parentMW, parentConds := s.middleware(s.owner(scope.parent, scope.parentVar), scope.parentVar)
+		inherited = parentMW
+		for mw, cond := range parentConds {
+			mergeCondition(conditions, mw, cond)
+		}
+	}
+	for mw, cond := range scope.conditions.forReceivers(routerVar) {
+		mergeCondition(conditions, mw, cond)
+	}
+
+	return middlewareChain(inherited, scope.use[routerVar]), conditions
 }
 
 // extractEndpoint extracts an endpoint from a route registration call.
-func (d *ChiDiscoverer) extractEndpoint(call *ast.CallExpr, source *astutil.ParsedSource, groups map[string]*ChiGroupInfo) *models.Endpoint {
+func (d *ChiDiscoverer) extractEndpoint(call *ast.CallExpr, source *astutil.ParsedSource, scopes *chiScopes) *models.Endpoint {
 	callName := astutil.GetCallName(call)
 	parts := strings.Split(callName, ".")
 
@@ -170,11 +278,11 @@ func (d *ChiDiscoverer) extractEndpoint(call *ast.CallExpr, source *astutil.Pars
 		}
 	}
 
-	return d.createEndpoint(call, source, groups, receiverVar, []models.HTTPMethod{httpMethod})
+	return d.createEndpoint(call, source, scopes, receiverVar, []models.HTTPMethod{httpMethod})
 }
 
 // createEndpoint creates an Endpoint from a route call.
-func (d *ChiDiscoverer) createEndpoint(call *ast.CallExpr, source *astutil.ParsedSource, groups map[string]*ChiGroupInfo, receiverVar string, methods []models.HTTPMethod) *models.Endpoint {
+func (d *ChiDiscoverer) createEndpoint(call *ast.CallExpr, source *astutil.ParsedSource, scopes *chiScopes, receiverVar string, methods []models.HTTPMethod) *models.Endpoint {
 	if len(call.Args) < 2 {
 		return nil
 	}
@@ -187,14 +295,14 @@ func (d *ChiDiscoverer) createEndpoint(call *ast.CallExpr, source *astutil.Parse
 	// Extract handler name (second argument)
 	handlerName := d.extractHandlerName(call.Args[1])
 
-	// Determine group prefix
-	prefix := ""
-	if group, ok := groups[receiverVar]; ok {
-		prefix = group.Prefix
-	}
+	// Determine group prefix from the enclosing Route() callbacks
+	scope := scopes.owner(scopes.innermost(call.Pos(), call.End(), nil), receiverVar)
+	prefix := scopes.prefix(scope, receiverVar)
 
 	// Extract authorization info (Chi uses Use() for middleware)
-	auth := d.authExtractor.Extract(nil, source)
+	allMiddleware, conditions := scopes.middleware(scope, receiverVar)
+	auth := d.authExtractor.Extract(allMiddleware, source)
+	applyConditions(&auth, conditions)
 
 	endpoint := &models.Endpoint{
 		Route:         route,
@@ -206,11 +314,36 @@ func (d *ChiDiscoverer) createEndpoint(call *ast.CallExpr, source *astutil.Parse
 		FunctionName:  handlerName,
 		Authorization: auth,
 		RouterPrefix:  prefix,
+		Middleware:    allMiddleware,
 	}
 
 	return endpoint
 }
 
+// implicitEndpoints returns the built-in routes of handlers mounted on a router
+// (e.g., r.Mount("/debug", middleware.Profiler()) serves /debug/pprof/* and /debug/vars).
+func (d *ChiDiscoverer) implicitEndpoints(call *ast.CallExpr, source *astutil.ParsedSource, scopes *chiScopes) []*models.Endpoint {
+	callName := astutil.GetCallName(call)
+	if !strings.HasSuffix(callName, ".Mount") || len(call.Args) < 2 {
+		return nil
+	}
+	receiverVar := strings.SplitN(callName, ".", 2)[0]
+	mountPath := strings.TrimSuffix(astutil.GetStringValue(call.Args[0]), "/")
+
+	var endpoints []*models.Endpoint
+	for _, route := range implicitRoutes(source, call.Args[1], mountedHandlers) {
+		path := mountPath + route
+		if path == "" {
+			path = "/"
+		}
+		endpoint := d.createEndpoint(syntheticRoute(call, receiverVar, "Get", path, call.Args[1]), source, scopes, receiverVar, []models.HTTPMethod{models.MethodGET})
+		if endpoint = markImplicit(endpoint, d.extractHandlerName(call.Args[1])); endpoint != nil {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
 // extractHandlerName extracts the function name from a handler argument.
 func (d *ChiDiscoverer) extractHandlerName(expr ast.Expr) string {
 	switch e := expr.(type) {
@@ -0,0 +1,57 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+)
+
+func TestChiDiscoverer_DiscoverWithSubrouterMiddleware(t *testing.T) {
+	discoverer := NewChiDiscoverer()
+	loader := astutil.NewSourceLoader()
+
+	code := `package main
+
+import "github.com/go-chi/chi/v5"
+
+func main() {
+	r := chi.NewRouter()
+	r.Use(logger)
+	r.Get("/health", health)
+
+	r.Route("/api", func(r chi.Router) {
+		r.Use(jwtAuth)
+		r.Get("/users", listUsers)
+
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(requireAdmin)
+			r.Delete("/users/{id}", deleteUser)
+		})
+	})
+}
+`
+
+	source, err := loader.ParseContent("test.go", code)
+	require.NoError(t, err)
+
+	endpoints, err := discoverer.Discover(source)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 3)
+
+	byRoute := make(map[string][]string)
+	for _, e := range endpoints {
+		byRoute[e.FullRoute()] = e.Middleware
+	}
+
+	// Sub-routers reuse the name "r" but only inherit middleware from their parents
+	assert.Equal(t, []string{"logger"}, byRoute["/health"])
+	assert.Equal(t, []string{"logger", "jwtAuth"}, byRoute["/api/users"])
+	assert.Equal(t, []string{"logger", "jwtAuth", "requireAdmin"}, byRoute["/api/admin/users/{id}"])
+
+	for _, e := range endpoints {
+		assert.Equal(t, e.FullRoute() != "/health", e.Authorization.RequiresAuth, e.FullRoute())
+	}
+}
@@ -0,0 +1,50 @@
+package discovery
+
+import (
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// middlewareConditions maps receiver variables to the guard condition of each
+// middleware registered on them. An empty condition means at least one
+// registration of the middleware is unconditional.
+type middlewareConditions map[string]map[string]string
+
+// record stores the condition guarding a middleware registration on a receiver.
+func (m middlewareConditions) record(receiverVar, middleware, condition string) {
+	if m[receiverVar] == nil {
+		m[receiverVar] = make(map[string]string)
+	}
+	mergeCondition(m[receiverVar], middleware, condition)
+}
+
+// forReceivers returns the combined middleware conditions of the given receivers.
+func (m middlewareConditions) forReceivers(receiverVars ...string) map[string]string {
+	result := make(map[string]string)
+	for _, v := range receiverVars {
+		for mw, cond := range m[v] {
+			mergeCondition(result, mw, cond)
+		}
+	}
+	return result
+}
+
+// mergeCondition adds a registration's condition for a middleware. The
+// middleware stays conditional only while every registration is guarded.
+func mergeCondition(conditions map[string]string, middleware, condition string) {
+	if existing, ok := conditions[middleware]; ok && (existing == "" || condition != "") {
+		return
+	}
+	conditions[middleware] = condition
+}
+
+// applyConditions records the guard conditions of auth dependencies on the auth info.
+func applyConditions(auth *models.AuthorizationInfo, conditions map[string]string) {
+	for _, dep := range auth.AuthDependencies {
+		if cond := conditions[dep]; cond != "" {
+			if auth.Conditions == nil {
+				auth.Conditions = make(map[string]string)
+			}
+			auth.Conditions[dep] = cond
+		}
+	}
+}
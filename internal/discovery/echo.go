@@ -51,6 +51,9 @@ func (d *EchoDiscoverer) Discover(source *astutil.ParsedSource) ([]*models.Endpo
 	// Find router groups
 	groups := d.findGroups(source)
 
+	// Collect Use() middleware per variable so that e.Use(auth) propagates to routes
+	useMiddleware, useConditions := d.findUseMiddleware(source)
+
 	// Find all route registrations
 	ast.Inspect(source.AST, func(n ast.Node) bool {
 		call, ok := n.(*ast.CallExpr)
@@ -58,10 +61,14 @@ func (d *EchoDiscoverer) Discover(source *astutil.ParsedSource) ([]*models.Endpo
 			return true
 		}
 
-		endpoint := d.extractEndpoint(call, source, groups)
+		endpoint := d.extractEndpoint(call, source, groups, useMiddleware, useConditions)
 		if endpoint != nil {
 			endpoints = append(endpoints, endpoint)
 		}
+		if endpoint := d.staticEndpoint(call, source, groups, useMiddleware, useConditions); endpoint != nil {
+			endpoints = append(endpoints, endpoint)
+		}
+		endpoints = append(endpoints, d.implicitEndpoints(call, source, groups, useMiddleware, useConditions)...)
 
 		return true
 	})
@@ -69,11 +76,50 @@ func (d *EchoDiscoverer) Discover(source *astutil.ParsedSource) ([]*models.Endpo
 	return endpoints, nil
 }
 
+// findUseMiddleware collects all .Use() calls and groups them by receiver variable.
+// Middleware registered inside an if or switch branch is also recorded with its guard condition.
+func (d *EchoDiscoverer) findUseMiddleware(source *astutil.ParsedSource) (map[string][]string, middlewareConditions) {
+	useMiddleware := make(map[string][]string)
+	conditions := make(middlewareConditions)
+	guards := astutil.NewConditionIndex(source.AST)
+
+	ast.Inspect(source.AST, func(n ast.Node) bool {
+		stmt, ok := n.(*ast.ExprStmt)
+		if !ok {
+			return true
+		}
+		call, ok := stmt.X.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		callName := astutil.GetCallName(call)
+		if !strings.HasSuffix(callName, ".Use") {
+			return true
+		}
+		parts := strings.SplitN(callName, ".", 2)
+		if len(parts) < 2 {
+			return true
+		}
+		receiverVar := parts[0]
+		condition := guards.ConditionFor(stmt)
+		for _, arg := range call.Args {
+			if fn := d.extractHandlerName(arg); fn != "" {
+				useMiddleware[receiverVar] = append(useMiddleware[receiverVar], fn)
+				conditions.record(receiverVar, fn, condition)
+			}
+		}
+		return true
+	})
+
+	return useMiddleware, conditions
+}
+
 // EchoGroupInfo stores information about an Echo router group.
 type EchoGroupInfo struct {
 	Prefix     string
 	Middleware []string
 	VarName    string
+	ParentVar  string
 }
 
 // findGroups finds all Echo Group() calls and their prefixes/middleware.
@@ -109,6 +155,13 @@ func (d *EchoDiscoverer) findGroups(source *astutil.ParsedSource) map[string]*Ec
 			VarName: ident.Name,
 		}
 
+		// Capture the receiver variable (e.g. for `api := e.Group(...)`, parentVar = "e")
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+			if parentIdent, ok2 := sel.X.(*ast.Ident); ok2 {
+				group.ParentVar = parentIdent.Name
+			}
+		}
+
 		// Extract prefix
 		if len(call.Args) > 0 {
 			group.Prefix = astutil.GetStringValue(call.Args[0])
@@ -129,7 +182,7 @@ func (d *EchoDiscoverer) findGroups(source *astutil.ParsedSource) map[string]*Ec
 }
 
 // extractEndpoint extracts an endpoint from a route registration call.
-func (d *EchoDiscoverer) extractEndpoint(call *ast.CallExpr, source *astutil.ParsedSource, groups map[string]*EchoGroupInfo) *models.Endpoint {
+func (d *EchoDiscoverer) extractEndpoint(call *ast.CallExpr, source *astutil.ParsedSource, groups map[string]*EchoGroupInfo, useMiddleware map[string][]string, useConditions middlewareConditions) *models.Endpoint {
 	callName := astutil.GetCallName(call)
 	parts := strings.Split(callName, ".")
 
@@ -154,7 +207,7 @@ func (d *EchoDiscoverer) extractEndpoint(call *ast.CallExpr, source *astutil.Par
 			call.Args = call.Args[1:]
 		} else if methodName == "Any" {
 			// Any() matches all methods
-			return d.createEndpoint(call, source, groups, receiverVar,
+			return d.createEndpoint(call, source, groups, useMiddleware, useConditions, receiverVar,
 				[]models.HTTPMethod{models.MethodGET, models.MethodPOST, models.MethodPUT,
 					models.MethodDELETE, models.MethodPATCH, models.MethodHEAD, models.MethodOPTIONS})
 		} else {
@@ -162,11 +215,11 @@ func (d *EchoDiscoverer) extractEndpoint(call *ast.CallExpr, source *astutil.Par
 		}
 	}
 
-	return d.createEndpoint(call, source, groups, receiverVar, []models.HTTPMethod{httpMethod})
+	return d.createEndpoint(call, source, groups, useMiddleware, useConditions, receiverVar, []models.HTTPMethod{httpMethod})
 }
 
 // createEndpoint creates an Endpoint from a route call.
-func (d *EchoDiscoverer) createEndpoint(call *ast.CallExpr, source *astutil.ParsedSource, groups map[string]*EchoGroupInfo, receiverVar string, methods []models.HTTPMethod) *models.Endpoint {
+func (d *EchoDiscoverer) createEndpoint(call *ast.CallExpr, source *astutil.ParsedSource, groups map[string]*EchoGroupInfo, useMiddleware map[string][]string, useConditions middlewareConditions, receiverVar string, methods []models.HTTPMethod) *models.Endpoint {
 	if len(call.Args) < 2 {
 		return nil
 	}
@@ -190,14 +243,22 @@ func (d *EchoDiscoverer) createEndpoint(call *ast.CallExpr, source *astutil.Pars
 	// Determine group prefix and middleware
 	prefix := ""
 	var groupMiddleware []string
+	conditionVars := []string{receiverVar}
 	if group, ok := groups[receiverVar]; ok {
 		prefix = group.Prefix
 		groupMiddleware = group.Middleware
+
+		// Prepend Use() middleware from the parent router
+		if group.ParentVar != "" {
+			groupMiddleware = middlewareChain(useMiddleware[group.ParentVar], groupMiddleware)
+			conditionVars = append(conditionVars, group.ParentVar)
+		}
 	}
 
-	// Extract authorization info
-	allMiddleware := append(groupMiddleware, middleware...)
+	// Extract authorization info: Use()-based MW comes first, then group MW, then inline MW
+	allMiddleware := middlewareChain(useMiddleware[receiverVar], groupMiddleware, middleware)
 	auth := d.authExtractor.Extract(allMiddleware, source)
+	applyConditions(&auth, useConditions.forReceivers(conditionVars...))
 
 	endpoint := &models.Endpoint{
 		Route:         route,
@@ -209,11 +270,55 @@ func (d *EchoDiscoverer) createEndpoint(call *ast.CallExpr, source *astutil.Pars
 		FunctionName:  handlerName,
 		Authorization: auth,
 		RouterPrefix:  prefix,
+		Middleware:    allMiddleware,
 	}
 
 	return endpoint
 }
 
+// staticEndpoint returns the GET endpoint of a static file registration, with the served
+// directory recorded in its metadata.
+func (d *EchoDiscoverer) staticEndpoint(call *ast.CallExpr, source *astutil.ParsedSource, groups map[string]*EchoGroupInfo, useMiddleware map[string][]string, useConditions middlewareConditions) *models.Endpoint {
+	static := parseStaticRoute(source, call, echoStaticMethods, "/*")
+	if static == nil {
+		return nil
+	}
+
+	route := syntheticRoute(call, static.receiverVar, "GET", static.route, call)
+	return static.mark(d.createEndpoint(route, source, groups, useMiddleware, useConditions, static.receiverVar, []models.HTTPMethod{models.MethodGET}))
+}
+
+// implicitEndpoints returns the routes registered by helpers that take the router as argument
+// (e.g., pprof.Register(r) serves /debug/pprof/*) and the routes answered by proxy middleware
+// registered with Use() (e.g., g.Use(middleware.Proxy(balancer)) forwards every method).
+func (d *EchoDiscoverer) implicitEndpoints(call *ast.CallExpr, source *astutil.ParsedSource, groups map[string]*EchoGroupInfo, useMiddleware map[string][]string, useConditions middlewareConditions) []*models.Endpoint {
+	if callName := astutil.GetCallName(call); strings.HasSuffix(callName, ".Use") {
+		receiverVar := strings.SplitN(callName, ".", 2)[0]
+		var endpoints []*models.Endpoint
+		for _, arg := range call.Args {
+			for _, route := range implicitRoutes(source, arg, proxyMiddleware) {
+				route := syntheticRoute(call, receiverVar, "Any", route, arg)
+				endpoint := d.createEndpoint(route, source, groups, useMiddleware, useConditions, receiverVar, allMethods)
+				if endpoint = markImplicit(endpoint, d.extractHandlerName(arg)); endpoint != nil {
+					endpoints = append(endpoints, endpoint)
+				}
+			}
+		}
+		return endpoints
+	}
+
+	receiverVar, route := implicitRegistration(source, call)
+	if receiverVar == "" {
+		return nil
+	}
+
+	endpoint := d.createEndpoint(syntheticRoute(call, receiverVar, "GET", route, call), source, groups, useMiddleware, useConditions, receiverVar, []models.HTTPMethod{models.MethodGET})
+	if endpoint = markImplicit(endpoint, astutil.GetCallName(call)); endpoint == nil {
+		return nil
+	}
+	return []*models.Endpoint{endpoint}
+}
+
 // extractHandlerName extracts the function name from a handler argument.
 func (d *EchoDiscoverer) extractHandlerName(expr ast.Expr) string {
 	switch e := expr.(type) {
@@ -0,0 +1,50 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+)
+
+func TestEchoDiscoverer_DiscoverWithUseMiddleware(t *testing.T) {
+	discoverer := NewEchoDiscoverer()
+	loader := astutil.NewSourceLoader()
+
+	code := `package main
+
+import "github.com/labstack/echo/v4"
+
+func main() {
+	e := echo.New()
+	e.Use(middleware.CORS())
+	e.GET("/health", health)
+
+	api := e.Group("/api")
+	api.Use(jwtAuth)
+	api.GET("/users", listUsers)
+}
+`
+
+	source, err := loader.ParseContent("test.go", code)
+	require.NoError(t, err)
+
+	endpoints, err := discoverer.Discover(source)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 2)
+
+	for _, e := range endpoints {
+		switch e.FullRoute() {
+		case "/health":
+			assert.Equal(t, []string{"middleware.CORS"}, e.Middleware)
+			assert.False(t, e.Authorization.RequiresAuth)
+		case "/api/users":
+			assert.Equal(t, []string{"jwtAuth", "middleware.CORS"}, e.Middleware)
+			assert.True(t, e.Authorization.RequiresAuth)
+		default:
+			t.Errorf("unexpected endpoint %s", e.FullRoute())
+		}
+	}
+}
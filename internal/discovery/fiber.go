@@ -52,7 +52,7 @@ func (d *FiberDiscoverer) Discover(source *astutil.ParsedSource) ([]*models.Endp
 	groups := d.findGroups(source)
 
 	// Collect Use() middleware per variable so that app.Use(auth) propagates to routes
-	useMiddleware := d.findUseMiddleware(source)
+	useMiddleware, useConditions := d.findUseMiddleware(source)
 
 	// Find all route registrations
 	ast.Inspect(source.AST, func(n ast.Node) bool {
@@ -61,10 +61,14 @@ func (d *FiberDiscoverer) Discover(source *astutil.ParsedSource) ([]*models.Endp
 			return true
 		}
 
-		endpoint := d.extractEndpoint(call, source, groups, useMiddleware)
+		endpoint := d.extractEndpoint(call, source, groups, useMiddleware, useConditions)
 		if endpoint != nil {
 			endpoints = append(endpoints, endpoint)
 		}
+		if endpoint := d.staticEndpoint(call, source, groups, useMiddleware, useConditions); endpoint != nil {
+			endpoints = append(endpoints, endpoint)
+		}
+		endpoints = append(endpoints, d.implicitEndpoints(call, source, groups, useMiddleware, useConditions)...)
 
 		return true
 	})
@@ -73,8 +77,11 @@ func (d *FiberDiscoverer) Discover(source *astutil.ParsedSource) ([]*models.Endp
 }
 
 // findUseMiddleware collects all .Use() calls and groups them by receiver variable.
-func (d *FiberDiscoverer) findUseMiddleware(source *astutil.ParsedSource) map[string][]string {
+// Middleware registered inside an if or switch branch is also recorded with its guard condition.
+func (d *FiberDiscoverer) findUseMiddleware(source *astutil.ParsedSource) (map[string][]string, middlewareConditions) {
 	useMiddleware := make(map[string][]string)
+	conditions := make(middlewareConditions)
+	guards := astutil.NewConditionIndex(source.AST)
 
 	ast.Inspect(source.AST, func(n ast.Node) bool {
 		stmt, ok := n.(*ast.ExprStmt)
@@ -94,15 +101,17 @@ func (d *FiberDiscoverer) findUseMiddleware(source *astutil.ParsedSource) map[st
 			return true
 		}
 		receiverVar := parts[0]
+		condition := guards.ConditionFor(stmt)
 		for _, arg := range call.Args {
 			if fn := d.extractHandlerName(arg); fn != "" {
 				useMiddleware[receiverVar] = append(useMiddleware[receiverVar], fn)
+				conditions.record(receiverVar, fn, condition)
 			}
 		}
 		return true
 	})
 
-	return useMiddleware
+	return useMiddleware, conditions
 }
 
 // FiberGroupInfo stores information about a Fiber router group.
@@ -173,7 +182,7 @@ func (d *FiberDiscoverer) findGroups(source *astutil.ParsedSource) map[string]*F
 }
 
 // extractEndpoint extracts an endpoint from a route registration call.
-func (d *FiberDiscoverer) extractEndpoint(call *ast.CallExpr, source *astutil.ParsedSource, groups map[string]*FiberGroupInfo, useMiddleware map[string][]string) *models.Endpoint {
+func (d *FiberDiscoverer) extractEndpoint(call *ast.CallExpr, source *astutil.ParsedSource, groups map[string]*FiberGroupInfo, useMiddleware map[string][]string, useConditions middlewareConditions) *models.Endpoint {
 	callName := astutil.GetCallName(call)
 	parts := strings.Split(callName, ".")
 
@@ -209,7 +218,7 @@ func (d *FiberDiscoverer) extractEndpoint(call *ast.CallExpr, source *astutil.Pa
 			call.Args = call.Args[1:]
 		} else if methodName == "All" {
 			// All() matches all methods
-			return d.createEndpoint(call, source, groups, useMiddleware, receiverVar,
+			return d.createEndpoint(call, source, groups, useMiddleware, useConditions, receiverVar,
 				[]models.HTTPMethod{models.MethodGET, models.MethodPOST, models.MethodPUT,
 					models.MethodDELETE, models.MethodPATCH, models.MethodHEAD, models.MethodOPTIONS})
 		} else {
@@ -217,11 +226,11 @@ func (d *FiberDiscoverer) extractEndpoint(call *ast.CallExpr, source *astutil.Pa
 		}
 	}
 
-	return d.createEndpoint(call, source, groups, useMiddleware, receiverVar, []models.HTTPMethod{httpMethod})
+	return d.createEndpoint(call, source, groups, useMiddleware, useConditions, receiverVar, []models.HTTPMethod{httpMethod})
 }
 
 // createEndpoint creates an Endpoint from a route call.
-func (d *FiberDiscoverer) createEndpoint(call *ast.CallExpr, source *astutil.ParsedSource, groups map[string]*FiberGroupInfo, useMiddleware map[string][]string, receiverVar string, methods []models.HTTPMethod) *models.Endpoint {
+func (d *FiberDiscoverer) createEndpoint(call *ast.CallExpr, source *astutil.ParsedSource, groups map[string]*FiberGroupInfo, useMiddleware map[string][]string, useConditions middlewareConditions, receiverVar string, methods []models.HTTPMethod) *models.Endpoint {
 	if len(call.Args) < 2 {
 		return nil
 	}
@@ -245,6 +254,7 @@ func (d *FiberDiscoverer) createEndpoint(call *ast.CallExpr, source *astutil.Par
 	// Determine group prefix and middleware
 	prefix := ""
 	var groupMiddleware []string
+	conditionVars := []string{receiverVar}
 	if group, ok := groups[receiverVar]; ok {
 		prefix = group.Prefix
 		groupMiddleware = group.Middleware
@@ -252,8 +262,9 @@ func (d *FiberDiscoverer) createEndpoint(call *ast.CallExpr, source *astutil.Par
 		// Prepend Use() middleware from parent group, then from this group
 		if group.ParentVar != "" {
 			if parentUseMW, ok2 := useMiddleware[group.ParentVar]; ok2 {
-				groupMiddleware = append(parentUseMW, groupMiddleware...)
+				groupMiddleware = middlewareChain(parentUseMW, groupMiddleware)
 			}
+			conditionVars = append(conditionVars, group.ParentVar)
 		}
 	}
 
@@ -264,8 +275,9 @@ func (d *FiberDiscoverer) createEndpoint(call *ast.CallExpr, source *astutil.Par
 	}
 
 	// Extract authorization info: Use()-based MW comes first, then group MW, then inline MW
-	allMiddleware := append(useMW, append(groupMiddleware, middleware...)...)
+	allMiddleware := middlewareChain(useMW, groupMiddleware, middleware)
 	auth := d.authExtractor.Extract(allMiddleware, source)
+	applyConditions(&auth, useConditions.forReceivers(conditionVars...))
 
 	endpoint := &models.Endpoint{
 		Route:         route,
@@ -277,11 +289,60 @@ func (d *FiberDiscoverer) createEndpoint(call *ast.CallExpr, source *astutil.Par
 		FunctionName:  handlerName,
 		Authorization: auth,
 		RouterPrefix:  prefix,
+		Middleware:    allMiddleware,
 	}
 
 	return endpoint
 }
 
+// staticEndpoint returns the GET endpoint of a static file registration, with the served
+// directory recorded in its metadata.
+func (d *FiberDiscoverer) staticEndpoint(call *ast.CallExpr, source *astutil.ParsedSource, groups map[string]*FiberGroupInfo, useMiddleware map[string][]string, useConditions middlewareConditions) *models.Endpoint {
+	static := parseStaticRoute(source, call, fiberStaticMethods, "/*")
+	if static == nil {
+		return nil
+	}
+
+	route := syntheticRoute(call, static.receiverVar, "Get", static.route, call)
+	return static.mark(d.createEndpoint(route, source, groups, useMiddleware, useConditions, static.receiverVar, []models.HTTPMethod{models.MethodGET}))
+}
+
+// implicitEndpoints returns the routes answered by middleware registered with Use()
+// (e.g., app.Use(pprof.New()) serves /debug/pprof/*, app.Use("/api", proxy.Balancer(cfg))
+// forwards every method below /api).
+func (d *FiberDiscoverer) implicitEndpoints(call *ast.CallExpr, source *astutil.ParsedSource, groups map[string]*FiberGroupInfo, useMiddleware map[string][]string, useConditions middlewareConditions) []*models.Endpoint {
+	callName := astutil.GetCallName(call)
+	if !strings.HasSuffix(callName, ".Use") {
+		return nil
+	}
+	receiverVar := strings.SplitN(callName, ".", 2)[0]
+
+	// Use() takes an optional path prefix before the middleware
+	prefix := ""
+	if len(call.Args) > 0 {
+		prefix = strings.TrimSuffix(astutil.GetStringValue(call.Args[0]), "/")
+	}
+
+	var endpoints []*models.Endpoint
+	for _, arg := range call.Args {
+		for _, route := range implicitRoutes(source, arg, servingMiddleware) {
+			route := syntheticRoute(call, receiverVar, "Get", route, arg)
+			endpoint := d.createEndpoint(route, source, groups, useMiddleware, useConditions, receiverVar, []models.HTTPMethod{models.MethodGET})
+			if endpoint = markImplicit(endpoint, d.extractHandlerName(arg)); endpoint != nil {
+				endpoints = append(endpoints, endpoint)
+			}
+		}
+		for _, route := range implicitRoutes(source, arg, proxyMiddleware) {
+			route := syntheticRoute(call, receiverVar, "All", prefix+route, arg)
+			endpoint := d.createEndpoint(route, source, groups, useMiddleware, useConditions, receiverVar, allMethods)
+			if endpoint = markImplicit(endpoint, d.extractHandlerName(arg)); endpoint != nil {
+				endpoints = append(endpoints, endpoint)
+			}
+		}
+	}
+	return endpoints
+}
+
 // extractHandlerName extracts the function name from a handler argument.
 func (d *FiberDiscoverer) extractHandlerName(expr ast.Expr) string {
 	switch e := expr.(type) {
@@ -52,7 +52,7 @@ func (d *GinDiscoverer) Discover(source *astutil.ParsedSource) ([]*models.Endpoi
 	groups := d.findGroups(source)
 
 	// Collect Use() middleware per variable so that router.Use(auth) propagates to routes
-	useMiddleware := d.findUseMiddleware(source)
+	useMiddleware, useConditions := d.findUseMiddleware(source)
 
 	// Find all route registrations
 	ast.Inspect(source.AST, func(n ast.Node) bool {
@@ -61,10 +61,14 @@ func (d *GinDiscoverer) Discover(source *astutil.ParsedSource) ([]*models.Endpoi
 			return true
 		}
 
-		endpoint := d.extractEndpoint(call, source, groups, useMiddleware)
+		endpoint := d.extractEndpoint(call, source, groups, useMiddleware, useConditions)
 		if endpoint != nil {
 			endpoints = append(endpoints, endpoint)
 		}
+		if endpoint := d.staticEndpoint(call, source, groups, useMiddleware, useConditions); endpoint != nil {
+			endpoints = append(endpoints, endpoint)
+		}
+		endpoints = append(endpoints, d.implicitEndpoints(call, source, groups, useMiddleware, useConditions)...)
 
 		return true
 	})
@@ -73,8 +77,11 @@ func (d *GinDiscoverer) Discover(source *astutil.ParsedSource) ([]*models.Endpoi
 }
 
 // findUseMiddleware collects all .Use() calls and groups them by receiver variable.
-func (d *GinDiscoverer) findUseMiddleware(source *astutil.ParsedSource) map[string][]string {
+// Middleware registered inside an if or switch branch is also recorded with its guard condition.
+func (d *GinDiscoverer) findUseMiddleware(source *astutil.ParsedSource) (map[string][]string, middlewareConditions) {
 	useMiddleware := make(map[string][]string)
+	conditions := make(middlewareConditions)
+	guards := astutil.NewConditionIndex(source.AST)
 
 	ast.Inspect(source.AST, func(n ast.Node) bool {
 		stmt, ok := n.(*ast.ExprStmt)
@@ -94,15 +101,17 @@ func (d *GinDiscoverer) findUseMiddleware(source *astutil.ParsedSource) map[stri
 			return true
 		}
 		receiverVar := parts[0]
+		condition := guards.ConditionFor(stmt)
 		for _, arg := range call.Args {
 			if fn := d.extractHandlerName(arg); fn != "" {
 				useMiddleware[receiverVar] = append(useMiddleware[receiverVar], fn)
+				conditions.record(receiverVar, fn, condition)
 			}
 		}
 		return true
 	})
 
-	return useMiddleware
+	return useMiddleware, conditions
 }
 
 // GroupInfo stores information about a Gin router group.
@@ -173,7 +182,7 @@ func (d *GinDiscoverer) findGroups(source *astutil.ParsedSource) map[string]*Gro
 }
 
 // extractEndpoint extracts an endpoint from a route registration call.
-func (d *GinDiscoverer) extractEndpoint(call *ast.CallExpr, source *astutil.ParsedSource, groups map[string]*GroupInfo, useMiddleware map[string][]string) *models.Endpoint {
+func (d *GinDiscoverer) extractEndpoint(call *ast.CallExpr, source *astutil.ParsedSource, groups map[string]*GroupInfo, useMiddleware map[string][]string, useConditions middlewareConditions) *models.Endpoint {
 	callName := astutil.GetCallName(call)
 	parts := strings.Split(callName, ".")
 
@@ -198,7 +207,7 @@ func (d *GinDiscoverer) extractEndpoint(call *ast.CallExpr, source *astutil.Pars
 			call.Args = call.Args[1:]
 		} else if methodName == "Any" {
 			// Any() matches all methods
-			return d.createEndpoint(call, source, groups, useMiddleware, receiverVar,
+			return d.createEndpoint(call, source, groups, useMiddleware, useConditions, receiverVar,
 				[]models.HTTPMethod{models.MethodGET, models.MethodPOST, models.MethodPUT,
 					models.MethodDELETE, models.MethodPATCH, models.MethodHEAD, models.MethodOPTIONS})
 		} else {
@@ -206,11 +215,11 @@ func (d *GinDiscoverer) extractEndpoint(call *ast.CallExpr, source *astutil.Pars
 		}
 	}
 
-	return d.createEndpoint(call, source, groups, useMiddleware, receiverVar, []models.HTTPMethod{httpMethod})
+	return d.createEndpoint(call, source, groups, useMiddleware, useConditions, receiverVar, []models.HTTPMethod{httpMethod})
 }
 
 // createEndpoint creates an Endpoint from a route call.
-func (d *GinDiscoverer) createEndpoint(call *ast.CallExpr, source *astutil.ParsedSource, groups map[string]*GroupInfo, useMiddleware map[string][]string, receiverVar string, methods []models.HTTPMethod) *models.Endpoint {
+func (d *GinDiscoverer) createEndpoint(call *ast.CallExpr, source *astutil.ParsedSource, groups map[string]*GroupInfo, useMiddleware map[string][]string, useConditions middlewareConditions, receiverVar string, methods []models.HTTPMethod) *models.Endpoint {
 	if len(call.Args) < 1 {
 		return nil
 	}
@@ -237,6 +246,7 @@ func (d *GinDiscoverer) createEndpoint(call *ast.CallExpr, source *astutil.Parse
 	// Determine group prefix and middleware
 	prefix := ""
 	var groupMiddleware []string
+	conditionVars := []string{receiverVar}
 	if group, ok := groups[receiverVar]; ok {
 		prefix = group.Prefix
 		groupMiddleware = group.Middleware
@@ -244,8 +254,9 @@ func (d *GinDiscoverer) createEndpoint(call *ast.CallExpr, source *astutil.Parse
 		// Prepend Use() middleware from parent group, then from this group
 		if group.ParentVar != "" {
 			if parentUseMW, ok2 := useMiddleware[group.ParentVar]; ok2 {
-				groupMiddleware = append(parentUseMW, groupMiddleware...)
+				groupMiddleware = middlewareChain(parentUseMW, groupMiddleware)
 			}
+			conditionVars = append(conditionVars, group.ParentVar)
 		}
 	}
 
@@ -256,8 +267,9 @@ func (d *GinDiscoverer) createEndpoint(call *ast.CallExpr, source *astutil.Parse
 	}
 
 	// Extract authorization info: Use()-based MW comes first, then group MW, then inline MW
-	allMiddleware := append(useMW, append(groupMiddleware, middleware...)...)
+	allMiddleware := middlewareChain(useMW, groupMiddleware, middleware)
 	auth := d.authExtractor.Extract(allMiddleware, source)
+	applyConditions(&auth, useConditions.forReceivers(conditionVars...))
 
 	endpoint := &models.Endpoint{
 		Route:         route,
@@ -269,11 +281,39 @@ func (d *GinDiscoverer) createEndpoint(call *ast.CallExpr, source *astutil.Parse
 		FunctionName:  handlerName,
 		Authorization: auth,
 		RouterPrefix:  prefix,
+		Middleware:    allMiddleware,
 	}
 
 	return endpoint
 }
 
+// staticEndpoint returns the GET endpoint of a static file registration, with the served
+// directory recorded in its metadata.
+func (d *GinDiscoverer) staticEndpoint(call *ast.CallExpr, source *astutil.ParsedSource, groups map[string]*GroupInfo, useMiddleware map[string][]string, useConditions middlewareConditions) *models.Endpoint {
+	static := parseStaticRoute(source, call, ginStaticMethods, "/*filepath")
+	if static == nil {
+		return nil
+	}
+
+	route := syntheticRoute(call, static.receiverVar, "GET", static.route, call)
+	return static.mark(d.createEndpoint(route, source, groups, useMiddleware, useConditions, static.receiverVar, []models.HTTPMethod{models.MethodGET}))
+}
+
+// implicitEndpoints returns the routes registered by helpers that take the router as argument
+// (e.g., pprof.Register(r) serves /debug/pprof/*).
+func (d *GinDiscoverer) implicitEndpoints(call *ast.CallExpr, source *astutil.ParsedSource, groups map[string]*GroupInfo, useMiddleware map[string][]string, useConditions middlewareConditions) []*models.Endpoint {
+	receiverVar, route := implicitRegistration(source, call)
+	if receiverVar == "" {
+		return nil
+	}
+
+	endpoint := d.createEndpoint(syntheticRoute(call, receiverVar, "GET", route, call), source, groups, useMiddleware, useConditions, receiverVar, []models.HTTPMethod{models.MethodGET})
+	if endpoint = markImplicit(endpoint, astutil.GetCallName(call)); endpoint == nil {
+		return nil
+	}
+	return []*models.Endpoint{endpoint}
+}
+
 // extractHandlerName extracts the function name from a handler argument.
 func (d *GinDiscoverer) extractHandlerName(expr ast.Expr) string {
 	switch e := expr.(type) {
@@ -220,3 +220,92 @@ func jwtAuth(c *gin.Context) {}
 		}
 	}
 }
+
+func TestGinDiscoverer_DiscoverWithConditionalMiddleware(t *testing.T) {
+	discoverer := NewGinDiscoverer()
+	loader := astutil.NewSourceLoader()
+
+	code := `package main
+
+import (
+	"os"
+
+	"github.com/gin-gonic/gin"
+)
+
+func main() {
+	r := gin.Default()
+	r.Use(loggingMiddleware)
+
+	api := r.Group("/api")
+	if os.Getenv("ENV") != "dev" {
+		api.Use(authMiddleware)
+	}
+	api.GET("/users", listUsers)
+
+	admin := r.Group("/admin")
+	admin.Use(authMiddleware)
+	admin.GET("/stats", getStats)
+}
+
+func listUsers(c *gin.Context) {}
+func getStats(c *gin.Context) {}
+func authMiddleware(c *gin.Context) {}
+func loggingMiddleware(c *gin.Context) {}
+`
+
+	source, err := loader.ParseContent("test.go", code)
+	require.NoError(t, err)
+
+	endpoints, err := discoverer.Discover(source)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 2)
+
+	for _, e := range endpoints {
+		switch e.Route {
+		case "/users":
+			assert.Equal(t, `os.Getenv("ENV") != "dev"`, e.Authorization.Conditions["authMiddleware"])
+			assert.True(t, e.Authorization.IsConditional())
+		case "/stats":
+			assert.Empty(t, e.Authorization.Conditions)
+			assert.False(t, e.Authorization.IsConditional())
+		}
+	}
+}
+
+func TestGinDiscoverer_DiscoverWithGuardedAndUnconditionalMiddleware(t *testing.T) {
+	discoverer := NewGinDiscoverer()
+	loader := astutil.NewSourceLoader()
+
+	code := `package main
+
+import (
+	"os"
+
+	"github.com/gin-gonic/gin"
+)
+
+func main() {
+	r := gin.Default()
+	api := r.Group("/api")
+	if os.Getenv("STRICT") == "1" {
+		api.Use(authMiddleware)
+	}
+	api.Use(authMiddleware)
+	api.GET("/users", listUsers)
+}
+
+func listUsers(c *gin.Context) {}
+func authMiddleware(c *gin.Context) {}
+`
+
+	source, err := loader.ParseContent("test.go", code)
+	require.NoError(t, err)
+
+	endpoints, err := discoverer.Discover(source)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+
+	assert.Empty(t, endpoints[0].Authorization.Conditions)
+	assert.False(t, endpoints[0].Authorization.IsConditional())
+}
@@ -0,0 +1,209 @@
+package discovery
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// implicitImports maps packages that register handlers on http.DefaultServeMux
+// as a side effect of being imported to the routes they register.
+var implicitImports = map[string][]string{
+	"net/http/pprof": {"/debug/pprof/*"},
+	"expvar":         {"/debug/vars"},
+}
+
+// mountedHandlers maps import paths to handler constructors whose handler serves built-in
+// routes below the path it is mounted at ("" is the mount path itself).
+var mountedHandlers = map[string]map[string][]string{
+	"github.com/go-chi/chi/v5/middleware": {
+		"Profiler": {"/pprof/*", "/vars"},
+	},
+	"github.com/prometheus/client_golang/prometheus/promhttp": {
+		"Handler":    {""},
+		"HandlerFor": {""},
+	},
+}
+
+// registeringFuncs maps import paths to functions that register built-in routes on the router
+// passed as their first argument, below the default prefix or the one passed as second argument.
+var registeringFuncs = map[string]map[string]string{
+	"github.com/gin-contrib/pprof": {
+		"Register":      "/debug/pprof",
+		"RouteRegister": "/debug/pprof",
+	},
+	"github.com/labstack/echo-contrib/pprof": {
+		"Register": "/debug/pprof",
+	},
+}
+
+// servingMiddleware maps import paths to middleware constructors that answer built-in
+// routes themselves when registered with Use().
+var servingMiddleware = map[string]map[string][]string{
+	"github.com/gofiber/fiber/v2/middleware/pprof": {
+		"New": {"/debug/pprof/*"},
+	},
+}
+
+// implicitRoutes returns the routes served by a call to one of the constructors in a table.
+func implicitRoutes(source *astutil.ParsedSource, expr ast.Expr, table map[string]map[string][]string) []string {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+	name := astutil.GetCallName(call)
+	for importPath, funcs := range table {
+		alias := source.GetImportAlias(importPath)
+		if alias == "" || !strings.HasPrefix(name, alias+".") {
+			continue
+		}
+		if routes, ok := funcs[strings.TrimPrefix(name, alias+".")]; ok {
+			return routes
+		}
+	}
+	return nil
+}
+
+// implicitRegistration returns the router variable and route of a call that registers built-in
+// routes on a router (e.g., pprof.Register(r)), or empty strings for any other call.
+func implicitRegistration(source *astutil.ParsedSource, call *ast.CallExpr) (string, string) {
+	name := astutil.GetCallName(call)
+	for importPath, funcs := range registeringFuncs {
+		alias := source.GetImportAlias(importPath)
+		if alias == "" || !strings.HasPrefix(name, alias+".") {
+			continue
+		}
+		prefix, ok := funcs[strings.TrimPrefix(name, alias+".")]
+		if !ok || len(call.Args) == 0 {
+			continue
+		}
+		router, ok := call.Args[0].(*ast.Ident)
+		if !ok {
+			continue
+		}
+		if len(call.Args) > 1 {
+			if custom := astutil.GetStringValue(call.Args[1]); custom != "" {
+				prefix = "/" + strings.Trim(custom, "/")
+			}
+		}
+		return router.Name, prefix + "/*"
+	}
+	return "", ""
+}
+
+// syntheticRoute builds a route registration call (receiverVar.method(route, handler)) at the
+// position of the call that implicitly registers the route, so that implicit routes resolve
+// their prefix and middleware exactly like explicit ones.
+func syntheticRoute(call *ast.CallExpr, receiverVar, method, route string, handler ast.Expr) *ast.CallExpr {
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   &ast.Ident{NamePos: call.Pos(), Name: receiverVar},
+			Sel: ast.NewIdent(method),
+		},
+		Lparen: call.Lparen,
+		Args: []ast.Expr{
+			&ast.BasicLit{ValuePos: call.Pos(), Kind: token.STRING, Value: strconv.Quote(route)},
+			handler,
+		},
+		Rparen: call.Rparen,
+	}
+}
+
+// PruneUnservedImplicitEndpoints removes the routes that imports register on http.DefaultServeMux
+// when no scanned file serves that mux, since importing net/http/pprof alone exposes nothing.
+func PruneUnservedImplicitEndpoints(endpoints []*models.Endpoint, sources *astutil.SourceSet) []*models.Endpoint {
+	if servesDefaultMux(sources) {
+		return endpoints
+	}
+
+	kept := make([]*models.Endpoint, 0, len(endpoints))
+	for _, e := range endpoints {
+		if e.Framework == models.FrameworkNetHTTP && e.Metadata[models.MetadataImplicit] != "" && implicitImports[e.FunctionName] != nil {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	return kept
+}
+
+// servesDefaultMux returns true if a non-test file serves http.DefaultServeMux: a nil handler
+// passed to http.ListenAndServe or http.Serve, an http.Server without a Handler, a reference to
+// http.DefaultServeMux, or routes registered on it with http.Handle and http.HandleFunc.
+func servesDefaultMux(sources *astutil.SourceSet) bool {
+	for _, source := range sources.Files {
+		alias := source.GetImportAlias("net/http")
+		if alias == "" || strings.HasSuffix(source.FilePath, "_test.go") {
+			continue
+		}
+
+		serves := false
+		ast.Inspect(source.AST, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.CallExpr:
+				switch astutil.GetCallName(node) {
+				case alias + ".Handle", alias + ".HandleFunc":
+					serves = true
+				case alias + ".ListenAndServe", alias + ".Serve":
+					serves = isNilArg(node, 1)
+				case alias + ".ListenAndServeTLS", alias + ".ServeTLS":
+					serves = isNilArg(node, 3)
+				}
+			case *ast.SelectorExpr:
+				if astutil.ExprString(node) == alias+".DefaultServeMux" {
+					serves = true
+				}
+			case *ast.CompositeLit:
+				if astutil.ExprString(node.Type) == alias+".Server" && !hasNonNilField(node, "Handler") {
+					serves = true
+				}
+			}
+			return !serves
+		})
+		if serves {
+			return true
+		}
+	}
+	return false
+}
+
+// isNilArg returns true if the call argument at the index is the nil literal.
+func isNilArg(call *ast.CallExpr, index int) bool {
+	if index >= len(call.Args) {
+		return false
+	}
+	ident, ok := call.Args[index].(*ast.Ident)
+	return ok && ident.Name == "nil"
+}
+
+// hasNonNilField returns true if a composite literal sets a field to something other than nil.
+func hasNonNilField(lit *ast.CompositeLit, field string) bool {
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok || key.Name != field {
+			continue
+		}
+		value, isIdent := kv.Value.(*ast.Ident)
+		return !isIdent || value.Name != "nil"
+	}
+	return false
+}
+
+// markImplicit records on an endpoint what registered it implicitly.
+func markImplicit(endpoint *models.Endpoint, registeredBy string) *models.Endpoint {
+	if endpoint == nil {
+		return nil
+	}
+	if endpoint.Metadata == nil {
+		endpoint.Metadata = make(map[string]string)
+	}
+	endpoint.Metadata[models.MetadataImplicit] = registeredBy
+	return endpoint
+}
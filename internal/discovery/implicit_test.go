@@ -0,0 +1,199 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+func TestImplicitEndpoints(t *testing.T) {
+	loader := astutil.NewSourceLoader()
+
+	tests := []struct {
+		name       string
+		discoverer Discoverer
+		code       string
+		expected   map[string]bool
+	}{
+		{
+			name:       "net/http pprof and expvar imports",
+			discoverer: NewNetHTTPDiscoverer(),
+			code: `package main
+
+import (
+	"expvar"
+	_ "net/http/pprof"
+)
+
+var requests = expvar.NewInt("requests")
+`,
+			expected: map[string]bool{"/debug/pprof/*": false, "/debug/vars": false},
+		},
+		{
+			name:       "chi profiler and metrics mounts",
+			discoverer: NewChiDiscoverer(),
+			code: `package main
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+func routes(r chi.Router) {
+	r.Mount("/metrics", promhttp.Handler())
+	r.Route("/internal", func(r chi.Router) {
+		r.Use(AdminAuth)
+		r.Mount("/debug", middleware.Profiler())
+	})
+}
+`,
+			expected: map[string]bool{"/metrics": false, "/internal/debug/pprof/*": true, "/internal/debug/vars": true},
+		},
+		{
+			name:       "gin-contrib pprof on a group",
+			discoverer: NewGinDiscoverer(),
+			code: `package main
+
+import (
+	"github.com/gin-contrib/pprof"
+	"github.com/gin-gonic/gin"
+)
+
+func main() {
+	r := gin.New()
+	admin := r.Group("/admin", AuthRequired())
+	pprof.RouteRegister(admin, "prof")
+}
+`,
+			expected: map[string]bool{"/admin/prof/*": true},
+		},
+		{
+			name:       "fiber pprof middleware",
+			discoverer: NewFiberDiscoverer(),
+			code: `package main
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/pprof"
+)
+
+func main() {
+	app := fiber.New()
+	app.Use(pprof.New())
+}
+`,
+			expected: map[string]bool{"/debug/pprof/*": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := loader.ParseContent("main.go", tt.code)
+			require.NoError(t, err)
+			require.True(t, tt.discoverer.CanHandle(source))
+
+			endpoints, err := tt.discoverer.Discover(source)
+			require.NoError(t, err)
+			require.Len(t, endpoints, len(tt.expected))
+
+			for _, e := range endpoints {
+				requiresAuth, ok := tt.expected[e.FullRoute()]
+				require.True(t, ok, "unexpected route %s", e.FullRoute())
+				assert.Equal(t, requiresAuth, e.Authorization.RequiresAuth, e.FullRoute())
+				assert.Equal(t, []models.HTTPMethod{models.MethodGET}, e.Methods)
+				assert.NotEmpty(t, e.Metadata[models.MetadataImplicit])
+			}
+		})
+	}
+}
+
+func TestPruneUnservedImplicitEndpoints(t *testing.T) {
+	loader := astutil.NewSourceLoader()
+
+	debug, err := loader.ParseContent("/project/debug.go", `package main
+
+import _ "net/http/pprof"
+`)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		server   string
+		expected []string
+	}{
+		{
+			name: "own mux served",
+			server: `package main
+
+import "net/http"
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users", listUsers)
+	http.ListenAndServe(":8080", mux)
+}
+`,
+			expected: []string{"/users"},
+		},
+		{
+			name: "default mux served",
+			server: `package main
+
+import "net/http"
+
+func main() {
+	go http.ListenAndServe("localhost:6060", nil)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users", listUsers)
+	http.ListenAndServe(":8080", mux)
+}
+`,
+			expected: []string{"/debug/pprof/*", "/users"},
+		},
+		{
+			name: "server without a handler",
+			server: `package main
+
+import "net/http"
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users", listUsers)
+	go http.ListenAndServe(":8080", mux)
+	srv := &http.Server{Addr: "localhost:6060"}
+	srv.ListenAndServe()
+}
+`,
+			expected: []string{"/debug/pprof/*", "/users"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, err := loader.ParseContent("/project/main.go", tt.server)
+			require.NoError(t, err)
+			sources := astutil.NewSourceSet("/project")
+			sources.Add(debug)
+			sources.Add(server)
+
+			discoverer := NewNetHTTPDiscoverer()
+			var endpoints []*models.Endpoint
+			for _, source := range sources.Files {
+				discovered, err := discoverer.Discover(source)
+				require.NoError(t, err)
+				endpoints = append(endpoints, discovered...)
+			}
+
+			var routes []string
+			for _, e := range PruneUnservedImplicitEndpoints(endpoints, sources) {
+				routes = append(routes, e.FullRoute())
+			}
+			assert.Equal(t, tt.expected, routes)
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package discovery
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// clientIPMethods are the request methods and fields returning the client address
+// (gin c.ClientIP, echo c.RealIP, fiber c.IP and c.IPs, net/http r.RemoteAddr).
+var clientIPMethods = map[string]bool{
+	"ClientIP":   true,
+	"RealIP":     true,
+	"IP":         true,
+	"IPs":        true,
+	"RemoteIP":   true,
+	"RemoteAddr": true,
+}
+
+// forwardedIPHeaders are the request headers carrying a client IP set by proxies, or by the client.
+var forwardedIPHeaders = map[string]bool{
+	"x-forwarded-for":  true,
+	"x-real-ip":        true,
+	"forwarded":        true,
+	"cf-connecting-ip": true,
+	"true-client-ip":   true,
+}
+
+// ipAllowlistNames are lowercase fragments of the names of IP allowlist middleware.
+var ipAllowlistNames = []string{"whitelist", "allowlist", "ipfilter", "ip_filter", "iprestrict", "allowedip", "allowip", "iponly"}
+
+// TagIPAllowlistEndpoints records on every endpoint protected by IP allowlist middleware the
+// middleware and how it obtains the client IP. Middleware is recognised by its name, or by a body
+// that compares the client IP with a list, set or network. Only middleware recognised by name
+// counts as authentication: a body reading the client IP may as well belong to a rate limiter.
+func TagIPAllowlistEndpoints(endpoints []*models.Endpoint, sources *astutil.SourceSet) {
+	for _, e := range endpoints {
+		for _, mw := range e.Middleware {
+			clientIP, byName, ok := ipAllowlist(e, mw, sources)
+			if !ok {
+				continue
+			}
+			if e.Metadata == nil {
+				e.Metadata = make(map[string]string)
+			}
+			e.Metadata[models.MetadataIPAllowlist] = mw
+			if clientIP != "" {
+				e.Metadata[models.MetadataClientIP] = clientIP
+			}
+			if !byName {
+				break
+			}
+
+			// Name-matched auth middleware (AdminWhitelist) is already a dependency
+			auth := &e.Authorization
+			auth.RequiresAuth = true
+			if auth.Source == "" {
+				auth.Source = "middleware"
+			}
+			if !containsName(auth.AuthDependencies, mw) {
+				auth.AuthDependencies = append(auth.AuthDependencies, mw)
+			}
+			break
+		}
+	}
+}
+
+// ipAllowlist returns whether a middleware is an IP allowlist, whether it was recognised by its
+// name, and the client IP source it reads, or "" if its body is not available.
+func ipAllowlist(e *models.Endpoint, mw string, sources *astutil.SourceSet) (string, bool, bool) {
+	clientIP, checked := "", false
+	if source, fn := sources.ResolveFunc(e.FilePath, mw); fn != nil && fn.Body != nil {
+		clientIP = readClientIP(fn.Body)
+		checked = clientIP != "" && checksClientIP(source, fn.Body)
+	}
+
+	lower := strings.ToLower(mw)
+	for _, name := range ipAllowlistNames {
+		if strings.Contains(lower, name) {
+			return clientIP, true, true
+		}
+	}
+	if checked {
+		return clientIP, false, true
+	}
+	return "", false, false
+}
+
+// readClientIP returns how a function body obtains the client IP: the call or field reading it
+// (c.ClientIP, r.RemoteAddr), or the forwarded header it reads ("X-Forwarded-For").
+func readClientIP(body *ast.BlockStmt) string {
+	found := ""
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found == "" && n != nil {
+			found = clientIPRead(n)
+		}
+		return found == ""
+	})
+	return found
+}
+
+// clientIPRead returns the call, field or forwarded header by which a node reads the client IP, or "".
+func clientIPRead(n ast.Node) string {
+	switch node := n.(type) {
+	case *ast.CallExpr:
+		// c.GetHeader("X-Forwarded-For"), r.Header.Get("X-Real-IP"), c.Get("X-Forwarded-For")
+		if header := ForwardedIPHeader(node); header != "" {
+			return header
+		}
+		if sel, ok := node.Fun.(*ast.SelectorExpr); ok && clientIPMethods[sel.Sel.Name] && len(node.Args) == 0 {
+			return astutil.GetCallName(node)
+		}
+	case *ast.SelectorExpr:
+		if node.Sel.Name == "RemoteAddr" {
+			return astutil.ExprString(node)
+		}
+	}
+	return ""
+}
+
+// ForwardedIPHeader returns the forwarded IP header read by a header lookup call, or ""
+// (c.GetHeader("X-Forwarded-For"), r.Header.Get("X-Real-IP"), c.Get("X-Forwarded-For")).
+func ForwardedIPHeader(call *ast.CallExpr) string {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(call.Args) == 0 {
+		return ""
+	}
+	switch sel.Sel.Name {
+	case "Get", "GetHeader", "Values":
+	default:
+		return ""
+	}
+	header := astutil.GetStringValue(call.Args[0])
+	if !forwardedIPHeaders[strings.ToLower(header)] {
+		return ""
+	}
+	return header
+}
+
+// checksClientIP returns true if a function body compares the client IP with an allowed set: equal
+// to an element of a list it ranges over, a boolean map lookup (allowed[ip]), slices.Contains, or
+// the Contains method of a network or set (ipnet.Contains(ip), prefix.Contains(addr)).
+func checksClientIP(source *astutil.ParsedSource, body *ast.BlockStmt) bool {
+	// Variables holding the client IP, in source order
+	clientIPVars := make(map[string]bool)
+	readsIP := func(expr ast.Expr) bool {
+		found := false
+		ast.Inspect(expr, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok && clientIPVars[ident.Name] {
+				found = true
+			}
+			if !found && n != nil {
+				found = clientIPRead(n) != ""
+			}
+			return !found
+		})
+		return found
+	}
+
+	rangeVars := make(map[string]bool)
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			// _, ok := allowed[ip]; a limiter, ok := limiters[ip] uses the value instead
+			if index, ok := node.Rhs[0].(*ast.IndexExpr); ok && len(node.Lhs) == 2 && len(node.Rhs) == 1 {
+				found = astutil.ExprString(node.Lhs[0]) == "_" && readsIP(index.Index)
+				return !found
+			}
+			for i, lhs := range node.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok || ident.Name == "_" {
+					continue
+				}
+				// Multi-value assignments (host, _, err := net.SplitHostPort(...)) take the single call
+				rhs := node.Rhs[0]
+				if len(node.Rhs) == len(node.Lhs) {
+					rhs = node.Rhs[i]
+				}
+				if readsIP(rhs) {
+					clientIPVars[ident.Name] = true
+				}
+			}
+		case *ast.RangeStmt:
+			for _, expr := range []ast.Expr{node.Key, node.Value} {
+				if ident, ok := expr.(*ast.Ident); ok {
+					rangeVars[ident.Name] = true
+				}
+			}
+		case *ast.IfStmt:
+			found = found || isBooleanLookup(node.Cond, readsIP)
+		case *ast.BinaryExpr:
+			if node.Op == token.EQL || node.Op == token.NEQ {
+				x, xOk := node.X.(*ast.Ident)
+				y, yOk := node.Y.(*ast.Ident)
+				found = found || (readsIP(node.X) && yOk && rangeVars[y.Name]) || (readsIP(node.Y) && xOk && rangeVars[x.Name])
+			}
+		case *ast.CallExpr:
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok || (sel.Sel.Name != "Contains" && sel.Sel.Name != "ContainsAddr") {
+				return !found
+			}
+			ident, isIdent := sel.X.(*ast.Ident)
+			switch {
+			case isIdent && source.IsPackageRef(ident):
+				// slices.Contains(allowed, ip), but not strings.Contains(ip, "10.")
+				found = found || (source.Imports["slices"] == ident.Name && len(node.Args) == 2 && readsIP(node.Args[1]))
+			case len(node.Args) == 1:
+				found = found || readsIP(node.Args[0])
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// isBooleanLookup returns true if a condition is a map lookup of the client IP used as a boolean
+// (allowed[ip], !allowed[ip]), not a value looked up by IP (limiters[ip].Allow()).
+func isBooleanLookup(cond ast.Expr, readsIP func(ast.Expr) bool) bool {
+	switch e := cond.(type) {
+	case *ast.ParenExpr:
+		return isBooleanLookup(e.X, readsIP)
+	case *ast.UnaryExpr:
+		return e.Op == token.NOT && isBooleanLookup(e.X, readsIP)
+	case *ast.BinaryExpr:
+		return (e.Op == token.LAND || e.Op == token.LOR) && (isBooleanLookup(e.X, readsIP) || isBooleanLookup(e.Y, readsIP))
+	case *ast.IndexExpr:
+		return readsIP(e.Index)
+	}
+	return false
+}
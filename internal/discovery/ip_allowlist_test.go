@@ -0,0 +1,118 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+func TestTagIPAllowlistEndpoints(t *testing.T) {
+	loader := astutil.NewSourceLoader()
+
+	routes, err := loader.ParseContent("/project/main.go", `package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.Default()
+	admin := r.Group("/admin", officeOnly([]string{"10.0.0.1"}))
+	admin.POST("/reset-cache", resetCache)
+
+	internal := r.Group("/internal", middleware.IPWhitelist(allowed))
+	internal.GET("/metrics", metrics)
+
+	r.GET("/users", requestLogger(), listUsers)
+	r.DELETE("/users/:id", rateLimit(), deleteUser)
+	r.GET("/vpn/status", vpnOnly, status)
+}
+`)
+	require.NoError(t, err)
+
+	// The middleware is declared in another file of the package
+	middleware, err := loader.ParseContent("/project/middleware.go", `package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func officeOnly(allowed []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, ip := range allowed {
+			if c.ClientIP() == ip {
+				c.Next()
+				return
+			}
+		}
+		c.AbortWithStatus(http.StatusForbidden)
+	}
+}
+
+func requestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log.Println(c.ClientIP(), c.Request.URL.Path)
+	}
+}
+
+func rateLimit() gin.HandlerFunc {
+	limiters := map[string]*rate.Limiter{}
+	return func(c *gin.Context) {
+		limiter, ok := limiters[c.ClientIP()]
+		if !ok {
+			limiter = rate.NewLimiter(1, 5)
+			limiters[c.ClientIP()] = limiter
+		}
+		if !limiter.Allow() {
+			c.AbortWithStatus(http.StatusTooManyRequests)
+			return
+		}
+		c.Next()
+	}
+}
+
+func vpnOnly(c *gin.Context) {
+	ip := net.ParseIP(c.ClientIP())
+	if !vpnNetwork.Contains(ip) {
+		c.AbortWithStatus(http.StatusForbidden)
+	}
+}
+`)
+	require.NoError(t, err)
+
+	sources := astutil.NewSourceSet("/project")
+	sources.Add(routes)
+	sources.Add(middleware)
+
+	endpoints, err := NewGinDiscoverer().Discover(routes)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 5)
+
+	TagIPAllowlistEndpoints(endpoints, sources)
+
+	// Only middleware recognised by name counts as authentication; a rate limiter keyed by
+	// client IP is not an allowlist
+	expected := map[string]struct {
+		allowlist, clientIP string
+		requiresAuth        bool
+	}{
+		"/admin/reset-cache": {"officeOnly", "c.ClientIP", false},
+		"/internal/metrics":  {"middleware.IPWhitelist", "", true},
+		"/users":             {"", "", false},
+		"/users/:id":         {"", "", false},
+		"/vpn/status":        {"vpnOnly", "c.ClientIP", false},
+	}
+	for _, e := range endpoints {
+		want := expected[e.FullRoute()]
+		assert.Equal(t, want.allowlist, e.Metadata[models.MetadataIPAllowlist], e.FullRoute())
+		assert.Equal(t, want.clientIP, e.Metadata[models.MetadataClientIP], e.FullRoute())
+		assert.Equal(t, want.requiresAuth, e.Authorization.RequiresAuth, e.FullRoute())
+		if want.requiresAuth {
+			assert.Contains(t, e.Authorization.AuthDependencies, want.allowlist, e.FullRoute())
+		}
+	}
+}
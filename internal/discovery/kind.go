@@ -0,0 +1,188 @@
+package discovery
+
+import (
+	"go/ast"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// maxHandlerCallDepth limits how deep handler calls are followed to find a connection upgrade.
+const maxHandlerCallDepth = 2
+
+// websocketUpgrades maps WebSocket packages to the calls that upgrade a connection inside a
+// handler. Gorilla upgrades through a method of an Upgrader value, so any receiver matches.
+var websocketUpgrades = map[string]string{
+	"github.com/gorilla/websocket": "Upgrade",
+	"nhooyr.io/websocket":          "Accept",
+	"github.com/coder/websocket":   "Accept",
+}
+
+// websocketHandlers maps WebSocket packages to the constructors whose result is registered
+// as the route handler (app.Get("/ws", websocket.New(...))).
+var websocketHandlers = map[string]string{
+	"github.com/gofiber/websocket/v2":      "New",
+	"github.com/gofiber/contrib/websocket": "New",
+	"golang.org/x/net/websocket":           "Handler",
+}
+
+// handlerBody is a handler function body and the file it is declared in.
+type handlerBody struct {
+	source *astutil.ParsedSource
+	body   *ast.BlockStmt
+}
+
+// TagEndpointKinds sets the kind of every endpoint by looking for WebSocket upgrades and
+// server-sent event streams in its handler and the functions it calls. Handlers may be declared
+// in other files, so this runs once all sources are parsed.
+func TagEndpointKinds(endpoints []*models.Endpoint, sources *astutil.SourceSet) {
+	for _, e := range endpoints {
+		e.Kind = endpointKind(e, sources)
+	}
+}
+
+// endpointKind determines the kind of a single endpoint.
+func endpointKind(e *models.Endpoint, sources *astutil.SourceSet) models.EndpointKind {
+	source := sources.Get(e.FilePath)
+	if source == nil {
+		return models.EndpointKindHTTP
+	}
+	if isWebSocketHandler(source, e.FunctionName) {
+		return models.EndpointKindWebSocket
+	}
+
+	kind := models.EndpointKindHTTP
+	for _, h := range handlerBodies(e, source, sources) {
+		ast.Inspect(h.body, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.CallExpr:
+				if isWebSocketUpgrade(h.source, node, sources) {
+					kind = models.EndpointKindWebSocket
+				} else if sel, ok := node.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "SSEvent" && kind == models.EndpointKindHTTP {
+					kind = models.EndpointKindSSE
+				}
+			case *ast.BasicLit:
+				if strings.Contains(node.Value, "text/event-stream") && kind == models.EndpointKindHTTP {
+					kind = models.EndpointKindSSE
+				}
+			}
+			return kind != models.EndpointKindWebSocket
+		})
+		if kind == models.EndpointKindWebSocket {
+			break
+		}
+	}
+
+	return kind
+}
+
+// isWebSocketHandler returns true if a handler name is a WebSocket handler constructor call.
+func isWebSocketHandler(source *astutil.ParsedSource, name string) bool {
+	for importPath, constructor := range websocketHandlers {
+		if alias := source.GetImportAlias(importPath); alias != "" && name == alias+"."+constructor {
+			return true
+		}
+	}
+	return false
+}
+
+// isWebSocketUpgrade returns true if a call upgrades the connection to a WebSocket.
+func isWebSocketUpgrade(source *astutil.ParsedSource, call *ast.CallExpr, sources *astutil.SourceSet) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	for importPath, upgrade := range websocketUpgrades {
+		if sel.Sel.Name != upgrade {
+			continue
+		}
+		// Package functions (websocket.Accept) are matched by alias
+		if alias := source.GetImportAlias(importPath); alias != "" && astutil.GetCallName(call) == alias+"."+upgrade {
+			return true
+		}
+		// Upgrader methods (upgrader.Upgrade) are matched if the package uses the library,
+		// since the Upgrader is often declared in another file
+		if importPath == "github.com/gorilla/websocket" && len(call.Args) >= 2 {
+			for _, pkgSource := range sources.Package(source.FilePath) {
+				if pkgSource.HasImport(importPath) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// handlerBodies returns the body of an endpoint's handler and of the functions it calls,
+// up to maxHandlerCallDepth. Anonymous handlers and handlers wrapped in adapters
+// (http.MaxBytesHandler(http.HandlerFunc(upload), n)) are found at the registration line.
+func handlerBodies(e *models.Endpoint, source *astutil.ParsedSource, sources *astutil.SourceSet) []handlerBody {
+	var bodies []handlerBody
+	seen := make(map[*ast.BlockStmt]bool)
+
+	var visit func(h handlerBody, depth int)
+	visit = func(h handlerBody, depth int) {
+		if h.body == nil || seen[h.body] {
+			return
+		}
+		seen[h.body] = true
+		bodies = append(bodies, h)
+		if depth == 0 {
+			return
+		}
+		for _, call := range astutil.FindCallExprs(h.body) {
+			if calleeSource, callee := sources.ResolveFunc(h.source.FilePath, astutil.GetCallName(call)); callee != nil {
+				visit(handlerBody{source: calleeSource, body: callee.Body}, depth-1)
+			}
+		}
+	}
+
+	if handlerSource, fn := sources.ResolveFunc(e.FilePath, e.FunctionName); fn != nil {
+		visit(handlerBody{source: handlerSource, body: fn.Body}, maxHandlerCallDepth)
+	}
+	literals, names := registrationHandlers(source, e.LineNumber)
+	for _, lit := range literals {
+		visit(handlerBody{source: source, body: lit.Body}, maxHandlerCallDepth)
+	}
+	for _, name := range names {
+		if handlerSource, fn := sources.ResolveFunc(e.FilePath, name); fn != nil {
+			visit(handlerBody{source: handlerSource, body: fn.Body}, maxHandlerCallDepth)
+		}
+	}
+
+	return bodies
+}
+
+// registrationHandlers returns the function literals passed to the calls starting at a line and
+// the names of the functions referenced in their arguments, which may be wrapped handlers.
+func registrationHandlers(source *astutil.ParsedSource, line int) ([]*ast.FuncLit, []string) {
+	var literals []*ast.FuncLit
+	var names []string
+	ast.Inspect(source.AST, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || astutil.GetLineNumber(source.FileSet, call) != line {
+			return true
+		}
+		for _, arg := range call.Args {
+			if lit, ok := arg.(*ast.FuncLit); ok {
+				literals = append(literals, lit)
+				continue
+			}
+			ast.Inspect(arg, func(n ast.Node) bool {
+				switch expr := n.(type) {
+				case *ast.FuncLit:
+					return false
+				case *ast.Ident:
+					names = append(names, expr.Name)
+				case *ast.SelectorExpr:
+					names = append(names, astutil.ExprString(expr))
+				}
+				return true
+			})
+		}
+		return true
+	})
+	return literals, names
+}
@@ -0,0 +1,73 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+func TestTagEndpointKinds(t *testing.T) {
+	loader := astutil.NewSourceLoader()
+
+	routes, err := loader.ParseContent("/project/main.go", `package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.New()
+	r.GET("/ws", serveWs)
+	r.GET("/chat", func(c *gin.Context) {
+		handleChat(c.Writer, c.Request)
+	})
+	r.GET("/events", func(c *gin.Context) {
+		c.SSEvent("message", "hello")
+	})
+	r.GET("/users", listUsers)
+}
+`)
+	require.NoError(t, err)
+
+	// The upgrader and the handlers are declared in another file of the package
+	handlers, err := loader.ParseContent("/project/ws.go", `package main
+
+import "github.com/gorilla/websocket"
+
+var upgrader = websocket.Upgrader{}
+
+func serveWs(c *gin.Context) {
+	conn, _ := upgrader.Upgrade(c.Writer, c.Request, nil)
+	_ = conn
+}
+
+func handleChat(w http.ResponseWriter, r *http.Request) {
+	serveWs(nil)
+}
+
+func listUsers(c *gin.Context) {}
+`)
+	require.NoError(t, err)
+
+	sources := astutil.NewSourceSet("/project")
+	sources.Add(routes)
+	sources.Add(handlers)
+
+	endpoints, err := NewGinDiscoverer().Discover(routes)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 4)
+
+	TagEndpointKinds(endpoints, sources)
+
+	expected := map[string]models.EndpointKind{
+		"/ws":     models.EndpointKindWebSocket,
+		"/chat":   models.EndpointKindWebSocket,
+		"/events": models.EndpointKindSSE,
+		"/users":  models.EndpointKindHTTP,
+	}
+	for _, e := range endpoints {
+		assert.Equal(t, expected[e.FullRoute()], e.Kind, e.FullRoute())
+	}
+}
@@ -0,0 +1,11 @@
+package discovery
+
+// middlewareChain concatenates middleware lists into a new slice, outermost first.
+// A fresh slice is always returned so that per-variable Use() lists are never aliased.
+func middlewareChain(lists ...[]string) []string {
+	var chain []string
+	for _, list := range lists {
+		chain = append(chain, list...)
+	}
+	return chain
+}
@@ -26,14 +26,24 @@ func (d *NetHTTPDiscoverer) Framework() models.Framework {
 	return models.FrameworkNetHTTP
 }
 
-// CanHandle returns true if the source imports net/http.
+// CanHandle returns true if the source imports net/http or a package that
+// registers handlers on http.DefaultServeMux.
 func (d *NetHTTPDiscoverer) CanHandle(source *astutil.ParsedSource) bool {
-	return source.HasImport("net/http")
+	if source.HasImport("net/http") {
+		return true
+	}
+	for importPath := range implicitImports {
+		if source.HasImport(importPath) {
+			return true
+		}
+	}
+	return false
 }
 
 // Discover finds all net/http endpoints in the source.
 func (d *NetHTTPDiscoverer) Discover(source *astutil.ParsedSource) ([]*models.Endpoint, error) {
-	var endpoints []*models.Endpoint
+	// Routes registered on http.DefaultServeMux by imports such as _ "net/http/pprof"
+	endpoints := d.implicitEndpoints(source)
 
 	// Find all http.HandleFunc and mux.HandleFunc calls
 	ast.Inspect(source.AST, func(n ast.Node) bool {
@@ -103,9 +113,39 @@ func (d *NetHTTPDiscoverer) extractEndpoint(call *ast.CallExpr, source *astutil.
 		Authorization: auth,
 	}
 
+	// File servers only answer reads; record the served directory
+	if dir, listing, ok := fileServer(source, call.Args[1]); ok {
+		endpoint.Methods = []models.HTTPMethod{models.MethodGET}
+		markStatic(endpoint, dir, listing)
+	}
+
 	return endpoint
 }
 
+// implicitEndpoints returns the routes that imported packages register on http.DefaultServeMux.
+func (d *NetHTTPDiscoverer) implicitEndpoints(source *astutil.ParsedSource) []*models.Endpoint {
+	var endpoints []*models.Endpoint
+
+	for _, imp := range source.AST.Imports {
+		importPath := strings.Trim(imp.Path.Value, `"`)
+		for _, route := range implicitImports[importPath] {
+			endpoint := &models.Endpoint{
+				Route:         route,
+				Methods:       []models.HTTPMethod{models.MethodGET},
+				FilePath:      source.FilePath,
+				LineNumber:    astutil.GetLineNumber(source.FileSet, imp),
+				Framework:     models.FrameworkNetHTTP,
+				EndpointType:  models.EndpointTypeFunction,
+				FunctionName:  importPath,
+				Authorization: d.authExtractor.Extract(nil, source),
+			}
+			endpoints = append(endpoints, markImplicit(endpoint, "import "+imp.Path.Value))
+		}
+	}
+
+	return endpoints
+}
+
 // extractHandlerName extracts the function name from a handler argument.
 func (d *NetHTTPDiscoverer) extractHandlerName(expr ast.Expr) string {
 	switch e := expr.(type) {
@@ -0,0 +1,359 @@
+package discovery
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// maxUpstreamDepth limits how many variable assignments are followed to find a literal upstream URL.
+const maxUpstreamDepth = 3
+
+// proxyMiddleware maps import paths to middleware constructors that forward every request
+// they receive to an upstream when registered with Use().
+var proxyMiddleware = map[string]map[string][]string{
+	"github.com/labstack/echo/v4/middleware": {
+		"Proxy":           {"/*"},
+		"ProxyWithConfig": {"/*"},
+	},
+	"github.com/gofiber/fiber/v2/middleware/proxy": {
+		"Balancer": {"/*"},
+	},
+}
+
+// proxyConstructors maps import paths to the functions that build or run a reverse proxy, with
+// the index of the argument holding the upstream URL (-1 if it is not a single argument).
+var proxyConstructors = map[string]map[string]int{
+	"net/http/httputil": {
+		"NewSingleHostReverseProxy": 0,
+	},
+	"github.com/labstack/echo/v4/middleware": {
+		"Proxy":           -1,
+		"ProxyWithConfig": -1,
+	},
+	"github.com/gofiber/fiber/v2/middleware/proxy": {
+		"Forward":     0,
+		"Balancer":    -1,
+		"Do":          1,
+		"DoRedirects": 1,
+		"DoTimeout":   1,
+		"DoDeadline":  1,
+	},
+}
+
+// allMethods are the methods of a route that answers every method.
+var allMethods = []models.HTTPMethod{models.MethodGET, models.MethodPOST, models.MethodPUT,
+	models.MethodDELETE, models.MethodPATCH, models.MethodHEAD, models.MethodOPTIONS}
+
+// reverseProxy is a reverse proxy found in the source.
+type reverseProxy struct {
+	// constructor is the call or type building the proxy (e.g., "httputil.NewSingleHostReverseProxy").
+	constructor string
+
+	// target is the upstream URL if it is a literal, or "".
+	target string
+}
+
+// TagProxyEndpoints records on every endpoint forwarding its requests to an upstream the proxy
+// that does so and, when it is a literal, the upstream URL. Proxies are found in the registration,
+// the handler and the functions it calls, package-level proxy variables and proxy middleware.
+func TagProxyEndpoints(endpoints []*models.Endpoint, sources *astutil.SourceSet) {
+	for _, e := range endpoints {
+		proxy := endpointProxy(e, sources)
+		if proxy == nil {
+			continue
+		}
+		if e.Metadata == nil {
+			e.Metadata = make(map[string]string)
+		}
+		e.Metadata[models.MetadataProxy] = proxy.constructor
+		if proxy.target != "" {
+			e.Metadata[models.MetadataProxyTarget] = proxy.target
+		}
+	}
+}
+
+// endpointProxy returns the reverse proxy serving an endpoint, or nil.
+func endpointProxy(e *models.Endpoint, sources *astutil.SourceSet) *reverseProxy {
+	source := sources.Get(e.FilePath)
+	if source == nil {
+		return nil
+	}
+	vars := proxyVars(sources, e.FilePath)
+
+	// The registration itself: mux.Handle("/api/", proxy), app.Get("/gh", proxy.Forward(url))
+	var proxy *reverseProxy
+	ast.Inspect(source.AST, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if proxy != nil || !ok || astutil.GetLineNumber(source.FileSet, call) != e.LineNumber {
+			return proxy == nil
+		}
+		for _, arg := range call.Args {
+			if proxy = findProxy(source, arg, vars); proxy != nil {
+				break
+			}
+		}
+		return false
+	})
+	if proxy != nil {
+		return proxy
+	}
+
+	for _, h := range handlerBodies(e, source, sources) {
+		if proxy := findProxy(h.source, h.body, vars); proxy != nil {
+			return proxy
+		}
+	}
+
+	// Proxy middleware answers every route of the router it is registered on
+	for _, mw := range e.Middleware {
+		if isProxyMiddleware(source, mw) {
+			return &reverseProxy{constructor: mw}
+		}
+	}
+	return nil
+}
+
+// findProxy returns the first reverse proxy built or referenced within a node.
+func findProxy(source *astutil.ParsedSource, node ast.Node, vars map[string]*reverseProxy) *reverseProxy {
+	var proxy *reverseProxy
+	ast.Inspect(node, func(n ast.Node) bool {
+		if proxy != nil {
+			return false
+		}
+		switch expr := n.(type) {
+		case *ast.Ident:
+			proxy = vars[expr.Name]
+		case ast.Expr:
+			proxy = parseProxy(source, expr)
+		}
+		return proxy == nil
+	})
+	return proxy
+}
+
+// parseProxy recognises a reverse proxy constructor call or httputil.ReverseProxy literal.
+func parseProxy(source *astutil.ParsedSource, expr ast.Expr) *reverseProxy {
+	switch e := expr.(type) {
+	case *ast.CompositeLit:
+		httputil := source.GetImportAlias("net/http/httputil")
+		if httputil == "" || astutil.ExprString(e.Type) != httputil+".ReverseProxy" {
+			return nil
+		}
+		return &reverseProxy{constructor: httputil + ".ReverseProxy", target: rewriteTarget(source, e)}
+	case *ast.CallExpr:
+		name := astutil.GetCallName(e)
+		for importPath, funcs := range proxyConstructors {
+			alias := source.GetImportAlias(importPath)
+			if alias == "" || !strings.HasPrefix(name, alias+".") {
+				continue
+			}
+			if index, ok := funcs[strings.TrimPrefix(name, alias+".")]; ok {
+				proxy := &reverseProxy{constructor: name}
+				if index >= 0 && index < len(e.Args) {
+					proxy.target = upstream(source, e.Args[index], maxUpstreamDepth)
+				}
+				return proxy
+			}
+		}
+	}
+	return nil
+}
+
+// rewriteTarget returns the literal upstream set by the Director or Rewrite function of a
+// ReverseProxy literal (req.URL.Host = "api.internal", r.SetURL(target)).
+func rewriteTarget(source *astutil.ParsedSource, lit *ast.CompositeLit) string {
+	target, scheme := "", ""
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		fn, isFunc := kv.Value.(*ast.FuncLit)
+		if !ok || !isFunc || (key.Name != "Director" && key.Name != "Rewrite") {
+			continue
+		}
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.AssignStmt:
+				for i, lhs := range node.Lhs {
+					sel, ok := lhs.(*ast.SelectorExpr)
+					if !ok || i >= len(node.Rhs) {
+						continue
+					}
+					switch {
+					case sel.Sel.Name == "Host" && target == "":
+						target = upstream(source, node.Rhs[i], maxUpstreamDepth)
+					case sel.Sel.Name == "Scheme":
+						scheme = astutil.GetStringValue(node.Rhs[i])
+					}
+				}
+			case *ast.CallExpr:
+				if sel, ok := node.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "SetURL" && len(node.Args) == 1 && target == "" {
+					target = upstream(source, node.Args[0], maxUpstreamDepth)
+				}
+			}
+			return true
+		})
+	}
+	if target != "" && scheme != "" && !strings.Contains(target, "://") {
+		target = scheme + "://" + target
+	}
+	return target
+}
+
+// upstream returns the literal URL of an expression: a string literal, url.Parse of a literal,
+// a url.URL literal with a literal Host, or a variable assigned one of these.
+func upstream(source *astutil.ParsedSource, expr ast.Expr, depth int) string {
+	if depth == 0 {
+		return ""
+	}
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return astutil.GetStringValue(e)
+	case *ast.UnaryExpr:
+		return upstream(source, e.X, depth)
+	case *ast.CallExpr:
+		name := astutil.GetCallName(e)
+		if urlAlias := source.GetImportAlias("net/url"); urlAlias != "" && (name == urlAlias+".Parse" || name == urlAlias+".ParseRequestURI") && len(e.Args) == 1 {
+			return upstream(source, e.Args[0], depth-1)
+		}
+	case *ast.CompositeLit:
+		urlAlias := source.GetImportAlias("net/url")
+		if urlAlias == "" || astutil.ExprString(e.Type) != urlAlias+".URL" {
+			return ""
+		}
+		scheme, host := "http", ""
+		for _, elt := range e.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			if key, ok := kv.Key.(*ast.Ident); ok {
+				switch key.Name {
+				case "Scheme":
+					if value := astutil.GetStringValue(kv.Value); value != "" {
+						scheme = value
+					}
+				case "Host":
+					host = astutil.GetStringValue(kv.Value)
+				}
+			}
+		}
+		if host != "" {
+			return scheme + "://" + host
+		}
+	case *ast.Ident:
+		if value := assignedValue(source, e.Name); value != nil {
+			return upstream(source, value, depth-1)
+		}
+	}
+	return ""
+}
+
+// assignedValue returns the first value assigned to a variable in a file, or nil.
+func assignedValue(source *astutil.ParsedSource, name string) ast.Expr {
+	var value ast.Expr
+	ast.Inspect(source.AST, func(n ast.Node) bool {
+		if value != nil {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			value = boundValue(identNames(node.Lhs), node.Rhs, name)
+		case *ast.ValueSpec:
+			var names []string
+			for _, ident := range node.Names {
+				names = append(names, ident.Name)
+			}
+			value = boundValue(names, node.Values, name)
+		}
+		return value == nil
+	})
+	return value
+}
+
+// proxyVars returns the variables of a package that hold a reverse proxy, by name.
+func proxyVars(sources *astutil.SourceSet, path string) map[string]*reverseProxy {
+	vars := make(map[string]*reverseProxy)
+	for _, source := range sources.Package(path) {
+		ast.Inspect(source.AST, func(n ast.Node) bool {
+			var names []string
+			var values []ast.Expr
+			switch node := n.(type) {
+			case *ast.AssignStmt:
+				names, values = identNames(node.Lhs), node.Rhs
+			case *ast.ValueSpec:
+				for _, ident := range node.Names {
+					names = append(names, ident.Name)
+				}
+				values = node.Values
+			default:
+				return true
+			}
+			for _, name := range names {
+				if value := boundValue(names, values, name); value != nil && name != "_" {
+					if proxy := parseProxy(source, unwrapAddrExpr(value)); proxy != nil {
+						vars[name] = proxy
+					}
+				}
+			}
+			return true
+		})
+	}
+	return vars
+}
+
+// isProxyMiddleware returns true if a middleware name is a proxy middleware constructor.
+func isProxyMiddleware(source *astutil.ParsedSource, name string) bool {
+	for importPath, funcs := range proxyMiddleware {
+		alias := source.GetImportAlias(importPath)
+		if alias == "" || !strings.HasPrefix(name, alias+".") {
+			continue
+		}
+		if _, ok := funcs[strings.TrimPrefix(name, alias+".")]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// identNames returns the names of identifier expressions ("" for any other expression).
+func identNames(exprs []ast.Expr) []string {
+	names := make([]string, len(exprs))
+	for i, expr := range exprs {
+		if ident, ok := expr.(*ast.Ident); ok {
+			names[i] = ident.Name
+		}
+	}
+	return names
+}
+
+// boundValue returns the value bound to a name by an assignment or declaration. With a single
+// multi-value call (u, err := url.Parse(...)) the call is returned for the first name.
+func boundValue(names []string, values []ast.Expr, name string) ast.Expr {
+	for i, n := range names {
+		if n != name {
+			continue
+		}
+		switch {
+		case len(values) == len(names):
+			return values[i]
+		case len(values) == 1 && i == 0:
+			return values[0]
+		}
+	}
+	return nil
+}
+
+// unwrapAddrExpr strips a leading & from an expression.
+func unwrapAddrExpr(expr ast.Expr) ast.Expr {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		return unary.X
+	}
+	return expr
+}
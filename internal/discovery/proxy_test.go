@@ -0,0 +1,145 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+func TestTagProxyEndpoints(t *testing.T) {
+	loader := astutil.NewSourceLoader()
+
+	routes, err := loader.ParseContent("/project/main.go", `package main
+
+import (
+	"net/http"
+	"net/http/httputil"
+)
+
+func main() {
+	http.Handle("/api/", apiProxy)
+	http.Handle("/billing", &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL.Scheme = "https"
+			req.URL.Host = "billing.example.com"
+		},
+	})
+	http.HandleFunc("/gh", forwardGitHub)
+	http.HandleFunc("/health", health)
+}
+`)
+	require.NoError(t, err)
+
+	// The proxy variable and the handlers are declared in another file of the package
+	proxies, err := loader.ParseContent("/project/proxy.go", `package main
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+)
+
+var target, _ = url.Parse("http://internal-api:8080")
+
+var apiProxy = httputil.NewSingleHostReverseProxy(target)
+
+func forwardGitHub(w http.ResponseWriter, r *http.Request) {
+	p := httputil.NewSingleHostReverseProxy(&url.URL{Scheme: "https", Host: "api.github.com"})
+	p.ServeHTTP(w, r)
+}
+
+func health(w http.ResponseWriter, r *http.Request) {}
+`)
+	require.NoError(t, err)
+
+	sources := astutil.NewSourceSet("/project")
+	sources.Add(routes)
+	sources.Add(proxies)
+
+	endpoints, err := NewNetHTTPDiscoverer().Discover(routes)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 4)
+
+	TagProxyEndpoints(endpoints, sources)
+
+	expected := map[string][2]string{
+		"/api/":    {"httputil.NewSingleHostReverseProxy", "http://internal-api:8080"},
+		"/billing": {"httputil.ReverseProxy", "https://billing.example.com"},
+		"/gh":      {"httputil.NewSingleHostReverseProxy", "https://api.github.com"},
+		"/health":  {"", ""},
+	}
+	for _, e := range endpoints {
+		assert.Equal(t, expected[e.FullRoute()][0], e.Metadata[models.MetadataProxy], e.FullRoute())
+		assert.Equal(t, expected[e.FullRoute()][1], e.Metadata[models.MetadataProxyTarget], e.FullRoute())
+	}
+}
+
+func TestProxyMiddlewareEndpoints(t *testing.T) {
+	loader := astutil.NewSourceLoader()
+
+	t.Run("echo", func(t *testing.T) {
+		source, err := loader.ParseContent("/project/main.go", `package main
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+func main() {
+	e := echo.New()
+	g := e.Group("/svc")
+	g.Use(middleware.Proxy(balancer))
+}
+`)
+		require.NoError(t, err)
+
+		endpoints, err := NewEchoDiscoverer().Discover(source)
+		require.NoError(t, err)
+		require.Len(t, endpoints, 1)
+		assert.Equal(t, "/svc/*", endpoints[0].FullRoute())
+		assert.Len(t, endpoints[0].Methods, 7)
+
+		sources := astutil.NewSourceSet("/project")
+		sources.Add(source)
+		TagProxyEndpoints(endpoints, sources)
+		assert.Equal(t, "middleware.Proxy", endpoints[0].Metadata[models.MetadataProxy])
+	})
+
+	t.Run("fiber", func(t *testing.T) {
+		source, err := loader.ParseContent("/project/main.go", `package main
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/proxy"
+)
+
+func main() {
+	app := fiber.New()
+	app.Get("/gh", proxy.Forward("https://github.com"))
+	app.Use("/lb", proxy.Balancer(proxy.Config{Servers: []string{"http://a"}}))
+}
+`)
+		require.NoError(t, err)
+
+		endpoints, err := NewFiberDiscoverer().Discover(source)
+		require.NoError(t, err)
+		require.Len(t, endpoints, 2)
+
+		sources := astutil.NewSourceSet("/project")
+		sources.Add(source)
+		TagProxyEndpoints(endpoints, sources)
+
+		routes := make(map[string]*models.Endpoint)
+		for _, e := range endpoints {
+			routes[e.FullRoute()] = e
+		}
+		require.Contains(t, routes, "/gh")
+		require.Contains(t, routes, "/lb/*")
+		assert.Equal(t, "https://github.com", routes["/gh"].Metadata[models.MetadataProxyTarget])
+		assert.Equal(t, "proxy.Balancer", routes["/lb/*"].Metadata[models.MetadataProxy])
+	})
+}
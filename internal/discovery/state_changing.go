@@ -0,0 +1,139 @@
+package discovery
+
+import (
+	"go/ast"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// execMethods are the database methods that run a statement without returning rows.
+var execMethods = map[string]bool{
+	"Exec":             true,
+	"ExecContext":      true,
+	"MustExec":         true,
+	"NamedExec":        true,
+	"NamedExecContext": true,
+}
+
+// ormMutatingMethods are the ORM and repository methods that create, change or delete records
+// (gorm db.Delete, db.Save, ent client.User.UpdateOneID, bun db.NewDelete).
+var ormMutatingMethods = map[string]bool{
+	"Create":        true,
+	"CreateBulk":    true,
+	"Save":          true,
+	"Insert":        true,
+	"Upsert":        true,
+	"Update":        true,
+	"Updates":       true,
+	"UpdateColumn":  true,
+	"UpdateColumns": true,
+	"UpdateOne":     true,
+	"UpdateOneID":   true,
+	"Delete":        true,
+	"DeleteOne":     true,
+	"DeleteOneID":   true,
+	"Remove":        true,
+	"Destroy":       true,
+	"NewInsert":     true,
+	"NewUpdate":     true,
+	"NewDelete":     true,
+}
+
+// ormImportPrefixes are the import path prefixes of ORM and query builder libraries.
+var ormImportPrefixes = []string{
+	"gorm.io/",
+	"github.com/jinzhu/gorm",
+	"entgo.io/ent",
+	"github.com/uptrace/bun",
+	"github.com/go-pg/pg",
+	"github.com/jmoiron/sqlx",
+	"github.com/volatiletech/sqlboiler",
+	"xorm.io/",
+}
+
+// dataStoreReceivers are the words of receiver names of database handles and repositories.
+var dataStoreReceivers = map[string]bool{
+	"db": true, "tx": true, "orm": true, "client": true, "repo": true, "repository": true,
+	"store": true, "dao": true, "model": true, "query": true,
+}
+
+// TagStateChangingEndpoints records on every read-only endpoint (GET, HEAD, OPTIONS) whose handler
+// or the functions it calls change state the mutating call: a database Exec, an ORM create, update
+// or delete, or one of the configured mutating functions. Exec and ORM methods only match on values,
+// so package functions such as os.Create are not state changes.
+func TagStateChangingEndpoints(endpoints []*models.Endpoint, sources *astutil.SourceSet, mutatingFuncs []string) {
+	for _, e := range endpoints {
+		if e.IsWriteEndpoint() {
+			continue
+		}
+		source := sources.Get(e.FilePath)
+		if source == nil {
+			continue
+		}
+
+		for _, h := range handlerBodies(e, source, sources) {
+			if call := findMutatingCall(h, mutatingFuncs); call != "" {
+				if e.Metadata == nil {
+					e.Metadata = make(map[string]string)
+				}
+				e.Metadata[models.MetadataStateChanging] = call
+				break
+			}
+		}
+	}
+}
+
+// findMutatingCall returns the name of the first mutating call in a handler body, or "".
+func findMutatingCall(h handlerBody, mutatingFuncs []string) string {
+	orm := false
+	for importPath := range h.source.Imports {
+		for _, prefix := range ormImportPrefixes {
+			orm = orm || strings.HasPrefix(importPath, prefix)
+		}
+	}
+
+	found := ""
+	ast.Inspect(h.body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || found != "" {
+			return found == ""
+		}
+		name := astutil.GetCallName(call)
+		method := name[strings.LastIndex(name, ".")+1:]
+		if matchesName(name, mutatingFuncs) {
+			found = name
+		}
+
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		switch {
+		case found != "" || !ok || isPackageSelector(h.source, sel):
+		case execMethods[method]:
+			found = name
+		case ormMutatingMethods[method] && (orm || isDataStoreReceiver(sel.X)):
+			found = name
+		}
+		return found == ""
+	})
+	return found
+}
+
+// isDataStoreReceiver returns true if a method receiver looks like a database handle or repository
+// (db, s.tx, client.User, userRepo).
+func isDataStoreReceiver(expr ast.Expr) bool {
+	for _, part := range strings.Split(astutil.ExprString(expr), ".") {
+		for _, word := range astutil.SplitWords(part) {
+			if dataStoreReceivers[word] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isPackageSelector returns true if a selector refers to a member of an imported package (os.Create).
+func isPackageSelector(source *astutil.ParsedSource, sel *ast.SelectorExpr) bool {
+	ident, ok := sel.X.(*ast.Ident)
+	return ok && source.IsPackageRef(ident)
+}
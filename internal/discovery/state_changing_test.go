@@ -0,0 +1,117 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+func TestTagStateChangingEndpoints(t *testing.T) {
+	loader := astutil.NewSourceLoader()
+
+	routes, err := loader.ParseContent("/project/main.go", `package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.Default()
+	r.GET("/users/:id/delete", deleteUser)
+	r.GET("/admin/reset-cache", func(c *gin.Context) {
+		cache.Purge()
+	})
+	r.GET("/reports/refresh", refreshReports)
+	r.GET("/users", listUsers)
+	r.GET("/logout", logout)
+	r.GET("/export", exportUsers)
+	r.POST("/users", createUser)
+}
+`)
+	require.NoError(t, err)
+
+	// The handlers are declared in another file of the package
+	handlers, err := loader.ParseContent("/project/handlers.go", `package main
+
+import "github.com/gin-gonic/gin"
+
+func deleteUser(c *gin.Context) {
+	userRepo.Delete(c.Param("id"))
+}
+
+func refreshReports(c *gin.Context) {
+	rebuild(c)
+}
+
+func rebuild(c *gin.Context) {
+	db.Exec("REFRESH MATERIALIZED VIEW reports")
+}
+
+func listUsers(c *gin.Context) {
+	var users []User
+	db.Find(&users)
+	c.JSON(200, users)
+}
+
+func logout(c *gin.Context) {
+	ctx.Delete("session")
+}
+
+func createUser(c *gin.Context) {
+	db.Create(&User{})
+}
+`)
+	require.NoError(t, err)
+
+	// A file using an ORM, where package functions named like ORM methods are not state changes
+	export, err := loader.ParseContent("/project/export.go", `package main
+
+import (
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func exportUsers(c *gin.Context) {
+	var users []User
+	conn.Find(&users)
+	f, _ := os.Create("/tmp/export.csv")
+	defer f.Close()
+	c.File(f.Name())
+}
+
+var conn *gorm.DB
+`)
+	require.NoError(t, err)
+
+	sources := astutil.NewSourceSet("/project")
+	sources.Add(routes)
+	sources.Add(handlers)
+	sources.Add(export)
+
+	endpoints, err := NewGinDiscoverer().Discover(routes)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 7)
+
+	TagStateChangingEndpoints(endpoints, sources, []string{"cache.Purge"})
+
+	// Write methods are not tagged, and a Delete on a non-database receiver is not a state change
+	expected := map[string]string{
+		"/users/:id/delete":  "userRepo.Delete",
+		"/admin/reset-cache": "cache.Purge",
+		"/reports/refresh":   "db.Exec",
+		"/users":             "",
+		"/logout":            "",
+		"/export":            "",
+	}
+	for _, e := range endpoints {
+		if e.Methods[0] != models.MethodGET {
+			assert.NotContains(t, e.Metadata, models.MetadataStateChanging, e.Route)
+			continue
+		}
+		assert.Equal(t, expected[e.Route], e.Metadata[models.MetadataStateChanging], e.Route)
+	}
+}
@@ -0,0 +1,179 @@
+package discovery
+
+import (
+	"go/ast"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// staticMethod is the kind of a framework static file registration method.
+type staticMethod int
+
+const (
+	// staticDirMethod serves a directory path without listing: (prefix, root[, config]).
+	staticDirMethod staticMethod = iota
+
+	// staticFSMethod serves a file system whose listing depends on the file system: (prefix, fs).
+	staticFSMethod
+
+	// staticFileMethod serves a single file: (path, file).
+	staticFileMethod
+)
+
+// ginStaticMethods are the Gin router methods that serve files.
+var ginStaticMethods = map[string]staticMethod{
+	"Static":     staticDirMethod,
+	"StaticFS":   staticFSMethod,
+	"StaticFile": staticFileMethod,
+}
+
+// echoStaticMethods are the Echo router methods that serve files.
+var echoStaticMethods = map[string]staticMethod{
+	"Static": staticDirMethod,
+	"File":   staticFileMethod,
+}
+
+// fiberStaticMethods are the Fiber router methods that serve files.
+var fiberStaticMethods = map[string]staticMethod{
+	"Static": staticDirMethod,
+}
+
+// staticRoute is a static file registration found in the source.
+type staticRoute struct {
+	receiverVar string
+	route       string
+	dir         string
+	listing     bool
+}
+
+// parseStaticRoute recognises a static file registration (r.Static("/assets", "./public")) and
+// returns the wildcard route it serves, or nil for any other call.
+func parseStaticRoute(source *astutil.ParsedSource, call *ast.CallExpr, methods map[string]staticMethod, wildcard string) *staticRoute {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(call.Args) < 2 {
+		return nil
+	}
+	receiver, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	method, ok := methods[sel.Sel.Name]
+	if !ok {
+		return nil
+	}
+	prefix := astutil.GetStringValue(call.Args[0])
+	if prefix == "" {
+		return nil
+	}
+
+	static := &staticRoute{receiverVar: receiver.Name, route: strings.TrimSuffix(prefix, "/") + wildcard}
+	switch method {
+	case staticDirMethod:
+		static.dir = pathValue(call.Args[1])
+		for _, arg := range call.Args[2:] {
+			static.listing = static.listing || enablesBrowse(arg)
+		}
+	case staticFSMethod:
+		static.dir, static.listing = fileSystemDir(source, call.Args[1])
+	case staticFileMethod:
+		static.route = prefix
+		static.dir = pathValue(call.Args[1])
+	}
+	if static.route == "" {
+		static.route = "/"
+	}
+
+	return static
+}
+
+// mark records the served directory on an endpoint.
+func (s *staticRoute) mark(endpoint *models.Endpoint) *models.Endpoint {
+	return markStatic(endpoint, s.dir, s.listing)
+}
+
+// fileServer recognises an http.FileServer handler, possibly wrapped in http.StripPrefix or a
+// framework adapter, and returns the served directory and whether directories are listed.
+func fileServer(source *astutil.ParsedSource, expr ast.Expr) (string, bool, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return "", false, false
+	}
+
+	name := astutil.GetCallName(call)
+	httpAlias := source.GetImportAlias("net/http")
+	switch {
+	case httpAlias != "" && (name == httpAlias+".FileServer" || name == httpAlias+".FileServerFS") && len(call.Args) == 1:
+		dir, listing := fileSystemDir(source, call.Args[0])
+		return dir, listing, true
+	case strings.HasSuffix(name, ".StripPrefix") && len(call.Args) == 2:
+		return fileServer(source, call.Args[1])
+	case (strings.HasSuffix(name, ".WrapH") || strings.HasSuffix(name, ".WrapHandler")) && len(call.Args) == 1:
+		return fileServer(source, call.Args[0])
+	}
+	return "", false, false
+}
+
+// fileSystemDir returns the directory of a file system expression and whether a file server
+// built on it lists directories. http.Dir and http.FS list directories; gin.Dir only if asked to.
+func fileSystemDir(source *astutil.ParsedSource, expr ast.Expr) (string, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return astutil.ExprString(expr), true
+	}
+
+	name := astutil.GetCallName(call)
+	if ginAlias := source.GetImportAlias(ginImport); ginAlias != "" && name == ginAlias+".Dir" {
+		return pathValue(call.Args[0]), len(call.Args) > 1 && isTrue(call.Args[1])
+	}
+	if strings.HasSuffix(name, ".Dir") || strings.HasSuffix(name, ".FS") {
+		return pathValue(call.Args[0]), true
+	}
+	return astutil.ExprString(expr), true
+}
+
+// enablesBrowse returns true if a static configuration literal sets Browse: true.
+func enablesBrowse(expr ast.Expr) bool {
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return false
+	}
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Browse" && isTrue(kv.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pathValue returns a string literal path, or the source of a non-literal expression.
+func pathValue(expr ast.Expr) string {
+	if lit, ok := expr.(*ast.BasicLit); ok {
+		return astutil.GetStringValue(lit)
+	}
+	return astutil.ExprString(expr)
+}
+
+// isTrue returns true if the expression is the identifier true.
+func isTrue(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "true"
+}
+
+// markStatic records the served directory and directory listing on a static file endpoint.
+func markStatic(endpoint *models.Endpoint, dir string, listing bool) *models.Endpoint {
+	if endpoint == nil {
+		return nil
+	}
+	if endpoint.Metadata == nil {
+		endpoint.Metadata = make(map[string]string)
+	}
+	endpoint.Metadata[models.MetadataStaticDir] = dir
+	if listing {
+		endpoint.Metadata[models.MetadataDirectoryListing] = "true"
+	}
+	return endpoint
+}
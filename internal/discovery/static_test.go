@@ -0,0 +1,108 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+func TestStaticEndpoints(t *testing.T) {
+	loader := astutil.NewSourceLoader()
+
+	type static struct {
+		dir     string
+		listing bool
+	}
+
+	tests := []struct {
+		name       string
+		discoverer Discoverer
+		code       string
+		expected   map[string]static
+	}{
+		{
+			name:       "gin static directories",
+			discoverer: NewGinDiscoverer(),
+			code: `package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.New()
+	r.Static("/assets", "./public")
+	r.StaticFS("/files", gin.Dir("./uploads", true))
+	r.StaticFile("/favicon.ico", "./public/favicon.ico")
+}
+`,
+			expected: map[string]static{
+				"/assets/*filepath": {dir: "./public"},
+				"/files/*filepath":  {dir: "./uploads", listing: true},
+				"/favicon.ico":      {dir: "./public/favicon.ico"},
+			},
+		},
+		{
+			name:       "echo static directory",
+			discoverer: NewEchoDiscoverer(),
+			code: `package main
+
+import "github.com/labstack/echo/v4"
+
+func main() {
+	e := echo.New()
+	e.Static("/static", "assets")
+}
+`,
+			expected: map[string]static{"/static/*": {dir: "assets"}},
+		},
+		{
+			name:       "fiber static with browsing",
+			discoverer: NewFiberDiscoverer(),
+			code: `package main
+
+import "github.com/gofiber/fiber/v2"
+
+func main() {
+	app := fiber.New()
+	app.Static("/", "./public", fiber.Static{Browse: true})
+}
+`,
+			expected: map[string]static{"/*": {dir: "./public", listing: true}},
+		},
+		{
+			name:       "net/http file server",
+			discoverer: NewNetHTTPDiscoverer(),
+			code: `package main
+
+import "net/http"
+
+func main() {
+	http.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.Dir("./public"))))
+}
+`,
+			expected: map[string]static{"/static/": {dir: "./public", listing: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := loader.ParseContent("main.go", tt.code)
+			require.NoError(t, err)
+
+			endpoints, err := tt.discoverer.Discover(source)
+			require.NoError(t, err)
+			require.Len(t, endpoints, len(tt.expected))
+
+			for _, e := range endpoints {
+				expected, ok := tt.expected[e.FullRoute()]
+				require.True(t, ok, "unexpected route %s", e.FullRoute())
+				assert.Equal(t, []models.HTTPMethod{models.MethodGET}, e.Methods)
+				assert.Equal(t, expected.dir, e.Metadata[models.MetadataStaticDir])
+				assert.Equal(t, expected.listing, e.Metadata[models.MetadataDirectoryListing] == "true")
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package discovery
+
+import (
+	"go/ast"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// uploadCalls are the request methods that read a multipart file upload
+// (c.FormFile, c.MultipartForm, r.ParseMultipartForm, c.SaveFile, ...).
+var uploadCalls = map[string]bool{
+	"FormFile":           true,
+	"MultipartForm":      true,
+	"ParseMultipartForm": true,
+	"MultipartReader":    true,
+	"SaveFile":           true,
+	"SaveUploadedFile":   true,
+}
+
+// bodyLimitCalls are the calls that cap the size of a request body inside a handler or
+// around it at registration (http.MaxBytesReader, http.MaxBytesHandler).
+var bodyLimitCalls = map[string]bool{
+	"MaxBytesReader":  true,
+	"MaxBytesHandler": true,
+}
+
+// bodyLimitMiddleware are lowercase fragments of middleware names that cap the request body
+// (echo middleware.BodyLimit, gin-contrib limits.RequestSizeLimiter, ...).
+var bodyLimitMiddleware = []string{"bodylimit", "maxbytes", "sizelimit", "bodysize"}
+
+// bodyLimitSettings are the router fields that cap request bodies for a whole application
+// (gin Engine.MaxMultipartMemory, fiber Config.BodyLimit).
+var bodyLimitSettings = map[string]bool{
+	"MaxMultipartMemory": true,
+	"BodyLimit":          true,
+}
+
+// TagUploadEndpoints records on every endpoint whose handler reads a multipart upload the call
+// that reads it and, if one is found, the mechanism limiting the request body size: a limit in
+// the handler or around it, body limit middleware, or an application-wide router setting.
+func TagUploadEndpoints(endpoints []*models.Endpoint, sources *astutil.SourceSet) {
+	for _, e := range endpoints {
+		source := sources.Get(e.FilePath)
+		if source == nil {
+			continue
+		}
+		bodies := handlerBodies(e, source, sources)
+
+		upload := ""
+		for _, h := range bodies {
+			if upload = findCall(h.body, uploadCalls); upload != "" {
+				break
+			}
+		}
+		if upload == "" {
+			continue
+		}
+
+		if e.Metadata == nil {
+			e.Metadata = make(map[string]string)
+		}
+		e.Metadata[models.MetadataUpload] = upload
+		if limit := bodyLimit(e, source, sources, bodies); limit != "" {
+			e.Metadata[models.MetadataBodyLimit] = limit
+		}
+	}
+}
+
+// bodyLimit returns the mechanism limiting the body size of an endpoint, or "".
+func bodyLimit(e *models.Endpoint, source *astutil.ParsedSource, sources *astutil.SourceSet, bodies []handlerBody) string {
+	for _, h := range bodies {
+		if limit := findCall(h.body, bodyLimitCalls); limit != "" {
+			return limit
+		}
+	}
+
+	// mux.Handle("/upload", http.MaxBytesHandler(h, 10<<20))
+	limit := ""
+	ast.Inspect(source.AST, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if limit != "" || !ok || astutil.GetLineNumber(source.FileSet, call) != e.LineNumber {
+			return limit == ""
+		}
+		limit = findCall(call, bodyLimitCalls)
+		return false
+	})
+	if limit != "" {
+		return limit
+	}
+
+	for _, mw := range e.Middleware {
+		lower := strings.ToLower(mw)
+		for _, fragment := range bodyLimitMiddleware {
+			if strings.Contains(lower, fragment) {
+				return mw
+			}
+		}
+	}
+
+	for _, pkgSource := range sources.Package(e.FilePath) {
+		if setting := findBodyLimitSetting(pkgSource); setting != "" {
+			return setting
+		}
+	}
+	return ""
+}
+
+// findCall returns the name of the first call within a node whose method or function name is
+// in a set, or "".
+func findCall(node ast.Node, names map[string]bool) string {
+	found := ""
+	ast.Inspect(node, func(n ast.Node) bool {
+		if found != "" {
+			return false
+		}
+		if call, ok := n.(*ast.CallExpr); ok {
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && names[sel.Sel.Name] {
+				found = astutil.GetCallName(call)
+			}
+		}
+		return found == ""
+	})
+	return found
+}
+
+// findBodyLimitSetting returns the application-wide body limit set in a file
+// (r.MaxMultipartMemory = 8 << 20, fiber.Config{BodyLimit: 4 * 1024 * 1024}), or "".
+func findBodyLimitSetting(source *astutil.ParsedSource) string {
+	found := ""
+	ast.Inspect(source.AST, func(n ast.Node) bool {
+		if found != "" {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range node.Lhs {
+				if sel, ok := lhs.(*ast.SelectorExpr); ok && bodyLimitSettings[sel.Sel.Name] {
+					found = sel.Sel.Name
+				}
+			}
+		case *ast.KeyValueExpr:
+			if key, ok := node.Key.(*ast.Ident); ok && bodyLimitSettings[key.Name] {
+				found = key.Name
+			}
+		}
+		return found == ""
+	})
+	return found
+}
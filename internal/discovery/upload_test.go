@@ -0,0 +1,96 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+func TestTagUploadEndpoints(t *testing.T) {
+	loader := astutil.NewSourceLoader()
+
+	routes, err := loader.ParseContent("/project/main.go", `package main
+
+import "net/http"
+
+func main() {
+	http.HandleFunc("/import", importFile)
+	http.HandleFunc("/limited", limited)
+	http.Handle("/wrapped", http.MaxBytesHandler(http.HandlerFunc(importFile), 1<<20))
+	http.HandleFunc("/users", listUsers)
+}
+`)
+	require.NoError(t, err)
+
+	// The handlers are declared in another file of the package
+	handlers, err := loader.ParseContent("/project/handlers.go", `package main
+
+import "net/http"
+
+func importFile(w http.ResponseWriter, r *http.Request) {
+	f, _, _ := r.FormFile("file")
+	_ = f
+}
+
+func limited(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+	r.ParseMultipartForm(1 << 20)
+}
+
+func listUsers(w http.ResponseWriter, r *http.Request) {}
+`)
+	require.NoError(t, err)
+
+	sources := astutil.NewSourceSet("/project")
+	sources.Add(routes)
+	sources.Add(handlers)
+
+	endpoints, err := NewNetHTTPDiscoverer().Discover(routes)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 4)
+
+	TagUploadEndpoints(endpoints, sources)
+
+	expected := map[string][2]string{
+		"/import":  {"r.FormFile", ""},
+		"/limited": {"r.ParseMultipartForm", "http.MaxBytesReader"},
+		"/wrapped": {"r.FormFile", "http.MaxBytesHandler"},
+		"/users":   {"", ""},
+	}
+	for _, e := range endpoints {
+		assert.Equal(t, expected[e.FullRoute()][0], e.Metadata[models.MetadataUpload], e.FullRoute())
+		assert.Equal(t, expected[e.FullRoute()][1], e.Metadata[models.MetadataBodyLimit], e.FullRoute())
+	}
+}
+
+func TestTagUploadEndpoints_RouterLimit(t *testing.T) {
+	source, err := astutil.NewSourceLoader().ParseContent("/project/main.go", `package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.Default()
+	r.MaxMultipartMemory = 8 << 20
+	r.POST("/avatar", func(c *gin.Context) {
+		file, _ := c.FormFile("avatar")
+		c.SaveUploadedFile(file, "./uploads/avatar.png")
+	})
+}
+`)
+	require.NoError(t, err)
+
+	sources := astutil.NewSourceSet("/project")
+	sources.Add(source)
+
+	endpoints, err := NewGinDiscoverer().Discover(source)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+
+	TagUploadEndpoints(endpoints, sources)
+	assert.Equal(t, "c.FormFile", endpoints[0].Metadata[models.MetadataUpload])
+	assert.Equal(t, "MaxMultipartMemory", endpoints[0].Metadata[models.MetadataBodyLimit])
+}
@@ -0,0 +1,168 @@
+package discovery
+
+import (
+	"go/ast"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// webhookSegments are the route segments of webhook receivers.
+var webhookSegments = map[string]bool{"webhook": true, "webhooks": true}
+
+// webhookVerifiers maps provider SDK import paths to the functions that verify a webhook signature.
+// Paths ending in "/" match any version or subpackage.
+var webhookVerifiers = map[string][]string{
+	"github.com/stripe/stripe-go/":                                {"ConstructEvent", "ConstructEventWithOptions", "ValidatePayload"},
+	"github.com/google/go-github/":                                {"ValidatePayload", "ValidatePayloadFromBody", "ValidateSignature"},
+	"github.com/svix/svix-webhooks/go":                            {"NewWebhook"},
+	"github.com/slack-go/slack":                                   {"NewSecretsVerifier"},
+	"github.com/twilio/twilio-go/client":                          {"NewRequestValidator"},
+	"github.com/standard-webhooks/standard-webhooks/libraries/go": {"NewWebhook"},
+}
+
+// TagWebhookEndpoints marks webhook endpoints and looks for signature verification in their
+// handler, the functions it calls and their middleware: hmac.Equal (or hmac.New with
+// subtle.ConstantTimeCompare), a provider SDK verifier or one of the configured verifiers.
+// A verified signature authenticates the sender, so the verifier is counted as authentication.
+func TagWebhookEndpoints(endpoints []*models.Endpoint, sources *astutil.SourceSet, verifiers []string) {
+	for _, e := range endpoints {
+		if !isWebhookRoute(e.FullRoute()) {
+			continue
+		}
+		if e.Metadata == nil {
+			e.Metadata = make(map[string]string)
+		}
+		e.Metadata[models.MetadataWebhook] = "true"
+
+		verifier := webhookVerifier(e, sources, verifiers)
+		if verifier == "" {
+			continue
+		}
+		e.Metadata[models.MetadataWebhookVerifier] = verifier
+
+		auth := &e.Authorization
+		auth.RequiresAuth = true
+		if auth.Source == "" {
+			auth.Source = "handler"
+		}
+		if !containsName(auth.AuthDependencies, verifier) {
+			auth.AuthDependencies = append(auth.AuthDependencies, verifier)
+		}
+	}
+}
+
+// isWebhookRoute returns true if a route has a webhook segment (/webhooks/stripe, /github-webhook).
+func isWebhookRoute(route string) bool {
+	segments := strings.FieldsFunc(strings.ToLower(route), func(r rune) bool {
+		return r == '/' || r == '-' || r == '_' || r == '.'
+	})
+	for _, seg := range segments {
+		if webhookSegments[seg] {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookVerifier returns the call verifying an endpoint's webhook signature, or "".
+func webhookVerifier(e *models.Endpoint, sources *astutil.SourceSet, verifiers []string) string {
+	source := sources.Get(e.FilePath)
+	if source == nil {
+		return ""
+	}
+
+	bodies := handlerBodies(e, source, sources)
+	for _, mw := range e.Middleware {
+		if mwSource, fn := sources.ResolveFunc(e.FilePath, mw); fn != nil {
+			bodies = append(bodies, handlerBody{source: mwSource, body: fn.Body})
+		}
+	}
+
+	for _, h := range bodies {
+		if verifier := findSignatureCheck(h, verifiers); verifier != "" {
+			return verifier
+		}
+	}
+
+	// A configured verifier used as middleware (router.Use(VerifySignature))
+	for _, mw := range e.Middleware {
+		if matchesName(mw, verifiers) {
+			return mw
+		}
+	}
+	return ""
+}
+
+// findSignatureCheck returns the signature verification call in a function body, or "".
+func findSignatureCheck(h handlerBody, verifiers []string) string {
+	hmacAlias := h.source.GetImportAlias("crypto/hmac")
+	subtleAlias := h.source.GetImportAlias("crypto/subtle")
+
+	found, hmacNew, constantTime := "", false, ""
+	ast.Inspect(h.body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || found != "" {
+			return found == ""
+		}
+		name := astutil.GetCallName(call)
+		switch {
+		case hmacAlias != "" && name == hmacAlias+".Equal":
+			found = name
+		case hmacAlias != "" && name == hmacAlias+".New":
+			hmacNew = true
+		case subtleAlias != "" && name == subtleAlias+".ConstantTimeCompare":
+			constantTime = name
+		case matchesName(name, verifiers) || isProviderVerifier(h.source, name):
+			found = name
+		}
+		return found == ""
+	})
+
+	if found == "" && hmacNew && constantTime != "" {
+		found = constantTime
+	}
+	return found
+}
+
+// isProviderVerifier returns true if a call is a provider SDK's webhook verification function.
+func isProviderVerifier(source *astutil.ParsedSource, name string) bool {
+	dot := strings.LastIndex(name, ".")
+	if dot < 0 {
+		return false
+	}
+	qualifier, fn := name[:dot], name[dot+1:]
+	for importPath, alias := range source.Imports {
+		if alias != qualifier {
+			continue
+		}
+		for prefix, funcs := range webhookVerifiers {
+			if (importPath == prefix || (strings.HasSuffix(prefix, "/") && strings.HasPrefix(importPath, prefix))) && containsName(funcs, fn) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchesName returns true if a call name matches one of the names, by full name or by function name.
+func matchesName(name string, names []string) bool {
+	method := name[strings.LastIndex(name, ".")+1:]
+	for _, n := range names {
+		if name == n || method == n[strings.LastIndex(n, ".")+1:] {
+			return true
+		}
+	}
+	return false
+}
+
+// containsName returns true if a list contains a name.
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+func TestTagWebhookEndpoints(t *testing.T) {
+	loader := astutil.NewSourceLoader()
+
+	routes, err := loader.ParseContent("/project/main.go", `package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.Default()
+	r.POST("/webhooks/stripe", stripeWebhook)
+	r.POST("/webhooks/github", githubWebhook)
+	r.POST("/webhooks/shop", shopWebhook)
+	r.POST("/webhooks/internal", internalWebhook)
+	r.POST("/webhooks/partner", partnerWebhook)
+	r.POST("/orders", createOrder)
+}
+`)
+	require.NoError(t, err)
+
+	// The handlers are declared in another file of the package
+	handlers, err := loader.ParseContent("/project/handlers.go", `package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"io"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v60/github"
+	"github.com/stripe/stripe-go/v76/webhook"
+)
+
+func stripeWebhook(c *gin.Context) {
+	payload, _ := io.ReadAll(c.Request.Body)
+	event, err := webhook.ConstructEvent(payload, c.GetHeader("Stripe-Signature"), secret)
+	_, _ = event, err
+}
+
+func githubWebhook(c *gin.Context) {
+	payload, err := github.ValidatePayload(c.Request, secret)
+	_, _ = payload, err
+}
+
+func shopWebhook(c *gin.Context) {
+	body, _ := io.ReadAll(c.Request.Body)
+	if !validSignature(body, c.GetHeader("X-Shop-Signature")) {
+		c.AbortWithStatus(401)
+	}
+}
+
+func validSignature(body []byte, signature string) bool {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), []byte(signature))
+}
+
+func internalWebhook(c *gin.Context) {
+	var event Event
+	c.BindJSON(&event)
+}
+
+func partnerWebhook(c *gin.Context) {
+	if !events.VerifySignature(c.Request) {
+		c.AbortWithStatus(401)
+	}
+}
+
+func createOrder(c *gin.Context) {}
+`)
+	require.NoError(t, err)
+
+	sources := astutil.NewSourceSet("/project")
+	sources.Add(routes)
+	sources.Add(handlers)
+
+	endpoints, err := NewGinDiscoverer().Discover(routes)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 6)
+
+	TagWebhookEndpoints(endpoints, sources, []string{"events.VerifySignature"})
+
+	expected := map[string]string{
+		"/webhooks/stripe":   "webhook.ConstructEvent",
+		"/webhooks/github":   "github.ValidatePayload",
+		"/webhooks/shop":     "hmac.Equal",
+		"/webhooks/internal": "",
+		"/webhooks/partner":  "events.VerifySignature",
+	}
+	for _, e := range endpoints {
+		want, isWebhook := expected[e.Route]
+		if !isWebhook {
+			assert.NotContains(t, e.Metadata, models.MetadataWebhook, e.Route)
+			continue
+		}
+		assert.Equal(t, "true", e.Metadata[models.MetadataWebhook], e.Route)
+		assert.Equal(t, want, e.Metadata[models.MetadataWebhookVerifier], e.Route)
+		assert.Equal(t, want != "", e.Authorization.RequiresAuth, e.Route)
+	}
+}
@@ -23,6 +23,10 @@ type AuthorizationInfo struct {
 	// AuthDependencies contains auth dependencies (middleware, decorators).
 	AuthDependencies []string `json:"auth_dependencies,omitempty"`
 
+	// Conditions maps auth dependencies to the condition guarding their registration
+	// (e.g., "cfg.AuthEnabled" for middleware registered inside `if cfg.AuthEnabled { ... }`).
+	Conditions map[string]string `json:"conditions,omitempty"`
+
 	// Inherited indicates whether auth is inherited from parent (router, group, etc.).
 	Inherited bool `json:"inherited"`
 
@@ -38,6 +42,7 @@ func NewAuthorizationInfo() AuthorizationInfo {
 		Permissions:      []string{},
 		Policies:         []string{},
 		AuthDependencies: []string{},
+		Conditions:       make(map[string]string),
 	}
 }
 
@@ -46,6 +51,20 @@ func (a *AuthorizationInfo) HasSpecificRequirements() bool {
 	return len(a.Roles) > 0 || len(a.Scopes) > 0 || len(a.Permissions) > 0 || len(a.Policies) > 0
 }
 
+// IsConditional returns true if every auth dependency is registered behind a condition,
+// meaning authentication can be switched off by configuration at runtime.
+func (a *AuthorizationInfo) IsConditional() bool {
+	if len(a.AuthDependencies) == 0 {
+		return false
+	}
+	for _, dep := range a.AuthDependencies {
+		if a.Conditions[dep] == "" {
+			return false
+		}
+	}
+	return true
+}
+
 // IsPublic returns true if the endpoint is effectively public.
 func (a *AuthorizationInfo) IsPublic() bool {
 	return a.AllowsAnonymous || (!a.RequiresAuth && !a.HasSpecificRequirements())
@@ -101,6 +120,7 @@ func (a *AuthorizationInfo) Merge(other AuthorizationInfo, override bool) Author
 		Permissions:      mergeStringSlices(a.Permissions, other.Permissions),
 		Policies:         mergeStringSlices(a.Policies, other.Policies),
 		AuthDependencies: mergeStringSlices(a.AuthDependencies, other.AuthDependencies),
+		Conditions:       mergeStringMaps(a.Conditions, other.Conditions),
 		Inherited:        inherited,
 		Source:           source,
 	}
@@ -127,3 +147,15 @@ func mergeStringSlices(a, b []string) []string {
 
 	return result
 }
+
+// mergeStringMaps merges two string maps, with values from b taking precedence.
+func mergeStringMaps(a, b map[string]string) map[string]string {
+	result := make(map[string]string, len(a)+len(b))
+	for k, v := range a {
+		result[k] = v
+	}
+	for k, v := range b {
+		result[k] = v
+	}
+	return result
+}
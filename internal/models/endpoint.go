@@ -7,6 +7,45 @@ import (
 	"strings"
 )
 
+// Endpoint metadata keys set during discovery.
+const (
+	// MetadataImplicit records what registered an implicit endpoint (an import or a helper call).
+	MetadataImplicit = "implicit"
+
+	// MetadataStaticDir is the directory or file system served by a static file endpoint.
+	MetadataStaticDir = "static_dir"
+
+	// MetadataDirectoryListing is "true" if a static file endpoint lists directory contents.
+	MetadataDirectoryListing = "directory_listing"
+
+	// MetadataProxy is the reverse proxy constructor or middleware that forwards the endpoint's requests.
+	MetadataProxy = "proxy"
+
+	// MetadataProxyTarget is the upstream URL of a proxy endpoint, when it is a literal.
+	MetadataProxyTarget = "proxy_target"
+
+	// MetadataUpload is the call through which an endpoint's handler reads a multipart file upload.
+	MetadataUpload = "upload"
+
+	// MetadataBodyLimit is the mechanism limiting the request body size of an upload endpoint.
+	MetadataBodyLimit = "body_limit"
+
+	// MetadataStateChanging is the mutating call made by the handler of a read-only endpoint (GET /x/delete).
+	MetadataStateChanging = "state_changing"
+
+	// MetadataIPAllowlist is the middleware restricting an endpoint to allowed client IPs.
+	MetadataIPAllowlist = "ip_allowlist"
+
+	// MetadataClientIP is how the IP allowlist middleware obtains the client IP (c.ClientIP, r.RemoteAddr, X-Forwarded-For).
+	MetadataClientIP = "client_ip"
+
+	// MetadataWebhook is "true" if an endpoint receives webhooks (/webhooks/stripe).
+	MetadataWebhook = "webhook"
+
+	// MetadataWebhookVerifier is the call verifying the signature of a webhook endpoint's requests.
+	MetadataWebhookVerifier = "webhook_verifier"
+)
+
 // Endpoint represents a discovered API endpoint.
 type Endpoint struct {
 	// Route is the path (e.g., "/api/users/:id").
@@ -27,6 +66,9 @@ type Endpoint struct {
 	// EndpointType is the type of endpoint definition.
 	EndpointType EndpointType `json:"endpoint_type"`
 
+	// Kind is the kind of connection the endpoint serves (plain HTTP, WebSocket, SSE).
+	Kind EndpointKind `json:"kind,omitempty"`
+
 	// FunctionName is the name of the function/method.
 	FunctionName string `json:"function_name"`
 
@@ -42,6 +84,9 @@ type Endpoint struct {
 	// RouterPrefix is the router/group prefix, if any.
 	RouterPrefix string `json:"router_prefix,omitempty"`
 
+	// Middleware is the middleware chain applied to the endpoint, outermost first.
+	Middleware []string `json:"middleware,omitempty"`
+
 	// Tags are for grouping (similar to Gin groups, etc.).
 	Tags []string `json:"tags,omitempty"`
 
@@ -97,14 +142,31 @@ func (e *Endpoint) ShortLocation() string {
 	return fmt.Sprintf("%s:%d", filepath.Base(e.FilePath), e.LineNumber)
 }
 
-// IsWriteEndpoint returns true if this endpoint handles any write methods.
+// RefMap returns a compact map identifying the endpoint, for referencing it from other objects.
+func (e *Endpoint) RefMap() map[string]interface{} {
+	methods := make([]string, len(e.Methods))
+	for i, m := range e.Methods {
+		methods[i] = string(m)
+	}
+
+	return map[string]interface{}{
+		"route":       e.FullRoute(),
+		"methods":     methods,
+		"file_path":   e.FilePath,
+		"line_number": e.LineNumber,
+	}
+}
+
+// IsWriteEndpoint returns true if this endpoint handles any write methods,
+// or its handler changes state although it only handles read methods.
 func (e *Endpoint) IsWriteEndpoint() bool {
 	for _, m := range e.Methods {
 		if m.IsWriteMethod() {
 			return true
 		}
 	}
-	return false
+	_, stateChanging := e.Metadata[MetadataStateChanging]
+	return stateChanging
 }
 
 // Hash returns a string hash based on route, methods, and file location.
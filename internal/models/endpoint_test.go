@@ -85,6 +85,7 @@ func TestEndpoint_IsWriteEndpoint(t *testing.T) {
 	tests := []struct {
 		name     string
 		methods  []HTTPMethod
+		metadata map[string]string
 		expected bool
 	}{
 		{
@@ -107,11 +108,17 @@ func TestEndpoint_IsWriteEndpoint(t *testing.T) {
 			methods:  []HTTPMethod{MethodHEAD, MethodOPTIONS},
 			expected: false,
 		},
+		{
+			name:     "state-changing GET",
+			methods:  []HTTPMethod{MethodGET},
+			metadata: map[string]string{MetadataStateChanging: "db.Delete"},
+			expected: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			e := &Endpoint{Methods: tt.methods}
+			e := &Endpoint{Methods: tt.methods, Metadata: tt.metadata}
 			assert.Equal(t, tt.expected, e.IsWriteEndpoint())
 		})
 	}
@@ -131,3 +131,17 @@ const (
 func (e EndpointType) String() string {
 	return string(e)
 }
+
+// EndpointKind represents the kind of connection an endpoint serves.
+type EndpointKind string
+
+const (
+	EndpointKindHTTP      EndpointKind = "http"
+	EndpointKindWebSocket EndpointKind = "websocket"
+	EndpointKindSSE       EndpointKind = "sse"
+)
+
+// String returns the string representation of the endpoint kind.
+func (k EndpointKind) String() string {
+	return string(k)
+}
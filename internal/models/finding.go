@@ -1,6 +1,11 @@
 package models
 
-// Finding represents a security finding for an endpoint.
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// Finding represents a security finding for an endpoint, or for the project as a whole.
 type Finding struct {
 	// RuleID is the unique rule identifier (e.g., "AP001").
 	RuleID string `json:"rule_id"`
@@ -15,11 +20,25 @@ type Finding struct {
 	Message string `json:"message"`
 
 	// Endpoint is the endpoint this finding relates to.
+	// Nil for project-level findings, which are located by FilePath and LineNumber instead.
 	Endpoint *Endpoint `json:"endpoint"`
 
+	// FilePath is the source file of a project-level finding.
+	FilePath string `json:"file_path,omitempty"`
+
+	// LineNumber is the source line of a project-level finding.
+	LineNumber int `json:"line_number,omitempty"`
+
 	// Recommendation is the recommendation for fixing the issue.
 	Recommendation string `json:"recommendation,omitempty"`
 
+	// Snippet is a single-line excerpt of the offending source, with secrets redacted.
+	Snippet string `json:"snippet,omitempty"`
+
+	// RelatedEndpoints are other endpoints involved in the finding
+	// (e.g., the other side of a duplicate route registration).
+	RelatedEndpoints []*Endpoint `json:"related_endpoints,omitempty"`
+
 	// Suppressed indicates whether this finding is suppressed by configuration.
 	Suppressed bool `json:"suppressed"`
 
@@ -38,24 +57,72 @@ func NewFinding(ruleID, ruleName string, severity Severity, message string, endp
 	}
 }
 
+// NewProjectFinding creates a new Finding that is located at a source position
+// rather than tied to a single endpoint.
+func NewProjectFinding(ruleID, ruleName string, severity Severity, message, filePath string, lineNumber int) *Finding {
+	return &Finding{
+		RuleID:     ruleID,
+		RuleName:   ruleName,
+		Severity:   severity,
+		Message:    message,
+		FilePath:   filePath,
+		LineNumber: lineNumber,
+	}
+}
+
+// IsProjectLevel returns true if the finding is not tied to a single endpoint.
+func (f *Finding) IsProjectLevel() bool {
+	return f.Endpoint == nil
+}
+
+// File returns the source file of the finding.
+func (f *Finding) File() string {
+	if f.Endpoint != nil {
+		return f.Endpoint.FilePath
+	}
+	return f.FilePath
+}
+
 // Location returns the display string for the finding location.
 func (f *Finding) Location() string {
-	return f.Endpoint.Location()
+	if f.Endpoint != nil {
+		return f.Endpoint.Location()
+	}
+	return fmt.Sprintf("%s:%d", f.FilePath, f.LineNumber)
+}
+
+// ShortLocation returns a display string with just filename and line.
+func (f *Finding) ShortLocation() string {
+	if f.Endpoint != nil {
+		return f.Endpoint.ShortLocation()
+	}
+	return fmt.Sprintf("%s:%d", filepath.Base(f.FilePath), f.LineNumber)
 }
 
-// Route returns the endpoint route.
+// Route returns the endpoint route, or an empty string for project-level findings.
 func (f *Finding) Route() string {
+	if f.Endpoint == nil {
+		return ""
+	}
 	return f.Endpoint.FullRoute()
 }
 
+// DisplayMethods returns the endpoint methods, or an empty string for project-level findings.
+func (f *Finding) DisplayMethods() string {
+	if f.Endpoint == nil {
+		return ""
+	}
+	return f.Endpoint.DisplayMethods()
+}
+
 // ToMap converts the finding to a map for JSON serialization.
 func (f *Finding) ToMap() map[string]interface{} {
-	methods := make([]string, len(f.Endpoint.Methods))
-	for i, m := range f.Endpoint.Methods {
-		methods[i] = string(m)
+	related := make([]map[string]interface{}, len(f.RelatedEndpoints))
+	for i, e := range f.RelatedEndpoints {
+		related[i] = e.RefMap()
 	}
 
-	return map[string]interface{}{
+	result := map[string]interface{}{
 		"rule_id":            f.RuleID,
 		"rule_name":          f.RuleName,
 		"severity":           string(f.Severity),
@@ -63,15 +130,34 @@ func (f *Finding) ToMap() map[string]interface{} {
 		"recommendation":     f.Recommendation,
 		"suppressed":         f.Suppressed,
 		"suppression_reason": f.SuppressionReason,
-		"endpoint": map[string]interface{}{
-			"route":          f.Endpoint.FullRoute(),
-			"methods":        methods,
-			"file_path":      f.Endpoint.FilePath,
-			"line_number":    f.Endpoint.LineNumber,
-			"framework":      string(f.Endpoint.Framework),
-			"function_name":  f.Endpoint.FunctionName,
-			"class_name":     f.Endpoint.ClassName,
-			"classification": string(f.Endpoint.Classification),
-		},
+		"related_endpoints":  related,
+		"snippet":            f.Snippet,
+	}
+
+	if f.Endpoint == nil {
+		result["endpoint"] = nil
+		result["file_path"] = f.FilePath
+		result["line_number"] = f.LineNumber
+		return result
 	}
+
+	methods := make([]string, len(f.Endpoint.Methods))
+	for i, m := range f.Endpoint.Methods {
+		methods[i] = string(m)
+	}
+
+	result["file_path"] = f.Endpoint.FilePath
+	result["line_number"] = f.Endpoint.LineNumber
+	result["endpoint"] = map[string]interface{}{
+		"route":          f.Endpoint.FullRoute(),
+		"methods":        methods,
+		"file_path":      f.Endpoint.FilePath,
+		"line_number":    f.Endpoint.LineNumber,
+		"framework":      string(f.Endpoint.Framework),
+		"function_name":  f.Endpoint.FunctionName,
+		"class_name":     f.Endpoint.ClassName,
+		"classification": string(f.Endpoint.Classification),
+	}
+
+	return result
 }
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFinding_EndpointLocation(t *testing.T) {
+	f := NewFinding("AP001", "Rule", SeverityHigh, "message", &Endpoint{
+		Route:      "/users",
+		Methods:    []HTTPMethod{MethodGET},
+		FilePath:   "/path/to/routes.go",
+		LineNumber: 12,
+	})
+
+	assert.False(t, f.IsProjectLevel())
+	assert.Equal(t, "/users", f.Route())
+	assert.Equal(t, "GET", f.DisplayMethods())
+	assert.Equal(t, "/path/to/routes.go", f.File())
+	assert.Equal(t, "/path/to/routes.go:12", f.Location())
+	assert.Equal(t, "routes.go:12", f.ShortLocation())
+}
+
+func TestFinding_ProjectLevelLocation(t *testing.T) {
+	f := NewProjectFinding("AP999", "Rule", SeverityMedium, "message", "/path/to/main.go", 7)
+
+	assert.True(t, f.IsProjectLevel())
+	assert.Equal(t, "", f.Route())
+	assert.Equal(t, "", f.DisplayMethods())
+	assert.Equal(t, "/path/to/main.go", f.File())
+	assert.Equal(t, "/path/to/main.go:7", f.Location())
+	assert.Equal(t, "main.go:7", f.ShortLocation())
+
+	m := f.ToMap()
+	assert.Nil(t, m["endpoint"])
+	assert.Equal(t, "/path/to/main.go", m["file_path"])
+	assert.Equal(t, 7, m["line_number"])
+}
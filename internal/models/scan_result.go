@@ -164,6 +164,12 @@ func (r *ScanResult) ToMap() map[string]interface{} {
 			"function_name":  e.FunctionName,
 			"class_name":     e.ClassName,
 		}
+		if e.Kind != "" {
+			endpoints[i]["kind"] = string(e.Kind)
+		}
+		if len(e.Metadata) > 0 {
+			endpoints[i]["metadata"] = e.Metadata
+		}
 	}
 
 	findings := make([]map[string]interface{}, len(r.Findings))
@@ -113,10 +113,21 @@ func (f *MarkdownFormatter) writeFindings(result *models.ScanResult, w io.Writer
 
 		for _, finding := range findings {
 			fmt.Fprintf(w, "#### %s: %s\n\n", finding.RuleID, finding.RuleName)
-			fmt.Fprintf(w, "- **Route:** `%s`\n", finding.Endpoint.FullRoute())
-			fmt.Fprintf(w, "- **Methods:** %s\n", finding.Endpoint.DisplayMethods())
+			if finding.IsProjectLevel() {
+				fmt.Fprintln(w, "- **Scope:** Project")
+			} else {
+				fmt.Fprintf(w, "- **Route:** `%s`\n", finding.Endpoint.FullRoute())
+				fmt.Fprintf(w, "- **Methods:** %s\n", finding.Endpoint.DisplayMethods())
+			}
 			fmt.Fprintf(w, "- **Location:** `%s`\n", finding.Location())
+			if finding.Snippet != "" {
+				fmt.Fprintf(w, "- **Code:** `%s`\n", finding.Snippet)
+			}
 			fmt.Fprintf(w, "- **Message:** %s\n", finding.Message)
+			for _, related := range finding.RelatedEndpoints {
+				fmt.Fprintf(w, "- **Related:** `%s` %s (`%s`)\n",
+					related.FullRoute(), related.DisplayMethods(), related.Location())
+			}
 			if finding.Recommendation != "" {
 				fmt.Fprintf(w, "- **Recommendation:** %s\n", finding.Recommendation)
 			}
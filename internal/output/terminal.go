@@ -227,8 +227,18 @@ func (f *TerminalFormatter) writeFindingPanel(finding *models.Finding, w io.Writ
 	innerWidth := panelWidth - 4 // "│ " + " │"
 
 	var lines []string
-	lines = append(lines, fmt.Sprintf("Route:    %s", finding.Endpoint.FullRoute()))
-	lines = append(lines, fmt.Sprintf("Location: %s", finding.Endpoint.ShortLocation()))
+	if finding.IsProjectLevel() {
+		lines = append(lines, "Scope:    project")
+	} else {
+		lines = append(lines, fmt.Sprintf("Route:    %s", finding.Endpoint.FullRoute()))
+	}
+	lines = append(lines, fmt.Sprintf("Location: %s", finding.ShortLocation()))
+	if finding.Snippet != "" {
+		lines = append(lines, truncate(fmt.Sprintf("Code:     %s", finding.Snippet), innerWidth))
+	}
+	for _, related := range finding.RelatedEndpoints {
+		lines = append(lines, truncate(fmt.Sprintf("Related:  %s (%s)", related.FullRoute(), related.ShortLocation()), innerWidth))
+	}
 	lines = append(lines, "")
 	lines = append(lines, wrapText(finding.Message, innerWidth)...)
 	if finding.Recommendation != "" {
@@ -0,0 +1,179 @@
+package routing
+
+import (
+	"path/filepath"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// ConflictKind is the type of collision between two route registrations.
+type ConflictKind string
+
+const (
+	// ConflictDuplicate means the same method and path are registered twice.
+	ConflictDuplicate ConflictKind = "duplicate"
+
+	// ConflictTrailingSlash means two registrations differ only by a trailing slash
+	// and are served by different handlers or with different authorization.
+	ConflictTrailingSlash ConflictKind = "trailing_slash"
+
+	// ConflictShadow means a wildcard registration catches requests for a more
+	// specific route with stronger authorization before that route is reached.
+	ConflictShadow ConflictKind = "shadow"
+)
+
+// Conflict describes a collision between two endpoint registrations.
+type Conflict struct {
+	// Kind is the type of conflict.
+	Kind ConflictKind
+
+	// Endpoint is the registration the conflict is reported on.
+	Endpoint *models.Endpoint
+
+	// Other is the conflicting registration.
+	Other *models.Endpoint
+}
+
+// Scope returns the programs serving an endpoint. Endpoints are only compared when they share a
+// program; an endpoint without programs is compared with none.
+type Scope func(e *models.Endpoint) []string
+
+// PackageScope scopes endpoints to the package directory they are registered in.
+func PackageScope(e *models.Endpoint) []string {
+	return []string{filepath.Dir(e.FilePath)}
+}
+
+// FindConflicts detects duplicate, overlapping and shadowing registrations.
+// Only endpoints from the same framework and program are compared, since routes
+// from different frameworks or binaries are served by different routers.
+func FindConflicts(endpoints []*models.Endpoint, scope Scope) []Conflict {
+	var conflicts []Conflict
+
+	normalized := make([]string, len(endpoints))
+	programs := make([][]string, len(endpoints))
+	for i, e := range endpoints {
+		normalized[i] = NormalizeEndpoint(e)
+		programs[i] = scope(e)
+	}
+
+	for i := 0; i < len(endpoints); i++ {
+		for j := i + 1; j < len(endpoints); j++ {
+			a, b := endpoints[i], endpoints[j]
+			if a.Framework != b.Framework || !sharesMethod(a, b) || !sharesProgram(programs[i], programs[j]) {
+				continue
+			}
+			if a.FilePath == b.FilePath && a.LineNumber == b.LineNumber {
+				continue
+			}
+
+			if normalized[i] == normalized[j] {
+				if conflict, ok := exactConflict(a, b); ok {
+					conflicts = append(conflicts, conflict)
+				}
+				continue
+			}
+
+			if conflict, ok := shadowConflict(a, normalized[i], b, normalized[j]); ok {
+				conflicts = append(conflicts, conflict)
+			}
+		}
+	}
+
+	return conflicts
+}
+
+// exactConflict classifies two registrations whose normalised paths are equal.
+// The later registration (b) is reported, referencing the earlier one.
+func exactConflict(a, b *models.Endpoint) (Conflict, bool) {
+	slashA := HasTrailingSlash(stripMethodPrefix(a.FullRoute()))
+	slashB := HasTrailingSlash(stripMethodPrefix(b.FullRoute()))
+
+	// Fiber ignores trailing slashes unless StrictRouting is enabled, and net/http
+	// subtree patterns are already folded into the wildcard by NormalizeEndpoint.
+	if slashA == slashB || a.Framework == models.FrameworkFiber || a.Framework == models.FrameworkNetHTTP {
+		return Conflict{Kind: ConflictDuplicate, Endpoint: b, Other: a}, true
+	}
+
+	if a.FunctionName != b.FunctionName || a.Classification != b.Classification {
+		return Conflict{Kind: ConflictTrailingSlash, Endpoint: b, Other: a}, true
+	}
+
+	return Conflict{}, false
+}
+
+// shadowConflict checks whether a wildcard registration overlaps a more specific
+// route that has stronger authorization. The protected route is reported.
+// Gin, Echo, Chi and net/http prefer the most specific route regardless of
+// registration order, so shadowing only happens in routers that match in order.
+func shadowConflict(a *models.Endpoint, normA string, b *models.Endpoint, normB string) (Conflict, bool) {
+	if !matchesInRegistrationOrder(a.Framework) {
+		return Conflict{}, false
+	}
+	if shadows(a, normA, b, normB) {
+		return Conflict{Kind: ConflictShadow, Endpoint: b, Other: a}, true
+	}
+	if shadows(b, normB, a, normA) {
+		return Conflict{Kind: ConflictShadow, Endpoint: a, Other: b}, true
+	}
+	return Conflict{}, false
+}
+
+// shadows returns true if the public wildcard route is registered before the
+// protected route it covers. Registration order is only known within a file.
+func shadows(wildcard *models.Endpoint, normWildcard string, route *models.Endpoint, normRoute string) bool {
+	return coversRoute(normWildcard, normRoute) &&
+		wildcard.Authorization.IsPublic() && !route.Authorization.IsPublic() &&
+		wildcard.FilePath == route.FilePath && wildcard.LineNumber < route.LineNumber
+}
+
+// matchesInRegistrationOrder returns true for routers that dispatch a request
+// to the first registered route that matches it.
+func matchesInRegistrationOrder(framework models.Framework) bool {
+	return framework == models.FrameworkFiber
+}
+
+// coversRoute returns true if the wildcard route pattern matches every request
+// that the specific route matches.
+func coversRoute(pattern, route string) bool {
+	patSegs := Segments(pattern)
+	if len(patSegs) == 0 || patSegs[len(patSegs)-1] != WildcardSegment {
+		return false
+	}
+
+	routeSegs := Segments(route)
+	prefix := patSegs[:len(patSegs)-1]
+	if len(routeSegs) < len(prefix) {
+		return false
+	}
+
+	for i, seg := range prefix {
+		if seg != routeSegs[i] && seg != ParamSegment {
+			return false
+		}
+	}
+	return true
+}
+
+// sharesProgram returns true if two endpoints are served by a common program.
+func sharesProgram(a, b []string) bool {
+	for _, pa := range a {
+		for _, pb := range b {
+			if pa == pb {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sharesMethod returns true if two endpoints handle at least one common HTTP method.
+func sharesMethod(a, b *models.Endpoint) bool {
+	for _, ma := range a.Methods {
+		for _, mb := range b.Methods {
+			if ma == mb {
+				return true
+			}
+		}
+	}
+	return false
+}
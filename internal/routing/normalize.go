@@ -0,0 +1,126 @@
+// Package routing normalises routes across frameworks and detects conflicting registrations.
+package routing
+
+import (
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// Placeholders used in normalised routes.
+const (
+	// ParamSegment replaces a single path parameter (":id", "{id}", "<id>").
+	ParamSegment = "{}"
+
+	// WildcardSegment replaces a catch-all segment ("*", "*path", "{path...}").
+	WildcardSegment = "*"
+)
+
+// Normalize converts a framework-specific route into a canonical form so that
+// routes from Gin, Echo, Chi, Fiber and net/http can be compared.
+// Path parameters become "{}", catch-all segments become "*", duplicate and
+// trailing slashes are removed, and Go 1.22 method prefixes ("GET /x") are dropped.
+func Normalize(route string) string {
+	route = stripMethodPrefix(strings.TrimSpace(route))
+
+	var segments []string
+	for _, seg := range strings.Split(route, "/") {
+		if seg == "" {
+			continue
+		}
+		seg = normalizeSegment(seg)
+		if seg == "" {
+			continue
+		}
+		segments = append(segments, seg)
+		if seg == WildcardSegment {
+			// Nothing after a catch-all can be matched separately
+			break
+		}
+	}
+
+	return "/" + strings.Join(segments, "/")
+}
+
+// NormalizeEndpoint normalises an endpoint's full route, taking framework
+// matching semantics into account (net/http patterns ending in "/" match a subtree).
+func NormalizeEndpoint(endpoint *models.Endpoint) string {
+	route := endpoint.FullRoute()
+	normalized := Normalize(route)
+
+	if endpoint.Framework == models.FrameworkNetHTTP && strings.HasSuffix(stripMethodPrefix(route), "/") {
+		if normalized == "/" {
+			return "/" + WildcardSegment
+		}
+		if !strings.HasSuffix(normalized, WildcardSegment) {
+			return normalized + "/" + WildcardSegment
+		}
+	}
+
+	return normalized
+}
+
+// HasTrailingSlash returns true if a non-root route ends with a slash.
+func HasTrailingSlash(route string) bool {
+	route = strings.TrimSpace(route)
+	return len(route) > 1 && strings.HasSuffix(route, "/")
+}
+
+// Segments splits a normalised route into its segments.
+func Segments(normalized string) []string {
+	return strings.FieldsFunc(normalized, func(r rune) bool { return r == '/' })
+}
+
+// ParamNames returns the names of the path parameters of a framework-specific route
+// (":id", "{userID}", "{id:[0-9]+}", "<id>"), without pattern or optional markers.
+// Catch-all segments are not included.
+func ParamNames(route string) []string {
+	var names []string
+	for _, seg := range strings.Split(stripMethodPrefix(route), "/") {
+		if normalizeSegment(seg) != ParamSegment {
+			continue
+		}
+		name := strings.Trim(seg, ":{}<>")
+		if idx := strings.IndexAny(name, ":<?"); idx >= 0 {
+			name = name[:idx]
+		}
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// IsParam returns true if a normalised segment is a parameter or wildcard.
+func IsParam(segment string) bool {
+	return segment == ParamSegment || segment == WildcardSegment
+}
+
+// stripMethodPrefix removes a Go 1.22 ServeMux method prefix ("GET /users" -> "/users").
+func stripMethodPrefix(route string) string {
+	if idx := strings.Index(route, " "); idx > 0 && !strings.HasPrefix(route, "/") {
+		return strings.TrimSpace(route[idx+1:])
+	}
+	return route
+}
+
+// normalizeSegment converts a single path segment to its canonical form.
+func normalizeSegment(seg string) string {
+	switch {
+	case seg == "{$}":
+		// net/http exact-match marker
+		return ""
+	case strings.HasPrefix(seg, "*"), seg == "+":
+		return WildcardSegment
+	case strings.HasPrefix(seg, ":"):
+		return ParamSegment
+	case strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}"):
+		if strings.HasSuffix(seg, "...}") {
+			return WildcardSegment
+		}
+		return ParamSegment
+	case strings.HasPrefix(seg, "<") && strings.HasSuffix(seg, ">"):
+		return ParamSegment
+	}
+	return seg
+}
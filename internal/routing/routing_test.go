@@ -0,0 +1,175 @@
+package routing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		route    string
+		expected string
+	}{
+		{"/users/:id", "/users/{}"},
+		{"/users/{id}", "/users/{}"},
+		{"/users/{id:[0-9]+}", "/users/{}"},
+		{"/users/<id>", "/users/{}"},
+		{"/files/*filepath", "/files/*"},
+		{"/files/*", "/files/*"},
+		{"/files/{path...}", "/files/*"},
+		{"GET /users/{id}", "/users/{}"},
+		{"/admin/", "/admin"},
+		{"//api//users", "/api/users"},
+		{"/", "/"},
+		{"", "/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.route, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Normalize(tt.route))
+		})
+	}
+}
+
+func TestParamNames(t *testing.T) {
+	tests := []struct {
+		route    string
+		expected []string
+	}{
+		{"/users/:id", []string{"id"}},
+		{"/users/{userID}/orders/:orderId", []string{"userID", "orderId"}},
+		{"/users/{id:[0-9]+}", []string{"id"}},
+		{"/users/:id<int>", []string{"id"}},
+		{"/users/:id?", []string{"id"}},
+		{"/users/<id>", []string{"id"}},
+		{"GET /items/{item_id}", []string{"item_id"}},
+		{"/files/*filepath", nil},
+		{"/files/{path...}", nil},
+		{"/users", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.route, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParamNames(tt.route))
+		})
+	}
+}
+
+func TestNormalizeEndpoint_NetHTTPSubtree(t *testing.T) {
+	e := &models.Endpoint{Route: "/static/", Framework: models.FrameworkNetHTTP}
+	assert.Equal(t, "/static/*", NormalizeEndpoint(e))
+
+	root := &models.Endpoint{Route: "/", Framework: models.FrameworkNetHTTP}
+	assert.Equal(t, "/*", NormalizeEndpoint(root))
+}
+
+func newEndpoint(route, file string, line int, method models.HTTPMethod, auth bool) *models.Endpoint {
+	e := &models.Endpoint{
+		Route:         route,
+		Methods:       []models.HTTPMethod{method},
+		FilePath:      file,
+		LineNumber:    line,
+		Framework:     models.FrameworkGin,
+		FunctionName:  "handler",
+		Authorization: models.NewAuthorizationInfo(),
+	}
+	if auth {
+		e.Authorization.RequiresAuth = true
+		e.Authorization.AuthDependencies = []string{"AuthMiddleware"}
+		e.Classification = models.ClassificationAuthenticated
+	}
+	return e
+}
+
+func withFramework(e *models.Endpoint, framework models.Framework) *models.Endpoint {
+	e.Framework = framework
+	return e
+}
+
+func TestFindConflicts(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoints []*models.Endpoint
+		expected  []ConflictKind
+	}{
+		{
+			name: "same method and path in two files",
+			endpoints: []*models.Endpoint{
+				newEndpoint("/users/:id", "a.go", 10, models.MethodGET, false),
+				newEndpoint("/users/{id}", "b.go", 20, models.MethodGET, false),
+			},
+			expected: []ConflictKind{ConflictDuplicate},
+		},
+		{
+			name: "same method and path in two main packages",
+			endpoints: []*models.Endpoint{
+				newEndpoint("/health", "cmd/a/main.go", 10, models.MethodGET, false),
+				newEndpoint("/health", "cmd/b/main.go", 10, models.MethodGET, false),
+			},
+			expected: nil,
+		},
+		{
+			name: "same path with different methods",
+			endpoints: []*models.Endpoint{
+				newEndpoint("/users", "a.go", 10, models.MethodGET, false),
+				newEndpoint("/users", "a.go", 11, models.MethodPOST, false),
+			},
+			expected: nil,
+		},
+		{
+			name: "trailing slash variant with different auth",
+			endpoints: []*models.Endpoint{
+				newEndpoint("/admin/", "a.go", 10, models.MethodGET, true),
+				newEndpoint("/admin", "a.go", 20, models.MethodGET, false),
+			},
+			expected: []ConflictKind{ConflictTrailingSlash},
+		},
+		{
+			name: "public wildcard registered first shadows protected route in fiber",
+			endpoints: []*models.Endpoint{
+				withFramework(newEndpoint("/*", "a.go", 10, models.MethodGET, false), models.FrameworkFiber),
+				withFramework(newEndpoint("/admin/x", "a.go", 20, models.MethodGET, true), models.FrameworkFiber),
+			},
+			expected: []ConflictKind{ConflictShadow},
+		},
+		{
+			name: "public wildcard registered after protected route in fiber",
+			endpoints: []*models.Endpoint{
+				withFramework(newEndpoint("/admin/x", "a.go", 10, models.MethodGET, true), models.FrameworkFiber),
+				withFramework(newEndpoint("/*", "a.go", 20, models.MethodGET, false), models.FrameworkFiber),
+			},
+			expected: nil,
+		},
+		{
+			name: "static route takes precedence over wildcard in gin",
+			endpoints: []*models.Endpoint{
+				newEndpoint("/*path", "a.go", 10, models.MethodGET, false),
+				newEndpoint("/admin/x", "a.go", 20, models.MethodGET, true),
+			},
+			expected: nil,
+		},
+		{
+			name: "protected wildcard does not shadow",
+			endpoints: []*models.Endpoint{
+				withFramework(newEndpoint("/admin/*", "a.go", 10, models.MethodGET, true), models.FrameworkFiber),
+				withFramework(newEndpoint("/admin/x", "a.go", 20, models.MethodGET, true), models.FrameworkFiber),
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conflicts := FindConflicts(tt.endpoints, PackageScope)
+			require.Len(t, conflicts, len(tt.expected))
+			for i, kind := range tt.expected {
+				assert.Equal(t, kind, conflicts[i].Kind)
+				assert.NotNil(t, conflicts[i].Other)
+			}
+		})
+	}
+}
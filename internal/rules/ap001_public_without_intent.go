@@ -53,8 +53,8 @@ func (r *AP001PublicWithoutIntent) Evaluate(endpoint *models.Endpoint) []*models
 		return nil
 	}
 
-	// Skip known public entry points (auth flows, health checks, oauth callbacks)
-	if isKnownPublicEndpoint(endpoint.FullRoute()) {
+	// Skip known public entry points (auth flows, health checks, oauth callbacks) and unsigned webhooks (AP040)
+	if isKnownPublicEndpoint(endpoint.FullRoute()) || isUnsignedWebhook(endpoint) {
 		return nil
 	}
 
@@ -55,16 +55,20 @@ func (r *AP004MissingAuthWrites) Evaluate(endpoint *models.Endpoint) []*models.F
 	}
 
 	// Skip known auth entry points (login, register, oauth, token endpoints are intentionally public writes)
-	if isKnownPublicEndpoint(endpoint.FullRoute()) {
+	// and unsigned webhooks, which AP040 reports
+	if isKnownPublicEndpoint(endpoint.FullRoute()) || isUnsignedWebhook(endpoint) {
 		return nil
 	}
 
 	// Flag if there's no auth at all
 	if !auth.RequiresAuth && len(auth.AuthDependencies) == 0 && !auth.HasSpecificRequirements() {
+		message := fmt.Sprintf("Write endpoint '%s' [%s] has no authentication",
+			endpoint.FullRoute(), endpoint.DisplayMethods())
+		if call, ok := endpoint.Metadata[models.MetadataStateChanging]; ok {
+			message += fmt.Sprintf(" (its handler changes state: %s)", call)
+		}
 		return []*models.Finding{
-			createFinding(r, endpoint,
-				fmt.Sprintf("Write endpoint '%s' [%s] has no authentication",
-					endpoint.FullRoute(), endpoint.DisplayMethods()),
+			createFinding(r, endpoint, message,
 				"Add authentication middleware to protect this write endpoint",
 			),
 		}
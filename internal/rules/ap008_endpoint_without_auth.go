@@ -54,8 +54,8 @@ func (r *AP008EndpointWithoutAuth) Evaluate(endpoint *models.Endpoint) []*models
 		return nil
 	}
 
-	// Skip known public endpoints (health checks, auth flows, etc.)
-	if isKnownPublicEndpoint(endpoint.FullRoute()) {
+	// Skip known public endpoints (health checks, auth flows, etc.) and unsigned webhooks (AP040)
+	if isKnownPublicEndpoint(endpoint.FullRoute()) || isUnsignedWebhook(endpoint) {
 		return nil
 	}
 
@@ -0,0 +1,99 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// configConditionMarkers are substrings indicating that a condition is driven
+// by configuration, feature flags, or environment variables.
+var configConditionMarkers = []string{
+	"os.getenv",
+	"os.lookupenv",
+	"flag.",
+	"viper.",
+	"cfg.",
+	"conf.",
+	"config.",
+	"settings.",
+	"env",
+	"debug",
+	"enabled",
+	"disable",
+	"mode",
+}
+
+// AP009ConditionalAuth flags endpoints whose authentication can be switched off at runtime.
+type AP009ConditionalAuth struct{}
+
+// NewAP009ConditionalAuth creates a new AP009 rule.
+func NewAP009ConditionalAuth() *AP009ConditionalAuth {
+	return &AP009ConditionalAuth{}
+}
+
+// ID returns the rule ID.
+func (r *AP009ConditionalAuth) ID() string {
+	return "AP009"
+}
+
+// Name returns the rule name.
+func (r *AP009ConditionalAuth) Name() string {
+	return "Conditionally enabled authentication"
+}
+
+// Severity returns the rule severity.
+func (r *AP009ConditionalAuth) Severity() models.Severity {
+	return models.SeverityHigh
+}
+
+// Description returns the rule description.
+func (r *AP009ConditionalAuth) Description() string {
+	return "Authentication middleware is only registered when a condition holds. " +
+		"A wrong flag or environment variable ships the service with authentication disabled."
+}
+
+// Evaluate checks if all of the endpoint's authentication is guarded by a condition.
+func (r *AP009ConditionalAuth) Evaluate(endpoint *models.Endpoint) []*models.Finding {
+	auth := &endpoint.Authorization
+
+	if !auth.IsConditional() {
+		return nil
+	}
+
+	var guards []string
+	configDriven := false
+	for _, dep := range auth.AuthDependencies {
+		cond := auth.Conditions[dep]
+		guards = append(guards, fmt.Sprintf("%s (if %s)", dep, cond))
+		if isConfigCondition(cond) {
+			configDriven = true
+		}
+	}
+	sort.Strings(guards)
+
+	message := fmt.Sprintf("Authentication on '%s' is only registered conditionally: %s",
+		endpoint.FullRoute(), strings.Join(guards, ", "))
+	if configDriven {
+		message += "; the condition depends on configuration or environment variables"
+	}
+
+	return []*models.Finding{
+		createFinding(r, endpoint, message,
+			"Register authentication middleware unconditionally, or fail startup when auth is disabled outside local development",
+		),
+	}
+}
+
+// isConfigCondition returns true if a condition reads configuration or environment state.
+func isConfigCondition(condition string) bool {
+	lower := strings.ToLower(condition)
+	for _, marker := range configConditionMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
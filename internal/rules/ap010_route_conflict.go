@@ -0,0 +1,129 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/routing"
+)
+
+// AP010RouteConflict flags duplicate, overlapping and shadowing route registrations.
+type AP010RouteConflict struct{}
+
+// NewAP010RouteConflict creates a new AP010 rule.
+func NewAP010RouteConflict() *AP010RouteConflict {
+	return &AP010RouteConflict{}
+}
+
+// ID returns the rule ID.
+func (r *AP010RouteConflict) ID() string {
+	return "AP010"
+}
+
+// Name returns the rule name.
+func (r *AP010RouteConflict) Name() string {
+	return "Route conflict"
+}
+
+// Severity returns the rule severity.
+func (r *AP010RouteConflict) Severity() models.Severity {
+	return models.SeverityMedium
+}
+
+// Description returns the rule description.
+func (r *AP010RouteConflict) Description() string {
+	return "Two registrations collide on the same method and path, differ only by a trailing slash, " +
+		"or a public wildcard route overlaps a protected route."
+}
+
+// EvaluateProject checks the endpoints of each program for colliding registrations.
+func (r *AP010RouteConflict) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+
+	for _, conflict := range routing.FindConflicts(result.Endpoints, programScope(sources)) {
+		e, other := conflict.Endpoint, conflict.Other
+
+		var finding *models.Finding
+		switch conflict.Kind {
+		case routing.ConflictDuplicate:
+			finding = createFinding(r, e,
+				fmt.Sprintf("Route '%s' [%s] is registered more than once: %s and %s",
+					e.FullRoute(), e.DisplayMethods(), other.ShortLocation(), e.ShortLocation()),
+				"Remove the duplicate registration; only one of the handlers will ever be reached",
+			)
+		case routing.ConflictTrailingSlash:
+			finding = createFinding(r, e,
+				fmt.Sprintf("Route '%s' and '%s' differ only by a trailing slash but use different handlers or authorization (%s, %s)",
+					e.FullRoute(), other.FullRoute(), other.ShortLocation(), e.ShortLocation()),
+				"Register a single canonical path, and disable trailing-slash redirects if both variants must exist",
+			)
+		case routing.ConflictShadow:
+			finding = createFinding(r, e,
+				fmt.Sprintf("Protected route '%s' is overlapped by public wildcard route '%s' (%s)",
+					e.FullRoute(), other.FullRoute(), other.ShortLocation()),
+				"Narrow the wildcard route or apply the same authentication to it",
+			)
+			finding.Severity = models.SeverityHigh
+		default:
+			continue
+		}
+
+		finding.RelatedEndpoints = []*models.Endpoint{other}
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
+
+// programScope scopes endpoints to the main packages that import their package, directly or
+// through other scanned packages, so routes of separate binaries (cmd/a, cmd/b) are not compared.
+// Endpoints of packages no main package imports are scoped to their package, and endpoints
+// registered in test files, which run their own test servers, are compared with none.
+func programScope(sources *astutil.SourceSet) routing.Scope {
+	imports := make(map[string][]string)
+	var mains []string
+	for _, source := range sources.Files {
+		if strings.HasSuffix(source.FilePath, "_test.go") {
+			continue
+		}
+		dir := filepath.Dir(source.FilePath)
+		if source.AST.Name.Name == "main" && !containsString(mains, dir) {
+			mains = append(mains, dir)
+		}
+		for importPath := range source.Imports {
+			if pkg := sources.PackageForImport(importPath); len(pkg) > 0 {
+				imports[dir] = append(imports[dir], filepath.Dir(pkg[0].FilePath))
+			}
+		}
+	}
+
+	programs := make(map[string][]string)
+	for _, mainDir := range mains {
+		seen := map[string]bool{mainDir: true}
+		queue := []string{mainDir}
+		for len(queue) > 0 {
+			dir := queue[0]
+			queue = queue[1:]
+			programs[dir] = append(programs[dir], mainDir)
+			for _, imported := range imports[dir] {
+				if !seen[imported] {
+					seen[imported] = true
+					queue = append(queue, imported)
+				}
+			}
+		}
+	}
+
+	return func(e *models.Endpoint) []string {
+		if strings.HasSuffix(e.FilePath, "_test.go") {
+			return nil
+		}
+		if served, ok := programs[filepath.Dir(e.FilePath)]; ok {
+			return served
+		}
+		return routing.PackageScope(e)
+	}
+}
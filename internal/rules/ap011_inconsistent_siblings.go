@@ -0,0 +1,262 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/routing"
+)
+
+// versionSegment matches API version path segments such as "v1" or "v2beta".
+var versionSegment = regexp.MustCompile(`^v[0-9]+[a-z0-9]*$`)
+
+// AP011InconsistentSiblings flags endpoints whose auth is weaker than related endpoints.
+type AP011InconsistentSiblings struct{}
+
+// NewAP011InconsistentSiblings creates a new AP011 rule.
+func NewAP011InconsistentSiblings() *AP011InconsistentSiblings {
+	return &AP011InconsistentSiblings{}
+}
+
+// ID returns the rule ID.
+func (r *AP011InconsistentSiblings) ID() string {
+	return "AP011"
+}
+
+// Name returns the rule name.
+func (r *AP011InconsistentSiblings) Name() string {
+	return "Inconsistent authorization among sibling endpoints"
+}
+
+// Severity returns the rule severity.
+func (r *AP011InconsistentSiblings) Severity() models.Severity {
+	return models.SeverityMedium
+}
+
+// Description returns the rule description.
+func (r *AP011InconsistentSiblings) Description() string {
+	return "Endpoint is less protected than sibling endpoints on the same resource, another version of its route, its router group, or handler. " +
+		"Inconsistent protection usually means middleware was forgotten on one registration."
+}
+
+// EvaluateProject clusters related endpoints and flags the weaker outliers.
+// Resource and version clusters are evaluated first, so an endpoint is compared with
+// the other methods and versions of its own resource before the rest of its group.
+// There a single stronger sibling is enough: a write method weaker than a read of the
+// same resource, or a version weaker than another version of the same route. Group and
+// receiver clusters only flag a weaker minority.
+func (r *AP011InconsistentSiblings) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	clusters := make(map[string][]*models.Endpoint)
+	var keys []string
+
+	for _, e := range result.Endpoints {
+		for _, key := range siblingKeys(e, sources) {
+			if _, ok := clusters[key]; !ok {
+				keys = append(keys, key)
+			}
+			clusters[key] = append(clusters[key], e)
+		}
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		return clusterPriority(keys[i]) < clusterPriority(keys[j])
+	})
+
+	var findings []*models.Finding
+	reported := make(map[*models.Endpoint]bool)
+
+	for _, key := range keys {
+		cluster := clusters[key]
+
+		for _, e := range cluster {
+			if reported[e] {
+				continue
+			}
+			// Explicitly public and well-known public endpoints are intentional
+			if e.Authorization.AllowsAnonymous || isKnownPublicEndpoint(e.FullRoute()) {
+				continue
+			}
+
+			stronger := strongerSiblings(e, cluster)
+			switch {
+			case len(stronger) == 0:
+				continue
+			case strings.HasPrefix(key, "version:"):
+			case strings.HasPrefix(key, "resource:") && isWeakerWrite(e, stronger):
+			case !isOutlier(len(stronger), len(cluster)):
+				continue
+			}
+
+			reported[e] = true
+			findings = append(findings, r.createSiblingFinding(e, stronger))
+		}
+	}
+
+	return findings
+}
+
+// strongerSiblings returns the endpoints of a cluster with the strongest protection,
+// or nil if the endpoint is already as protected as any of them.
+func strongerSiblings(e *models.Endpoint, cluster []*models.Endpoint) []*models.Endpoint {
+	strongest := postureRank(e)
+	for _, sibling := range cluster {
+		if rank := postureRank(sibling); rank > strongest {
+			strongest = rank
+		}
+	}
+	if strongest == postureRank(e) {
+		return nil
+	}
+
+	var stronger []*models.Endpoint
+	for _, sibling := range cluster {
+		if postureRank(sibling) == strongest {
+			stronger = append(stronger, sibling)
+		}
+	}
+	return stronger
+}
+
+// clusterPriority orders cluster keys: resource clusters first, then versions of a route,
+// then router groups and receiver types.
+func clusterPriority(key string) int {
+	switch {
+	case strings.HasPrefix(key, "resource:"):
+		return 0
+	case strings.HasPrefix(key, "version:"):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// isWeakerWrite returns true if a write endpoint is less protected than a read of its resource,
+// such as a public PUT /users/:id next to an authenticated GET /users/:id.
+func isWeakerWrite(e *models.Endpoint, stronger []*models.Endpoint) bool {
+	if !e.IsWriteEndpoint() {
+		return false
+	}
+	for _, sibling := range stronger {
+		if !sibling.IsWriteEndpoint() {
+			return true
+		}
+	}
+	return false
+}
+
+// isOutlier returns true if an endpoint is a minority in its cluster: at least two
+// siblings are more protected and they make up at least two thirds of the cluster.
+// A weaker majority is a deliberate split, and public majorities are left to AP008.
+func isOutlier(stronger, clusterSize int) bool {
+	return stronger >= 2 && 3*stronger >= 2*clusterSize
+}
+
+// createSiblingFinding builds the finding for an endpoint weaker than its siblings.
+func (r *AP011InconsistentSiblings) createSiblingFinding(e *models.Endpoint, stronger []*models.Endpoint) *models.Finding {
+	sibling := stronger[0]
+
+	protection := string(sibling.Classification)
+	if len(sibling.Authorization.AuthDependencies) > 0 {
+		protection += " via " + strings.Join(sibling.Authorization.AuthDependencies, ", ")
+	}
+
+	finding := createFinding(r, e,
+		fmt.Sprintf("Endpoint '%s' [%s] is %s, but sibling '%s' [%s] is %s",
+			e.FullRoute(), e.DisplayMethods(), e.Classification,
+			sibling.FullRoute(), sibling.DisplayMethods(), protection),
+		"Apply the same authentication and authorization middleware as the sibling endpoints, or mark this endpoint as intentionally public",
+	)
+	if e.IsWriteEndpoint() {
+		finding.Severity = models.SeverityHigh
+	}
+	finding.RelatedEndpoints = stronger
+
+	return finding
+}
+
+// siblingKeys returns the cluster keys an endpoint belongs to: its normalised resource
+// path, its methods and route without version segments, its router group, and the
+// receiver type its handler is a method of.
+func siblingKeys(e *models.Endpoint, sources *astutil.SourceSet) []string {
+	keys := []string{"resource:" + string(e.Framework) + ":" + resourcePath(e)}
+
+	if unversioned := unversionedPath(e); unversioned != routing.NormalizeEndpoint(e) {
+		keys = append(keys, "version:"+string(e.Framework)+":"+e.DisplayMethods()+" "+unversioned)
+	}
+
+	if e.RouterPrefix != "" {
+		keys = append(keys, "group:"+e.FilePath+":"+e.RouterPrefix)
+	}
+
+	if receiver := handlerReceiver(e, sources); receiver != "" {
+		keys = append(keys, "receiver:"+receiver)
+	}
+
+	return keys
+}
+
+// resourcePath normalises a route to the resource it addresses: version segments
+// are dropped and trailing parameters are trimmed ("/v1/users/:id" -> "/users").
+func resourcePath(e *models.Endpoint) string {
+	kept := withoutVersions(e)
+	for len(kept) > 0 && routing.IsParam(kept[len(kept)-1]) {
+		kept = kept[:len(kept)-1]
+	}
+
+	return "/" + strings.Join(kept, "/")
+}
+
+// unversionedPath returns the normalised route without version segments ("/v1/users/:id" -> "/users/:id").
+func unversionedPath(e *models.Endpoint) string {
+	return "/" + strings.Join(withoutVersions(e), "/")
+}
+
+// withoutVersions returns the segments of the normalised route, without version segments.
+func withoutVersions(e *models.Endpoint) []string {
+	var kept []string
+	for _, seg := range routing.Segments(routing.NormalizeEndpoint(e)) {
+		if versionSegment.MatchString(strings.ToLower(seg)) {
+			continue
+		}
+		kept = append(kept, seg)
+	}
+	return kept
+}
+
+// handlerReceiver returns the type a handler method value is taken from ("app/handlers.UserHandler"
+// for "h.GetUser" where h is a *UserHandler), or an empty string for plain functions,
+// package-qualified functions and receivers whose type cannot be resolved.
+func handlerReceiver(e *models.Endpoint, sources *astutil.SourceSet) string {
+	sel, ok := handlerExpr(e, sources).(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+
+	receiver := sources.Types(e.FilePath).TypeOf(sel.X)
+
+	if ptr, ok := receiver.(*types.Pointer); ok {
+		receiver = ptr.Elem()
+	}
+	named, ok := receiver.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return ""
+	}
+	return named.Obj().Pkg().Path() + "." + named.Obj().Name()
+}
+
+// postureRank orders classifications by strength of protection.
+func postureRank(e *models.Endpoint) int {
+	switch e.Classification {
+	case models.ClassificationAuthenticated:
+		return 1
+	case models.ClassificationRoleRestricted, models.ClassificationPolicyRestricted:
+		return 2
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,139 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// AP012MixedHandlerExposure flags handlers registered both publicly and behind authentication.
+type AP012MixedHandlerExposure struct{}
+
+// NewAP012MixedHandlerExposure creates a new AP012 rule.
+func NewAP012MixedHandlerExposure() *AP012MixedHandlerExposure {
+	return &AP012MixedHandlerExposure{}
+}
+
+// ID returns the rule ID.
+func (r *AP012MixedHandlerExposure) ID() string {
+	return "AP012"
+}
+
+// Name returns the rule name.
+func (r *AP012MixedHandlerExposure) Name() string {
+	return "Handler exposed on public and protected routes"
+}
+
+// Severity returns the rule severity.
+func (r *AP012MixedHandlerExposure) Severity() models.Severity {
+	return models.SeverityHigh
+}
+
+// Description returns the rule description.
+func (r *AP012MixedHandlerExposure) Description() string {
+	return "The same handler is registered behind authentication on one route and publicly on another. " +
+		"This usually means an old unauthenticated alias route was left behind during a migration."
+}
+
+// EvaluateProject groups endpoints by resolved handler and flags the public registrations
+// of handlers that are also registered behind authentication.
+func (r *AP012MixedHandlerExposure) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	groups := make(map[string][]*models.Endpoint)
+	var keys []string
+
+	for _, e := range result.Endpoints {
+		key := handlerKey(e, sources)
+		if key == "" {
+			continue
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], e)
+	}
+
+	var findings []*models.Finding
+	for _, key := range keys {
+		var public, protected []*models.Endpoint
+		for _, e := range groups[key] {
+			if e.Classification == models.ClassificationPublic {
+				public = append(public, e)
+			} else {
+				protected = append(protected, e)
+			}
+		}
+
+		if len(public) == 0 || len(protected) == 0 {
+			continue
+		}
+
+		for _, e := range public {
+			finding := createFinding(r, e,
+				fmt.Sprintf("Handler '%s' is public on '%s' [%s] but requires authentication on '%s' [%s]",
+					e.FunctionName, e.FullRoute(), e.DisplayMethods(),
+					protected[0].FullRoute(), protected[0].DisplayMethods()),
+				"Remove the public alias route, or apply the same middleware as the protected registration",
+			)
+			finding.RelatedEndpoints = protected
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
+
+// handlerKey identifies the handler function of an endpoint across files. A handler named at its
+// registration call is keyed by the function the types resolve, so h.List of a UserHandler and h.List
+// of an OrderHandler differ. Otherwise package-qualified handlers are keyed by package and plain
+// functions by the directory they are used in. Returns an empty string for handlers that cannot be
+// identified (e.g., inline function literals, or methods of a receiver of unknown type).
+func handlerKey(e *models.Endpoint, sources *astutil.SourceSet) string {
+	name := e.FunctionName
+	if name == "" || name == "<anonymous>" {
+		return ""
+	}
+
+	if fn := funcObject(sources, e.FilePath, handlerExpr(e, sources)); fn != nil {
+		return fn.FullName()
+	}
+
+	dir := filepath.Dir(e.FilePath)
+
+	if idx := strings.Index(name, "."); idx > 0 {
+		qualifier, fn := name[:idx], name[idx+1:]
+		if source := sources.Get(e.FilePath); source != nil {
+			for importPath, alias := range source.Imports {
+				if alias == qualifier {
+					return packageKey(importPath, sources) + "." + fn
+				}
+			}
+		}
+		return ""
+	}
+
+	return packageKeyForDir(dir, sources) + "." + name
+}
+
+// packageKeyForDir returns the package key of a scanned directory, relative to the scan root.
+func packageKeyForDir(dir string, sources *astutil.SourceSet) string {
+	rel, err := filepath.Rel(sources.Root, dir)
+	if err != nil {
+		return filepath.ToSlash(dir)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// packageKey maps an import path to the key of the scanned directory it refers to,
+// or returns the import path itself for packages outside the scanned project.
+func packageKey(importPath string, sources *astutil.SourceSet) string {
+	for _, source := range sources.Files {
+		key := packageKeyForDir(filepath.Dir(source.FilePath), sources)
+		if key != "." && strings.HasSuffix(importPath, "/"+key) {
+			return key
+		}
+	}
+	return importPath
+}
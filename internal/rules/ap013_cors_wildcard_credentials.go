@@ -0,0 +1,73 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// AP013CORSWildcardCredentials flags CORS policies that allow any origin together with credentials.
+type AP013CORSWildcardCredentials struct{}
+
+// NewAP013CORSWildcardCredentials creates a new AP013 rule.
+func NewAP013CORSWildcardCredentials() *AP013CORSWildcardCredentials {
+	return &AP013CORSWildcardCredentials{}
+}
+
+// ID returns the rule ID.
+func (r *AP013CORSWildcardCredentials) ID() string {
+	return "AP013"
+}
+
+// Name returns the rule name.
+func (r *AP013CORSWildcardCredentials) Name() string {
+	return "CORS wildcard origin with credentials"
+}
+
+// Severity returns the rule severity.
+func (r *AP013CORSWildcardCredentials) Severity() models.Severity {
+	return models.SeverityHigh
+}
+
+// Description returns the rule description.
+func (r *AP013CORSWildcardCredentials) Description() string {
+	return "CORS middleware allows any origin and sets AllowCredentials. " +
+		"Several middlewares then reflect the caller's origin, letting any website make authenticated requests."
+}
+
+// EvaluateProject checks the CORS configurations and reports the endpoints they cover.
+func (r *AP013CORSWildcardCredentials) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+	reported := make(map[*models.Endpoint]bool)
+
+	for _, policy := range findCORSPolicies(sources) {
+		if !policy.AllowsAnyOrigin() || !policy.AllowCredentials {
+			continue
+		}
+
+		recommendation := "List the trusted origins explicitly, or disable AllowCredentials for public cross-origin access"
+		covered := policy.coveredEndpoints(result.Endpoints)
+		if len(covered) == 0 {
+			findings = append(findings, createProjectFinding(r, policy.FilePath, policy.LineNumber,
+				fmt.Sprintf("CORS policy '%s' allows any origin with credentials", policy.Middleware),
+				recommendation,
+			))
+			continue
+		}
+
+		for _, e := range covered {
+			if reported[e] {
+				continue
+			}
+			reported[e] = true
+			findings = append(findings, createFinding(r, e,
+				fmt.Sprintf("Endpoint '%s' [%s] is covered by CORS policy at %s that allows any origin with credentials",
+					e.FullRoute(), e.DisplayMethods(), policy.Location()),
+				recommendation,
+			))
+		}
+	}
+
+	return findings
+}
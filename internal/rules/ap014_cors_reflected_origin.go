@@ -0,0 +1,82 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// AP014CORSReflectedOrigin flags CORS origin callbacks that accept every origin.
+type AP014CORSReflectedOrigin struct{}
+
+// NewAP014CORSReflectedOrigin creates a new AP014 rule.
+func NewAP014CORSReflectedOrigin() *AP014CORSReflectedOrigin {
+	return &AP014CORSReflectedOrigin{}
+}
+
+// ID returns the rule ID.
+func (r *AP014CORSReflectedOrigin) ID() string {
+	return "AP014"
+}
+
+// Name returns the rule name.
+func (r *AP014CORSReflectedOrigin) Name() string {
+	return "CORS origin reflected unconditionally"
+}
+
+// Severity returns the rule severity.
+func (r *AP014CORSReflectedOrigin) Severity() models.Severity {
+	return models.SeverityHigh
+}
+
+// Description returns the rule description.
+func (r *AP014CORSReflectedOrigin) Description() string {
+	return "The CORS AllowOriginFunc returns true for every origin, so the caller's origin is reflected back. " +
+		"Unlike a plain wildcard this also works with credentials."
+}
+
+// EvaluateProject checks the CORS configurations and reports the endpoints they cover.
+func (r *AP014CORSReflectedOrigin) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+	reported := make(map[*models.Endpoint]bool)
+
+	for _, policy := range findCORSPolicies(sources) {
+		if !policy.ReflectsAnyOrigin {
+			continue
+		}
+
+		severity := r.Severity()
+		if policy.AllowCredentials {
+			severity = models.SeverityCritical
+		}
+
+		recommendation := "Validate the origin against an allowlist in AllowOriginFunc instead of returning true"
+		covered := policy.coveredEndpoints(result.Endpoints)
+		if len(covered) == 0 {
+			finding := createProjectFinding(r, policy.FilePath, policy.LineNumber,
+				fmt.Sprintf("CORS policy '%s' accepts every origin in its origin callback", policy.Middleware),
+				recommendation,
+			)
+			finding.Severity = severity
+			findings = append(findings, finding)
+			continue
+		}
+
+		for _, e := range covered {
+			if reported[e] {
+				continue
+			}
+			reported[e] = true
+			finding := createFinding(r, e,
+				fmt.Sprintf("Endpoint '%s' [%s] is covered by CORS policy at %s whose origin callback accepts every origin",
+					e.FullRoute(), e.DisplayMethods(), policy.Location()),
+				recommendation,
+			)
+			finding.Severity = severity
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
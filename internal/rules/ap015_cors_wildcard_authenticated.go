@@ -0,0 +1,64 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// AP015CORSWildcardAuthenticated flags wildcard CORS policies on authenticated endpoints.
+type AP015CORSWildcardAuthenticated struct{}
+
+// NewAP015CORSWildcardAuthenticated creates a new AP015 rule.
+func NewAP015CORSWildcardAuthenticated() *AP015CORSWildcardAuthenticated {
+	return &AP015CORSWildcardAuthenticated{}
+}
+
+// ID returns the rule ID.
+func (r *AP015CORSWildcardAuthenticated) ID() string {
+	return "AP015"
+}
+
+// Name returns the rule name.
+func (r *AP015CORSWildcardAuthenticated) Name() string {
+	return "Wildcard CORS on authenticated endpoint"
+}
+
+// Severity returns the rule severity.
+func (r *AP015CORSWildcardAuthenticated) Severity() models.Severity {
+	return models.SeverityMedium
+}
+
+// Description returns the rule description.
+func (r *AP015CORSWildcardAuthenticated) Description() string {
+	return "An authenticated endpoint is covered by a CORS policy that allows any origin. " +
+		"Any website can call it with a token obtained by the browser, and a later credentials change makes it exploitable."
+}
+
+// EvaluateProject checks the CORS configurations and reports the authenticated endpoints they cover.
+// Policies that also allow credentials are reported by AP013 instead.
+func (r *AP015CORSWildcardAuthenticated) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+	reported := make(map[*models.Endpoint]bool)
+
+	for _, policy := range findCORSPolicies(sources) {
+		if !policy.AllowsAnyOrigin() || policy.AllowCredentials {
+			continue
+		}
+
+		for _, e := range policy.coveredEndpoints(result.Endpoints) {
+			if reported[e] || e.Classification == models.ClassificationPublic {
+				continue
+			}
+			reported[e] = true
+			findings = append(findings, createFinding(r, e,
+				fmt.Sprintf("Authenticated endpoint '%s' [%s] is covered by CORS policy at %s that allows any origin",
+					e.FullRoute(), e.DisplayMethods(), policy.Location()),
+				"Restrict the CORS policy of authenticated route groups to the origins of your own frontends",
+			))
+		}
+	}
+
+	return findings
+}
@@ -0,0 +1,271 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"path/filepath"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// sessionImports are packages that keep the authenticated user in a cookie-backed session.
+var sessionImports = []string{
+	"github.com/gorilla/sessions",
+	"github.com/gin-contrib/sessions",
+	"github.com/alexedwards/scs/v2",
+	"github.com/gofiber/fiber/v2/middleware/session",
+	"github.com/labstack/echo-contrib/session",
+}
+
+// csrfImports maps CSRF middleware packages to the constructors that install the protection.
+// Other functions of these packages (csrf.Token, csrf.TemplateField) do not protect anything.
+var csrfImports = map[string][]string{
+	"github.com/gorilla/csrf":                     {"Protect"},
+	"github.com/gofiber/fiber/v2/middleware/csrf": {"New"},
+	"github.com/labstack/echo/v4/middleware":      {"CSRF", "CSRFWithConfig"},
+	"github.com/justinas/nosurf":                  {"New", "NewPure"},
+	"github.com/utrack/gin-csrf":                  {"Middleware"},
+}
+
+// csrfHandlerWrappers are CSRF constructors that take the handler to protect directly
+// (nosurf.New(r)) instead of returning a middleware (csrf.Protect(key)(r)).
+var csrfHandlerWrappers = map[string][]string{
+	"github.com/justinas/nosurf": {"New", "NewPure"},
+}
+
+// AP016MissingCSRF flags cookie-authenticated write endpoints without CSRF protection.
+type AP016MissingCSRF struct{}
+
+// NewAP016MissingCSRF creates a new AP016 rule.
+func NewAP016MissingCSRF() *AP016MissingCSRF {
+	return &AP016MissingCSRF{}
+}
+
+// ID returns the rule ID.
+func (r *AP016MissingCSRF) ID() string {
+	return "AP016"
+}
+
+// Name returns the rule name.
+func (r *AP016MissingCSRF) Name() string {
+	return "Missing CSRF protection on cookie-authenticated write"
+}
+
+// Severity returns the rule severity.
+func (r *AP016MissingCSRF) Severity() models.Severity {
+	return models.SeverityHigh
+}
+
+// Description returns the rule description.
+func (r *AP016MissingCSRF) Description() string {
+	return "Write endpoint authenticates with a session cookie but no CSRF middleware is applied. " +
+		"Browsers attach the cookie to cross-site requests, so any website can trigger the action."
+}
+
+// EvaluateProject checks write endpoints with session or cookie authentication for CSRF middleware.
+func (r *AP016MissingCSRF) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+	wrapped := make(map[string]*csrfWrappedRouters)
+
+	for _, e := range result.Endpoints {
+		if !e.IsWriteEndpoint() {
+			continue
+		}
+
+		via := cookieAuthSource(e, sources)
+		if via == "" {
+			continue
+		}
+
+		// CSRF middleware does not check safe methods, so it cannot protect a state-changing GET
+		if call, ok := e.Metadata[models.MetadataStateChanging]; ok {
+			findings = append(findings, createFinding(r, e,
+				fmt.Sprintf("Endpoint '%s' [%s] authenticates with a session cookie (%s) and changes state (%s) on a read method, which CSRF middleware does not check",
+					e.FullRoute(), e.DisplayMethods(), via, call),
+				"Move the state change to a POST, PUT, PATCH or DELETE route protected by CSRF middleware",
+			))
+			continue
+		}
+		if hasCSRFMiddleware(e, sources) {
+			continue
+		}
+
+		dir := filepath.Dir(e.FilePath)
+		if _, ok := wrapped[dir]; !ok {
+			wrapped[dir] = findCSRFWrappedRouters(e.FilePath, sources)
+		}
+		if wrapped[dir].Protects(e) {
+			continue
+		}
+
+		findings = append(findings, createFinding(r, e,
+			fmt.Sprintf("Endpoint '%s' [%s] authenticates with a session cookie (%s) but has no CSRF protection",
+				e.FullRoute(), e.DisplayMethods(), via),
+			"Add CSRF middleware (gorilla/csrf, nosurf, or the framework's CSRF middleware) to state-changing routes, or use SameSite=Strict cookies",
+		))
+	}
+
+	return findings
+}
+
+// cookieAuthSource returns the middleware that makes an endpoint's authentication cookie-based,
+// or an empty string if the endpoint does not use session or cookie authentication.
+func cookieAuthSource(e *models.Endpoint, sources *astutil.SourceSet) string {
+	source := sources.Get(e.FilePath)
+
+	// Session middleware in the chain (sessions.Sessions, session.Middleware, scs LoadAndSave)
+	for _, mw := range e.Middleware {
+		if strings.HasSuffix(mw, ".LoadAndSave") || (source != nil && callFromImports(source, mw, sessionImports)) {
+			return mw
+		}
+	}
+
+	for _, dep := range e.Authorization.AuthDependencies {
+		lower := strings.ToLower(dep)
+		if strings.Contains(lower, "session") || strings.Contains(lower, "cookie") {
+			return dep
+		}
+
+		// Auth middleware that reads a cookie or session store
+		if depSource, fn := sources.ResolveFunc(e.FilePath, dep); fn != nil && readsSession(depSource, fn) {
+			return dep
+		}
+	}
+
+	return ""
+}
+
+// readsSession returns true if a function reads a cookie or uses a session package.
+func readsSession(source *astutil.ParsedSource, fn *ast.FuncDecl) bool {
+	found := false
+	ast.Inspect(fn, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok || found {
+			return !found
+		}
+		if sel.Sel.Name == "Cookie" || sel.Sel.Name == "Cookies" {
+			found = true
+		}
+		if ident, ok := sel.X.(*ast.Ident); ok && callFromImports(source, ident.Name+"."+sel.Sel.Name, sessionImports) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// hasCSRFMiddleware returns true if the endpoint's middleware chain contains CSRF protection:
+// a CSRF package constructor, or a middleware of the project named after CSRF.
+func hasCSRFMiddleware(e *models.Endpoint, sources *astutil.SourceSet) bool {
+	source := sources.Get(e.FilePath)
+	for _, mw := range e.Middleware {
+		if source != nil && isCSRFPackageCall(source, mw) {
+			if isCSRFCall(source, mw, csrfImports) {
+				return true
+			}
+			continue
+		}
+		lower := strings.ToLower(mw)
+		if strings.Contains(lower, "csrf") || strings.Contains(lower, "xsrf") || strings.Contains(lower, "nosurf") {
+			return true
+		}
+	}
+	return false
+}
+
+// isCSRFPackageCall returns true if a call name refers to any function of a CSRF package.
+func isCSRFPackageCall(source *astutil.ParsedSource, name string) bool {
+	for importPath := range csrfImports {
+		if alias := source.GetImportAlias(importPath); alias != "" && strings.HasPrefix(name, alias+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// isCSRFCall returns true if a call name refers to one of the listed CSRF constructors.
+func isCSRFCall(source *astutil.ParsedSource, name string, constructors map[string][]string) bool {
+	for importPath, funcs := range constructors {
+		alias := source.GetImportAlias(importPath)
+		if alias != "" && strings.HasPrefix(name, alias+".") && containsString(funcs, strings.TrimPrefix(name, alias+".")) {
+			return true
+		}
+	}
+	return false
+}
+
+// csrfWrappedRouters are the routers of a package wrapped by CSRF middleware.
+type csrfWrappedRouters struct {
+	tree    *routerTree
+	routers []types.Object
+}
+
+// Protects returns true if an endpoint is registered on a wrapped router or one of its sub-routers.
+func (w *csrfWrappedRouters) Protects(e *models.Endpoint) bool {
+	if len(w.routers) == 0 {
+		return false
+	}
+	router := w.tree.EndpointRouter(e)
+	for _, wrapped := range w.routers {
+		if w.tree.Descends(router, wrapped) {
+			return true
+		}
+	}
+	return false
+}
+
+// findCSRFWrappedRouters finds the routers of a package passed to CSRF middleware: csrf.Protect(key)(r),
+// nosurf.New(r), or a middleware held in a variable first (protect := csrf.Protect(key); protect(r)).
+func findCSRFWrappedRouters(filePath string, sources *astutil.SourceSet) *csrfWrappedRouters {
+	tree := newRouterTree(sources, filePath)
+	wrapped := &csrfWrappedRouters{tree: tree}
+
+	for _, source := range sources.Package(filePath) {
+		middlewareVars := make(map[types.Object]bool)
+		ast.Inspect(source.AST, func(n ast.Node) bool {
+			if assign, ok := n.(*ast.AssignStmt); ok {
+				for i, lhs := range assign.Lhs {
+					call, isCall := rhsAt(assign, i).(*ast.CallExpr)
+					if ident, isIdent := lhs.(*ast.Ident); isCall && isIdent && isCSRFCall(source, astutil.GetCallName(call), csrfImports) {
+						middlewareVars[tree.info.ObjectOf(ident)] = true
+					}
+				}
+			}
+			return true
+		})
+
+		for _, call := range astutil.FindCallExprs(source.AST) {
+			if len(call.Args) == 0 {
+				continue
+			}
+			wraps := isCSRFCall(source, astutil.GetCallName(call), csrfHandlerWrappers)
+			switch fun := call.Fun.(type) {
+			case *ast.CallExpr:
+				wraps = wraps || isCSRFCall(source, astutil.GetCallName(fun), csrfImports)
+			case *ast.Ident:
+				wraps = wraps || middlewareVars[tree.info.ObjectOf(fun)]
+			}
+			if !wraps {
+				continue
+			}
+			if router := tree.RouterOf(call.Args[0]); router != nil {
+				wrapped.routers = append(wrapped.routers, router)
+			}
+		}
+	}
+
+	return wrapped
+}
+
+// callFromImports returns true if a qualified name ("pkg.Func") refers to one of the import paths.
+func callFromImports(source *astutil.ParsedSource, name string, importPaths []string) bool {
+	for _, importPath := range importPaths {
+		if alias := source.GetImportAlias(importPath); alias != "" && strings.HasPrefix(name, alias+".") {
+			return true
+		}
+	}
+	return false
+}
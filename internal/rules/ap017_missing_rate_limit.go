@@ -0,0 +1,182 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"path/filepath"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// rateLimitImports are packages providing rate-limiting middleware.
+var rateLimitImports = []string{
+	"github.com/ulule/limiter",
+	"github.com/gofiber/fiber/v2/middleware/limiter",
+	"github.com/go-chi/httprate",
+	"github.com/didip/tollbooth",
+}
+
+// rateLimitNameMarkers are substrings of conventional rate-limiting middleware names.
+var rateLimitNameMarkers = []string{"ratelimit", "rate_limit", "limiter", "throttle"}
+
+// shortCodeSegments are entry points guarded by short one-time codes, where
+// missing brute-force protection is most severe.
+var shortCodeSegments = map[string]bool{"otp": true, "2fa": true, "totp": true, "mfa": true}
+
+// AP017MissingRateLimit flags authentication entry points without rate limiting.
+type AP017MissingRateLimit struct {
+	customMiddleware []string
+}
+
+// NewAP017MissingRateLimit creates a new AP017 rule.
+// customMiddleware contains additional names of rate-limiting middleware.
+func NewAP017MissingRateLimit(customMiddleware []string) *AP017MissingRateLimit {
+	return &AP017MissingRateLimit{customMiddleware: customMiddleware}
+}
+
+// ID returns the rule ID.
+func (r *AP017MissingRateLimit) ID() string {
+	return "AP017"
+}
+
+// Name returns the rule name.
+func (r *AP017MissingRateLimit) Name() string {
+	return "Missing rate limiting on authentication endpoint"
+}
+
+// Severity returns the rule severity.
+func (r *AP017MissingRateLimit) Severity() models.Severity {
+	return models.SeverityMedium
+}
+
+// Description returns the rule description.
+func (r *AP017MissingRateLimit) Description() string {
+	return "Login, token, password reset and one-time-code endpoints are public by design, " +
+		"which makes them the target of credential stuffing and brute force. No rate-limit middleware was found."
+}
+
+// EvaluateProject checks authentication entry points for rate-limit middleware. Every method
+// is checked, since codes and magic links are often verified with a GET.
+func (r *AP017MissingRateLimit) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+	wrapped := make(map[string]bool)
+
+	for _, e := range result.Endpoints {
+		segment := authEntryPointSegment(e.FullRoute())
+		if segment == "" || r.hasRateLimit(e, sources) {
+			continue
+		}
+
+		// Routers without middleware chains (net/http) are usually wrapped as a whole
+		if len(e.Middleware) == 0 {
+			dir := filepath.Dir(e.FilePath)
+			if _, ok := wrapped[dir]; !ok {
+				wrapped[dir] = packageUsesImports(e.FilePath, sources, rateLimitImports)
+			}
+			if wrapped[dir] {
+				continue
+			}
+		}
+
+		finding := createFinding(r, e,
+			fmt.Sprintf("Authentication endpoint '%s' [%s] has no rate-limit middleware", e.FullRoute(), e.DisplayMethods()),
+			"Apply per-IP and per-account rate limiting (e.g., httprate, ulule/limiter, or the framework's limiter middleware)",
+		)
+		if shortCodeSegments[segment] {
+			finding.Severity = models.SeverityHigh
+		}
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
+
+// hasRateLimit returns true if the endpoint's middleware chain contains rate limiting.
+func (r *AP017MissingRateLimit) hasRateLimit(e *models.Endpoint, sources *astutil.SourceSet) bool {
+	source := sources.Get(e.FilePath)
+
+	for _, mw := range e.Middleware {
+		lower := strings.ToLower(mw)
+		for _, marker := range rateLimitNameMarkers {
+			if strings.Contains(lower, marker) {
+				return true
+			}
+		}
+		for _, name := range r.customMiddleware {
+			if name != "" && strings.Contains(lower, strings.ToLower(name)) {
+				return true
+			}
+		}
+
+		if source != nil && (callFromImportPrefixes(source, mw, rateLimitImports) || isEchoRateLimiter(source, mw)) {
+			return true
+		}
+
+		// Custom middleware wrapping golang.org/x/time/rate
+		if mwSource, fn := sources.ResolveFunc(e.FilePath, mw); fn != nil && usesTimeRate(mwSource, fn) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isEchoRateLimiter returns true if a middleware name is Echo's RateLimiter middleware.
+func isEchoRateLimiter(source *astutil.ParsedSource, name string) bool {
+	alias := source.GetImportAlias("github.com/labstack/echo/v4/middleware")
+	return alias != "" && strings.HasPrefix(name, alias+".RateLimiter")
+}
+
+// usesTimeRate returns true if a function uses golang.org/x/time/rate, either directly
+// or by calling Allow/Wait/Reserve on a limiter declared elsewhere in the file.
+func usesTimeRate(source *astutil.ParsedSource, fn *ast.FuncDecl) bool {
+	alias := source.GetImportAlias("golang.org/x/time/rate")
+	if alias == "" {
+		return false
+	}
+
+	found := false
+	ast.Inspect(fn, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == alias {
+				found = true
+			}
+			switch sel.Sel.Name {
+			case "Allow", "Wait", "Reserve":
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// callFromImportPrefixes returns true if a qualified name refers to a package whose import path
+// starts with one of the prefixes (covering versioned and sub-packages such as ulule/limiter/v3/...).
+func callFromImportPrefixes(source *astutil.ParsedSource, name string, prefixes []string) bool {
+	for importPath, alias := range source.Imports {
+		if !strings.HasPrefix(name, alias+".") {
+			continue
+		}
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(importPath, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// packageUsesImports returns true if any file of the package calls a function from one of the import prefixes.
+func packageUsesImports(filePath string, sources *astutil.SourceSet, prefixes []string) bool {
+	for _, source := range sources.Package(filePath) {
+		for _, call := range astutil.FindCallExprs(source.AST) {
+			if callFromImportPrefixes(source, astutil.GetCallName(call), prefixes) {
+				return true
+			}
+		}
+	}
+	return false
+}
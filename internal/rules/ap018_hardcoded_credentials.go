@@ -0,0 +1,341 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// maxSnippetLength is the maximum length of a redacted source snippet, in characters.
+const maxSnippetLength = 120
+
+// redactedSecret replaces secret literals in snippets.
+const redactedSecret = `"****"`
+
+// credentialConstructor describes an auth middleware constructor that accepts credentials.
+type credentialConstructor struct {
+	// kind describes the credential ("password", "signing key").
+	kind string
+
+	// args are the indexes of the arguments holding credentials; nil means all arguments.
+	args []int
+}
+
+// credentialConstructors maps import paths to their credential-accepting constructors.
+var credentialConstructors = map[string]map[string]credentialConstructor{
+	"github.com/gin-gonic/gin": {
+		"BasicAuth":         {kind: "password"},
+		"BasicAuthForRealm": {kind: "password", args: []int{0}},
+	},
+	"github.com/labstack/echo-jwt/v4": {
+		"JWT":        {kind: "signing key"},
+		"WithConfig": {kind: "signing key"},
+	},
+	"github.com/labstack/echo/v4/middleware": {
+		"JWT":                 {kind: "signing key"},
+		"JWTWithConfig":       {kind: "signing key"},
+		"BasicAuth":           {kind: "password"},
+		"BasicAuthWithConfig": {kind: "password"},
+	},
+	"github.com/go-chi/jwtauth/v5": {
+		"New": {kind: "signing key", args: []int{1}},
+	},
+	"github.com/gofiber/fiber/v2/middleware/basicauth": {
+		"New": {kind: "password"},
+	},
+	"github.com/gofiber/contrib/jwt": {
+		"New": {kind: "signing key"},
+	},
+}
+
+// secretFields are configuration struct fields that hold credentials.
+var secretFields = map[string]bool{
+	"SigningKey":  true,
+	"SigningKeys": true,
+	"Key":         true,
+	"Secret":      true,
+	"SecretKey":   true,
+	"Password":    true,
+	"Users":       true,
+	"Accounts":    true,
+}
+
+// AP018HardcodedCredentials flags secrets embedded in auth middleware configuration.
+type AP018HardcodedCredentials struct{}
+
+// NewAP018HardcodedCredentials creates a new AP018 rule.
+func NewAP018HardcodedCredentials() *AP018HardcodedCredentials {
+	return &AP018HardcodedCredentials{}
+}
+
+// ID returns the rule ID.
+func (r *AP018HardcodedCredentials) ID() string {
+	return "AP018"
+}
+
+// Name returns the rule name.
+func (r *AP018HardcodedCredentials) Name() string {
+	return "Hard-coded credentials"
+}
+
+// Severity returns the rule severity.
+func (r *AP018HardcodedCredentials) Severity() models.Severity {
+	return models.SeverityHigh
+}
+
+// Description returns the rule description.
+func (r *AP018HardcodedCredentials) Description() string {
+	return "Auth middleware is configured with a literal password or signing key. " +
+		"Anyone with access to the source or the binary can authenticate or forge tokens."
+}
+
+// EvaluateProject checks literal arguments of known auth middleware constructors.
+func (r *AP018HardcodedCredentials) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+
+	for _, source := range sources.Files {
+		constructors := make(map[string]credentialConstructor)
+		for importPath, funcs := range credentialConstructors {
+			if alias := source.GetImportAlias(importPath); alias != "" {
+				for name, c := range funcs {
+					constructors[alias+"."+name] = c
+				}
+			}
+		}
+		if len(constructors) == 0 {
+			continue
+		}
+
+		literals := compositeVars(source.AST)
+
+		for _, call := range astutil.FindCallExprs(source.AST) {
+			name := astutil.GetCallName(call)
+			constructor, ok := constructors[name]
+			if !ok {
+				continue
+			}
+
+			var secrets []*ast.BasicLit
+			for i, arg := range call.Args {
+				if constructor.args != nil && !containsInt(constructor.args, i) {
+					continue
+				}
+				if fn, ok := arg.(*ast.FuncLit); ok {
+					secrets = append(secrets, comparedPasswords(fn)...)
+					continue
+				}
+				secrets = append(secrets, secretLiterals(arg, literals)...)
+			}
+			if len(secrets) == 0 {
+				continue
+			}
+
+			finding := createProjectFinding(r, source.FilePath, astutil.GetLineNumber(source.FileSet, secrets[0]),
+				fmt.Sprintf("Hard-coded %s passed to '%s'", constructor.kind, name),
+				"Load credentials from the environment or a secret manager and rotate the exposed value",
+			)
+			finding.Snippet = redactedSnippet(source, call, secrets)
+			finding.RelatedEndpoints = endpointsUsingMiddleware(result.Endpoints, source.FilePath, name)
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
+
+// compositeVars maps variable names to the composite literals assigned to them in a file,
+// so that configurations declared before being passed to a constructor are inspected too.
+func compositeVars(file *ast.File) map[string]*ast.CompositeLit {
+	vars := make(map[string]*ast.CompositeLit)
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok || i >= len(node.Rhs) {
+					continue
+				}
+				if lit, ok := unwrapAddr(node.Rhs[i]).(*ast.CompositeLit); ok {
+					vars[ident.Name] = lit
+				}
+			}
+		case *ast.ValueSpec:
+			for i, name := range node.Names {
+				if i < len(node.Values) {
+					if lit, ok := unwrapAddr(node.Values[i]).(*ast.CompositeLit); ok {
+						vars[name.Name] = lit
+					}
+				}
+			}
+		}
+		return true
+	})
+	return vars
+}
+
+// secretLiterals returns the string literals holding credentials in an expression: the
+// expression itself, values of map literals (accounts), and secret fields of config structs.
+func secretLiterals(expr ast.Expr, vars map[string]*ast.CompositeLit) []*ast.BasicLit {
+	expr = unwrapAddr(expr)
+
+	if lit := stringLiteral(expr); lit != nil {
+		return []*ast.BasicLit{lit}
+	}
+
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if lit, ok := vars[e.Name]; ok {
+			return secretLiterals(lit, nil)
+		}
+	case *ast.CompositeLit:
+		var secrets []*ast.BasicLit
+		for _, elt := range e.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			// Struct fields only count if they hold credentials; map values always do
+			if key, ok := kv.Key.(*ast.Ident); ok && !secretFields[key.Name] {
+				continue
+			}
+			secrets = append(secrets, secretLiterals(kv.Value, vars)...)
+		}
+		return secrets
+	}
+
+	return nil
+}
+
+// stringLiteral returns the literal of a non-empty string or []byte("...") expression.
+func stringLiteral(expr ast.Expr) *ast.BasicLit {
+	if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 1 {
+		if arr, ok := call.Fun.(*ast.ArrayType); ok {
+			if elt, ok := arr.Elt.(*ast.Ident); ok && elt.Name == "byte" {
+				expr = call.Args[0]
+			}
+		}
+	}
+
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING || astutil.GetStringValue(lit) == "" {
+		return nil
+	}
+	return lit
+}
+
+// comparedPasswords returns the string literals a basic-auth validator compares
+// its password parameter against (password == "secret", ConstantTimeCompare(...)).
+func comparedPasswords(fn *ast.FuncLit) []*ast.BasicLit {
+	var params []string
+	for _, field := range fn.Type.Params.List {
+		for _, name := range field.Names {
+			params = append(params, name.Name)
+		}
+	}
+	if len(params) < 2 {
+		return nil
+	}
+	password := params[1]
+
+	var secrets []*ast.BasicLit
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.BinaryExpr:
+			if node.Op != token.EQL {
+				return true
+			}
+			if mentionsIdent(node.X, password) {
+				if lit := stringLiteral(node.Y); lit != nil {
+					secrets = append(secrets, lit)
+				}
+			} else if mentionsIdent(node.Y, password) {
+				if lit := stringLiteral(node.X); lit != nil {
+					secrets = append(secrets, lit)
+				}
+			}
+		case *ast.CallExpr:
+			if !strings.HasSuffix(astutil.GetCallName(node), "ConstantTimeCompare") || len(node.Args) != 2 {
+				return true
+			}
+			for i, arg := range node.Args {
+				if mentionsIdent(arg, password) {
+					if lit := stringLiteral(node.Args[1-i]); lit != nil {
+						secrets = append(secrets, lit)
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	return secrets
+}
+
+// mentionsIdent returns true if an expression references the named identifier.
+func mentionsIdent(expr ast.Expr, name string) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == name {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// redactedSnippet returns the source of a node on a single line, with the given literals
+// replaced by a placeholder and the result truncated to maxSnippetLength.
+func redactedSnippet(source *astutil.ParsedSource, node ast.Node, secrets []*ast.BasicLit) string {
+	text := source.NodeText(node)
+	if text == "" {
+		return ""
+	}
+	base := source.FileSet.Position(node.Pos()).Offset
+
+	// Replace from the end so earlier offsets stay valid
+	sorted := append([]*ast.BasicLit(nil), secrets...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Pos() > sorted[j].Pos() })
+	for _, lit := range sorted {
+		start := source.FileSet.Position(lit.Pos()).Offset - base
+		end := source.FileSet.Position(lit.End()).Offset - base
+		if start < 0 || end > len(text) || start > end {
+			continue
+		}
+		text = text[:start] + redactedSecret + text[end:]
+	}
+
+	text = strings.Join(strings.Fields(text), " ")
+	if runes := []rune(text); len(runes) > maxSnippetLength {
+		text = string(runes[:maxSnippetLength-3]) + "..."
+	}
+	return text
+}
+
+// endpointsUsingMiddleware returns the endpoints of a file whose middleware chain contains a middleware.
+func endpointsUsingMiddleware(endpoints []*models.Endpoint, filePath, middleware string) []*models.Endpoint {
+	var using []*models.Endpoint
+	for _, e := range endpoints {
+		if e.FilePath != filePath {
+			continue
+		}
+		if containsString(e.Middleware, middleware) {
+			using = append(using, e)
+		}
+	}
+	return using
+}
+
+// containsInt returns true if a slice contains an int.
+func containsInt(list []int, n int) bool {
+	for _, item := range list {
+		if item == n {
+			return true
+		}
+	}
+	return false
+}
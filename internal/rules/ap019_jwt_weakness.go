@@ -0,0 +1,450 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// minSigningKeyLength is the minimum length in bytes of a symmetric JWT signing key.
+const minSigningKeyLength = 32
+
+// maxCallDepth limits how deep auth middleware calls are followed to find the JWT validation.
+const maxCallDepth = 3
+
+// jwtImportPrefixes are the JWT parsing libraries whose usage is analysed.
+var jwtImportPrefixes = []string{
+	"github.com/golang-jwt/jwt",
+	"github.com/dgrijalva/jwt-go",
+	"github.com/form3tech-oss/jwt-go",
+}
+
+// jwtWeakness is a JWT validation weakness found in a function or middleware constructor call.
+type jwtWeakness struct {
+	source *astutil.ParsedSource
+	node   ast.Node
+	name   string
+	issues []string
+
+	// fn is the function containing the validation; nil for middleware constructor calls.
+	fn *ast.FuncDecl
+
+	critical bool
+}
+
+// add records an issue once.
+func (w *jwtWeakness) add(issue string, critical bool) {
+	if !containsString(w.issues, issue) {
+		w.issues = append(w.issues, issue)
+	}
+	w.critical = w.critical || critical
+}
+
+// AP019JWTWeakness flags JWT validation that can be bypassed or brute-forced.
+type AP019JWTWeakness struct{}
+
+// NewAP019JWTWeakness creates a new AP019 rule.
+func NewAP019JWTWeakness() *AP019JWTWeakness {
+	return &AP019JWTWeakness{}
+}
+
+// ID returns the rule ID.
+func (r *AP019JWTWeakness) ID() string {
+	return "AP019"
+}
+
+// Name returns the rule name.
+func (r *AP019JWTWeakness) Name() string {
+	return "Weak JWT verification"
+}
+
+// Severity returns the rule severity.
+func (r *AP019JWTWeakness) Severity() models.Severity {
+	return models.SeverityHigh
+}
+
+// Description returns the rule description.
+func (r *AP019JWTWeakness) Description() string {
+	return "JWT middleware skips signature or claims validation, accepts the none algorithm, does not pin the " +
+		"signing method, or uses a short symmetric key. Endpoints behind it are classified as authenticated but can be reached with forged tokens."
+}
+
+// EvaluateProject analyses JWT validation code and reports it at the middleware definition,
+// listing every endpoint whose auth middleware reaches it.
+func (r *AP019JWTWeakness) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+	authFuncs := funcsReachedByAuth(result.Endpoints, sources)
+
+	for _, source := range sources.Files {
+		for _, weakness := range findJWTWeaknesses(source, sources, authFuncs) {
+			finding := createProjectFinding(r, source.FilePath, astutil.GetLineNumber(source.FileSet, weakness.node),
+				fmt.Sprintf("JWT validation in '%s' %s", weakness.name, strings.Join(weakness.issues, "; ")),
+				"Use jwt.Parse with jwt.WithValidMethods, keep claims validation enabled, and load a random key of at least 32 bytes",
+			)
+			if weakness.critical {
+				finding.Severity = models.SeverityCritical
+			}
+
+			if weakness.fn != nil {
+				finding.RelatedEndpoints = endpointsReachingFunc(result.Endpoints, sources, weakness.fn)
+			} else {
+				finding.RelatedEndpoints = endpointsUsingMiddleware(result.Endpoints, source.FilePath, weakness.name)
+			}
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
+
+// findJWTWeaknesses analyses the auth middleware functions of a file that use a JWT library,
+// with the key funcs they pass to the parser, and the signing keys passed to JWT middleware constructors.
+func findJWTWeaknesses(source *astutil.ParsedSource, sources *astutil.SourceSet, authFuncs map[*ast.FuncDecl]bool) []*jwtWeakness {
+	var weaknesses []*jwtWeakness
+	keys := stringVars(source.AST)
+
+	alias := ""
+	for importPath, a := range source.Imports {
+		for _, prefix := range jwtImportPrefixes {
+			if strings.HasPrefix(importPath, prefix) {
+				alias = a
+			}
+		}
+	}
+
+	if alias != "" {
+		for _, fn := range astutil.FindFuncDecls(source.AST) {
+			if !authFuncs[fn] {
+				continue
+			}
+			weakness := &jwtWeakness{source: source, node: fn, name: fn.Name.Name, fn: fn}
+			analyzeJWTFunc(weakness, alias, keys, sources)
+			if len(weakness.issues) > 0 {
+				weaknesses = append(weaknesses, weakness)
+			}
+		}
+	}
+
+	// Short keys passed to JWT middleware constructors (echojwt, jwtauth, ...)
+	for importPath, funcs := range credentialConstructors {
+		ctorAlias := source.GetImportAlias(importPath)
+		if ctorAlias == "" {
+			continue
+		}
+		for _, call := range astutil.FindCallExprs(source.AST) {
+			name := astutil.GetCallName(call)
+			constructor, ok := funcs[strings.TrimPrefix(name, ctorAlias+".")]
+			if !ok || !strings.HasPrefix(name, ctorAlias+".") || constructor.kind != "signing key" {
+				continue
+			}
+			weakness := &jwtWeakness{source: source, node: call, name: name}
+			for i, arg := range call.Args {
+				if constructor.args != nil && !containsInt(constructor.args, i) {
+					continue
+				}
+				for _, lit := range secretLiterals(arg, compositeVars(source.AST)) {
+					checkKeyLength(weakness, lit)
+				}
+			}
+			if len(weakness.issues) > 0 {
+				weaknesses = append(weaknesses, weakness)
+			}
+		}
+	}
+
+	return weaknesses
+}
+
+// analyzeJWTFunc records the JWT weaknesses of a function.
+func analyzeJWTFunc(w *jwtWeakness, alias string, keys map[string]*ast.BasicLit, sources *astutil.SourceSet) {
+	parsers := make(map[string]bool)
+
+	ast.Inspect(w.fn, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.SelectorExpr:
+			if node.Sel.Name == "ParseUnverified" {
+				w.add("parses tokens without verifying the signature (ParseUnverified)", true)
+			}
+		case *ast.KeyValueExpr:
+			if key, ok := node.Key.(*ast.Ident); ok && key.Name == "SkipClaimsValidation" && isTrueLiteral(node.Value) {
+				w.add("skips claims validation", false)
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				if i >= len(node.Rhs) {
+					break
+				}
+				if sel, ok := lhs.(*ast.SelectorExpr); ok && sel.Sel.Name == "SkipClaimsValidation" && isTrueLiteral(node.Rhs[i]) {
+					w.add("skips claims validation", false)
+				}
+				// Remember parsers created with a pinned signing method
+				if ident, ok := lhs.(*ast.Ident); ok {
+					if call, ok := node.Rhs[i].(*ast.CallExpr); ok && astutil.GetCallName(call) == alias+".NewParser" {
+						parsers[ident.Name] = hasValidMethodsOption(call.Args)
+					}
+				}
+			}
+		case *ast.CallExpr:
+			name := astutil.GetCallName(node)
+			if passesNoneAlgorithm(node) {
+				w.add("accepts the 'none' algorithm", true)
+			}
+			if strings.HasSuffix(name, ".WithoutClaimsValidation") {
+				w.add("skips claims validation (WithoutClaimsValidation)", false)
+			}
+			if strings.HasSuffix(name, ".SignedString") && len(node.Args) == 1 {
+				if lit := keyLiteral(node.Args[0], keys); lit != nil {
+					checkKeyLength(w, lit)
+				}
+			}
+			if keyFunc, pinned, ok := parseCall(node, alias, parsers); ok {
+				analyzeKeyFunc(w, keyFunc, pinned, keys, sources)
+			}
+		}
+		return true
+	})
+}
+
+// parseCall recognises jwt.Parse/ParseWithClaims calls (package functions and parser methods)
+// and returns the key func argument and whether the signing method is pinned by an option.
+func parseCall(call *ast.CallExpr, alias string, parsers map[string]bool) (ast.Expr, bool, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, false, false
+	}
+
+	keyFuncIndex := 1
+	switch sel.Sel.Name {
+	case "Parse":
+	case "ParseWithClaims":
+		keyFuncIndex = 2
+	default:
+		return nil, false, false
+	}
+
+	pinned := false
+	switch x := sel.X.(type) {
+	case *ast.Ident:
+		if x.Name != alias {
+			p, isParser := parsers[x.Name]
+			if !isParser {
+				return nil, false, false
+			}
+			pinned = p
+		}
+	case *ast.CallExpr:
+		if astutil.GetCallName(x) != alias+".NewParser" {
+			return nil, false, false
+		}
+		pinned = hasValidMethodsOption(x.Args)
+	default:
+		return nil, false, false
+	}
+
+	if len(call.Args) <= keyFuncIndex {
+		return nil, false, false
+	}
+	pinned = pinned || hasValidMethodsOption(call.Args[keyFuncIndex+1:])
+
+	return call.Args[keyFuncIndex], pinned, true
+}
+
+// passesNoneAlgorithm returns true if a call is given the 'none' algorithm as a signing method,
+// key or accepted method (jwt.New(jwt.SigningMethodNone), jwt.WithValidMethods([]string{"none"})).
+// Comparisons that reject it (t.Method == jwt.SigningMethodNone) are not calls and are ignored.
+func passesNoneAlgorithm(call *ast.CallExpr) bool {
+	validMethods := strings.HasSuffix(astutil.GetCallName(call), ".WithValidMethods")
+	for _, arg := range call.Args {
+		if isNoneAlgorithm(arg) {
+			return true
+		}
+		if lit, ok := arg.(*ast.CompositeLit); ok && validMethods {
+			for _, elt := range lit.Elts {
+				if strings.EqualFold(astutil.GetStringValue(elt), "none") || isNoneAlgorithm(elt) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// isNoneAlgorithm returns true for jwt.SigningMethodNone, jwt.SigningMethodNone.Alg()
+// and jwt.UnsafeAllowNoneSignatureType.
+func isNoneAlgorithm(expr ast.Expr) bool {
+	if call, ok := expr.(*ast.CallExpr); ok {
+		if fun, ok := call.Fun.(*ast.SelectorExpr); ok && fun.Sel.Name == "Alg" {
+			expr = fun.X
+		}
+	}
+	sel, ok := expr.(*ast.SelectorExpr)
+	return ok && (sel.Sel.Name == "SigningMethodNone" || sel.Sel.Name == "UnsafeAllowNoneSignatureType")
+}
+
+// hasValidMethodsOption returns true if parser options pin the accepted signing methods.
+func hasValidMethodsOption(args []ast.Expr) bool {
+	for _, arg := range args {
+		if call, ok := arg.(*ast.CallExpr); ok && strings.HasSuffix(astutil.GetCallName(call), ".WithValidMethods") {
+			return true
+		}
+	}
+	return false
+}
+
+// analyzeKeyFunc checks that a key func verifies token.Method and returns a long enough key.
+func analyzeKeyFunc(w *jwtWeakness, keyFunc ast.Expr, pinned bool, keys map[string]*ast.BasicLit, sources *astutil.SourceSet) {
+	var body *ast.BlockStmt
+	switch kf := keyFunc.(type) {
+	case *ast.FuncLit:
+		body = kf.Body
+	case *ast.Ident, *ast.SelectorExpr:
+		if _, fn := sources.ResolveFunc(w.source.FilePath, astutil.ExprString(kf)); fn != nil {
+			body = fn.Body
+		}
+	}
+	if body == nil {
+		return
+	}
+
+	checksMethod := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.SelectorExpr:
+			if node.Sel.Name == "Method" || node.Sel.Name == "Alg" {
+				checksMethod = true
+			}
+		case *ast.ReturnStmt:
+			if len(node.Results) > 0 {
+				if lit := keyLiteral(node.Results[0], keys); lit != nil {
+					checkKeyLength(w, lit)
+				}
+				// Returning this key is how golang-jwt is told to accept unsigned tokens
+				if isNoneAlgorithm(node.Results[0]) {
+					w.add("accepts the 'none' algorithm", true)
+				}
+			}
+		}
+		return true
+	})
+
+	if !checksMethod && !pinned {
+		w.add("key func returns the key without checking token.Method", false)
+	}
+}
+
+// checkKeyLength records an issue if a symmetric key literal is shorter than minSigningKeyLength.
+func checkKeyLength(w *jwtWeakness, lit *ast.BasicLit) {
+	if n := len(astutil.GetStringValue(lit)); n < minSigningKeyLength {
+		w.add(fmt.Sprintf("uses a %d-byte signing key (minimum %d)", n, minSigningKeyLength), false)
+	}
+}
+
+// keyLiteral returns the string literal of a key expression, resolving variables declared in the file.
+func keyLiteral(expr ast.Expr, keys map[string]*ast.BasicLit) *ast.BasicLit {
+	if lit := stringLiteral(expr); lit != nil {
+		return lit
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return keys[ident.Name]
+	}
+	if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 1 {
+		if _, isArray := call.Fun.(*ast.ArrayType); isArray {
+			return keyLiteral(call.Args[0], keys)
+		}
+	}
+	return nil
+}
+
+// stringVars maps variable and constant names to the string literals they are declared with.
+func stringVars(file *ast.File) map[string]*ast.BasicLit {
+	vars := make(map[string]*ast.BasicLit)
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.ValueSpec:
+			for i, name := range node.Names {
+				if i < len(node.Values) {
+					if lit := stringLiteral(node.Values[i]); lit != nil {
+						vars[name.Name] = lit
+					}
+				}
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok && i < len(node.Rhs) {
+					if lit := stringLiteral(node.Rhs[i]); lit != nil {
+						vars[ident.Name] = lit
+					}
+				}
+			}
+		}
+		return true
+	})
+	return vars
+}
+
+// funcsReachedByAuth returns the functions that endpoints' auth middleware is, or calls
+// up to maxCallDepth levels deep.
+func funcsReachedByAuth(endpoints []*models.Endpoint, sources *astutil.SourceSet) map[*ast.FuncDecl]bool {
+	reached := make(map[*ast.FuncDecl]bool)
+
+	var visit func(source *astutil.ParsedSource, fn *ast.FuncDecl, depth int)
+	visit = func(source *astutil.ParsedSource, fn *ast.FuncDecl, depth int) {
+		if reached[fn] {
+			return
+		}
+		reached[fn] = true
+		if depth == 0 || fn.Body == nil {
+			return
+		}
+		for _, call := range astutil.FindCallExprs(fn.Body) {
+			if calleeSource, callee := sources.ResolveFunc(source.FilePath, astutil.GetCallName(call)); callee != nil {
+				visit(calleeSource, callee, depth-1)
+			}
+		}
+	}
+
+	for _, e := range endpoints {
+		for _, dep := range e.Authorization.AuthDependencies {
+			if src, fn := sources.ResolveFunc(e.FilePath, dep); fn != nil {
+				visit(src, fn, maxCallDepth)
+			}
+		}
+	}
+	return reached
+}
+
+// endpointsReachingFunc returns the endpoints whose auth middleware is, or calls, the given function.
+func endpointsReachingFunc(endpoints []*models.Endpoint, sources *astutil.SourceSet, target *ast.FuncDecl) []*models.Endpoint {
+	var reaching []*models.Endpoint
+	for _, e := range endpoints {
+		for _, dep := range e.Authorization.AuthDependencies {
+			if src, fn := sources.ResolveFunc(e.FilePath, dep); fn != nil && funcReaches(sources, src, fn, target, maxCallDepth) {
+				reaching = append(reaching, e)
+				break
+			}
+		}
+	}
+	return reaching
+}
+
+// funcReaches returns true if fn is target or calls it, following calls up to depth levels.
+func funcReaches(sources *astutil.SourceSet, source *astutil.ParsedSource, fn, target *ast.FuncDecl, depth int) bool {
+	if fn == target {
+		return true
+	}
+	if depth == 0 || fn.Body == nil {
+		return false
+	}
+
+	for _, call := range astutil.FindCallExprs(fn.Body) {
+		if calleeSource, callee := sources.ResolveFunc(source.FilePath, astutil.GetCallName(call)); callee != nil && callee != fn {
+			if funcReaches(sources, calleeSource, callee, target, depth-1) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,201 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"path/filepath"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// AP020DebugMode flags framework debug mode enabled in non-test code.
+type AP020DebugMode struct{}
+
+// NewAP020DebugMode creates a new AP020 rule.
+func NewAP020DebugMode() *AP020DebugMode {
+	return &AP020DebugMode{}
+}
+
+// ID returns the rule ID.
+func (r *AP020DebugMode) ID() string {
+	return "AP020"
+}
+
+// Name returns the rule name.
+func (r *AP020DebugMode) Name() string {
+	return "Debug mode enabled"
+}
+
+// Severity returns the rule severity.
+func (r *AP020DebugMode) Severity() models.Severity {
+	return models.SeverityMedium
+}
+
+// Description returns the rule description.
+func (r *AP020DebugMode) Description() string {
+	return "Framework debug mode is enabled outside tests (gin.SetMode(gin.DebugMode), e.Debug = true). " +
+		"Debug mode exposes internal errors, stack traces and route listings."
+}
+
+// EvaluateProject finds debug mode switches in non-test files. Switches guarded by a
+// condition (e.g., an environment check) are reported with a lower severity.
+func (r *AP020DebugMode) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+
+	for _, source := range sources.Files {
+		if strings.HasSuffix(source.FilePath, "_test.go") {
+			continue
+		}
+		guards := astutil.NewConditionIndex(source.AST)
+
+		for _, dm := range findDebugModeSwitches(source, sources) {
+			finding := createProjectFinding(r, source.FilePath, astutil.GetLineNumber(source.FileSet, dm.node),
+				fmt.Sprintf("%s debug mode enabled by '%s'", dm.framework, source.NodeText(dm.node)),
+				"Enable debug mode only in local development builds and make release mode the default",
+			)
+			if condition := guards.ConditionFor(dm.node); condition != "" {
+				finding.Severity = models.SeverityLow
+				finding.Message += fmt.Sprintf(" when %s", condition)
+			}
+			finding.RelatedEndpoints = dm.affectedEndpoints(result.Endpoints, source.FilePath)
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
+
+// debugModeSwitch is a statement that enables framework debug mode.
+type debugModeSwitch struct {
+	node      ast.Node
+	framework models.Framework
+
+	// global is true if the switch affects every router of the process, not only the one it is set on.
+	global bool
+}
+
+// findDebugModeSwitches returns the statements of a file that enable Gin or Echo debug mode.
+func findDebugModeSwitches(source *astutil.ParsedSource, sources *astutil.SourceSet) []debugModeSwitch {
+	var switches []debugModeSwitch
+	ginAlias := source.GetImportAlias("github.com/gin-gonic/gin")
+	echoAlias := source.GetImportAlias("github.com/labstack/echo/v4")
+
+	var info *astutil.PackageTypes
+	var instances map[types.Object]bool
+
+	ast.Inspect(source.AST, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			// gin.SetMode(gin.DebugMode) or gin.SetMode("debug")
+			if ginAlias == "" || astutil.GetCallName(node) != ginAlias+".SetMode" || len(node.Args) != 1 {
+				return true
+			}
+			if astutil.ExprString(node.Args[0]) == ginAlias+".DebugMode" || astutil.GetStringValue(node.Args[0]) == "debug" {
+				switches = append(switches, debugModeSwitch{node: node, framework: models.FrameworkGin, global: true})
+			}
+		case *ast.AssignStmt:
+			// e.Debug = true, where e is an *echo.Echo
+			if echoAlias == "" {
+				return true
+			}
+			for i, lhs := range node.Lhs {
+				sel, ok := lhs.(*ast.SelectorExpr)
+				if !ok || sel.Sel.Name != "Debug" || i >= len(node.Rhs) || !isTrueLiteral(node.Rhs[i]) {
+					continue
+				}
+				if instances == nil {
+					info = sources.Types(source.FilePath)
+					instances = echoInstances(sources.Package(source.FilePath), info)
+				}
+				if instances[info.ObjectOf(varIdent(sel.X))] {
+					switches = append(switches, debugModeSwitch{node: node, framework: models.FrameworkEcho})
+				}
+			}
+		}
+		return true
+	})
+
+	return switches
+}
+
+// echoInstances returns the variables, parameters and struct fields of a package that hold an
+// *echo.Echo: declared with that type, or assigned the result of echo.New(). Echo is not part of
+// the scanned sources, so its values have no type and are recognised by their declarations.
+func echoInstances(pkg []*astutil.ParsedSource, info *astutil.PackageTypes) map[types.Object]bool {
+	instances := make(map[types.Object]bool)
+	for _, source := range pkg {
+		alias := source.GetImportAlias("github.com/labstack/echo/v4")
+		if alias == "" {
+			continue
+		}
+		isEchoType := func(expr ast.Expr) bool {
+			star, ok := expr.(*ast.StarExpr)
+			return ok && astutil.ExprString(star.X) == alias+".Echo"
+		}
+		isEchoNew := func(expr ast.Expr) bool {
+			call, ok := expr.(*ast.CallExpr)
+			return ok && astutil.GetCallName(call) == alias+".New"
+		}
+
+		ast.Inspect(source.AST, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.Field:
+				if isEchoType(node.Type) {
+					for _, name := range node.Names {
+						instances[info.ObjectOf(name)] = true
+					}
+				}
+			case *ast.ValueSpec:
+				for i, name := range node.Names {
+					if (node.Type != nil && isEchoType(node.Type)) || (i < len(node.Values) && isEchoNew(node.Values[i])) {
+						instances[info.ObjectOf(name)] = true
+					}
+				}
+			case *ast.AssignStmt:
+				for i, lhs := range node.Lhs {
+					if i < len(node.Rhs) && isEchoNew(node.Rhs[i]) {
+						instances[info.ObjectOf(varIdent(lhs))] = true
+					}
+				}
+			case *ast.KeyValueExpr:
+				// Server{echo: echo.New()}
+				if key, ok := node.Key.(*ast.Ident); ok && isEchoNew(node.Value) {
+					instances[info.ObjectOf(key)] = true
+				}
+			}
+			return true
+		})
+	}
+	delete(instances, nil)
+	return instances
+}
+
+// varIdent returns the identifier naming a variable or field expression: the variable
+// itself, or the field it selects (s.echo -> echo). Returns nil for other expressions.
+func varIdent(expr ast.Expr) *ast.Ident {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e
+	case *ast.SelectorExpr:
+		return e.Sel
+	case *ast.ParenExpr:
+		return varIdent(e.X)
+	}
+	return nil
+}
+
+// affectedEndpoints returns the endpoints of the switch's framework that run in debug mode:
+// all of them for process-wide switches, otherwise those defined in the same package.
+func (s debugModeSwitch) affectedEndpoints(endpoints []*models.Endpoint, filePath string) []*models.Endpoint {
+	var matching []*models.Endpoint
+	dir := filepath.Dir(filePath)
+	for _, e := range endpoints {
+		if e.Framework == s.framework && (s.global || filepath.Dir(e.FilePath) == dir) {
+			matching = append(matching, e)
+		}
+	}
+	return matching
+}
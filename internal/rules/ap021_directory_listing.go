@@ -0,0 +1,51 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// AP021DirectoryListing flags static file endpoints that list directory contents.
+type AP021DirectoryListing struct{}
+
+// NewAP021DirectoryListing creates a new AP021 rule.
+func NewAP021DirectoryListing() *AP021DirectoryListing {
+	return &AP021DirectoryListing{}
+}
+
+// ID returns the rule ID.
+func (r *AP021DirectoryListing) ID() string {
+	return "AP021"
+}
+
+// Name returns the rule name.
+func (r *AP021DirectoryListing) Name() string {
+	return "Directory listing enabled"
+}
+
+// Severity returns the rule severity.
+func (r *AP021DirectoryListing) Severity() models.Severity {
+	return models.SeverityMedium
+}
+
+// Description returns the rule description.
+func (r *AP021DirectoryListing) Description() string {
+	return "Static file endpoint lists directory contents (http.FileServer, gin.Dir(root, true), Browse: true). " +
+		"Listings reveal every file in the served directory, including ones never linked from the application."
+}
+
+// Evaluate checks static file endpoints for directory listing.
+func (r *AP021DirectoryListing) Evaluate(endpoint *models.Endpoint) []*models.Finding {
+	if endpoint.Metadata[models.MetadataDirectoryListing] != "true" {
+		return nil
+	}
+
+	return []*models.Finding{
+		createFinding(r, endpoint,
+			fmt.Sprintf("Static file endpoint '%s' lists the contents of '%s'",
+				endpoint.FullRoute(), endpoint.Metadata[models.MetadataStaticDir]),
+			"Disable directory browsing, or wrap the file system so that directories without an index file return 404",
+		),
+	}
+}
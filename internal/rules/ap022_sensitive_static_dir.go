@@ -0,0 +1,134 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// sensitiveFilePatterns are glob patterns of files that must never be served statically.
+var sensitiveFilePatterns = []string{
+	".env",
+	".env.*",
+	".git",
+	"*.pem",
+	"*.key",
+	"id_rsa*",
+	"config.yaml",
+	"config.yml",
+	"config.json",
+	"config.toml",
+	"secrets.*",
+	"credentials*",
+	"go.mod",
+	"*.go",
+}
+
+// AP022SensitiveStaticDir flags static file endpoints serving the project root or a directory
+// holding configuration or secrets.
+type AP022SensitiveStaticDir struct{}
+
+// NewAP022SensitiveStaticDir creates a new AP022 rule.
+func NewAP022SensitiveStaticDir() *AP022SensitiveStaticDir {
+	return &AP022SensitiveStaticDir{}
+}
+
+// ID returns the rule ID.
+func (r *AP022SensitiveStaticDir) ID() string {
+	return "AP022"
+}
+
+// Name returns the rule name.
+func (r *AP022SensitiveStaticDir) Name() string {
+	return "Sensitive directory served statically"
+}
+
+// Severity returns the rule severity.
+func (r *AP022SensitiveStaticDir) Severity() models.Severity {
+	return models.SeverityHigh
+}
+
+// Description returns the rule description.
+func (r *AP022SensitiveStaticDir) Description() string {
+	return "Static file endpoint serves the project root or a directory containing .env, config, key or source files. " +
+		"Anyone can download credentials and configuration by guessing file names."
+}
+
+// EvaluateProject checks the directory served by each static file endpoint. Relative directories
+// are resolved against the scan root and against the directory of the registering file.
+func (r *AP022SensitiveStaticDir) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+
+	for _, e := range result.Endpoints {
+		dir, ok := e.Metadata[models.MetadataStaticDir]
+		if !ok {
+			continue
+		}
+
+		var problem string
+		switch {
+		case filepath.Clean(dir) == "/":
+			problem = "serves the file system root"
+		default:
+			for _, candidate := range staticDirCandidates(dir, result.ScanPath, e.FilePath) {
+				if candidate == filepath.Clean(result.ScanPath) {
+					problem = "serves the project root"
+					break
+				}
+				if files := sensitiveFiles(candidate); len(files) > 0 {
+					problem = fmt.Sprintf("serves a directory containing %s", strings.Join(files, ", "))
+					break
+				}
+			}
+		}
+		if problem == "" {
+			continue
+		}
+
+		findings = append(findings, createFinding(r, e,
+			fmt.Sprintf("Static file endpoint '%s' %s ('%s')", e.FullRoute(), problem, dir),
+			"Serve a dedicated public directory (e.g., ./public) that only contains assets",
+		))
+	}
+
+	return findings
+}
+
+// staticDirCandidates returns the absolute paths a served directory may resolve to at runtime.
+func staticDirCandidates(dir, scanPath, filePath string) []string {
+	if filepath.IsAbs(dir) {
+		return []string{filepath.Clean(dir)}
+	}
+	if dir == "" {
+		dir = "."
+	}
+	return []string{
+		filepath.Join(scanPath, dir),
+		filepath.Join(filepath.Dir(filePath), dir),
+	}
+}
+
+// sensitiveFiles returns the names of the entries of a directory that match sensitiveFilePatterns.
+func sensitiveFiles(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var found []string
+	for _, entry := range entries {
+		for _, pattern := range sensitiveFilePatterns {
+			if matched, _ := filepath.Match(pattern, entry.Name()); matched {
+				found = append(found, entry.Name())
+				break
+			}
+		}
+	}
+	sort.Strings(found)
+	return found
+}
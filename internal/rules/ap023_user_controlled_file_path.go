@@ -0,0 +1,234 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// fileServingMethods maps methods that send a file from disk to the index of their path argument.
+var fileServingMethods = map[string]int{
+	"File":           0, // gin, echo
+	"FileAttachment": 0, // gin
+	"Attachment":     0, // echo
+	"Inline":         0, // echo
+	"SendFile":       0, // fiber
+	"Download":       0, // fiber
+	"ServeFile":      2, // net/http: http.ServeFile(w, r, name)
+}
+
+// AP023UserControlledFilePath flags files served from paths built from request input.
+type AP023UserControlledFilePath struct{}
+
+// NewAP023UserControlledFilePath creates a new AP023 rule.
+func NewAP023UserControlledFilePath() *AP023UserControlledFilePath {
+	return &AP023UserControlledFilePath{}
+}
+
+// ID returns the rule ID.
+func (r *AP023UserControlledFilePath) ID() string {
+	return "AP023"
+}
+
+// Name returns the rule name.
+func (r *AP023UserControlledFilePath) Name() string {
+	return "User-controlled file path"
+}
+
+// Severity returns the rule severity.
+func (r *AP023UserControlledFilePath) Severity() models.Severity {
+	return models.SeverityHigh
+}
+
+// Description returns the rule description.
+func (r *AP023UserControlledFilePath) Description() string {
+	return "Handler sends a file whose path comes from a route parameter, query or form value. " +
+		"Paths containing ../ let clients read any file the process can access."
+}
+
+// EvaluateProject checks the file-serving calls of each handler for request-controlled paths.
+func (r *AP023UserControlledFilePath) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+
+	for _, source := range sources.Files {
+		for _, h := range findHandlerFuncs(source) {
+			taint := newRequestTaint(h, pathSanitizers)
+			containment := newPathContainment(h, taint)
+
+			h.Inspect(func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				name := astutil.GetCallName(call)
+				index, ok := fileServingMethods[name[strings.LastIndex(name, ".")+1:]]
+				if !ok || !strings.Contains(name, ".") || index >= len(call.Args) || !taint.Tainted(call.Args[index]) || containment.Guards(call, call.Args[index]) {
+					return true
+				}
+
+				finding := createProjectFinding(r, source.FilePath, astutil.GetLineNumber(source.FileSet, call),
+					fmt.Sprintf("Handler '%s' passes a request-controlled path to '%s'", h.Name(), name),
+					"Reduce the value to a file name with filepath.Base, or join it with a fixed root and verify the result stays inside it",
+				)
+				finding.Snippet = redactedSnippet(source, call, nil)
+				finding.RelatedEndpoints = h.Endpoints(result.Endpoints, sources)
+				findings = append(findings, finding)
+				return true
+			})
+		}
+	}
+
+	return findings
+}
+
+// pathCleaners are the calls that return a cleaned path, with no .. elements left to escape a prefix.
+var pathCleaners = map[string]bool{
+	"filepath.Clean":        true,
+	"filepath.Join":         true,
+	"filepath.Abs":          true,
+	"filepath.EvalSymlinks": true,
+	"path.Clean":            true,
+	"path.Join":             true,
+}
+
+// pathContainment finds the checks of a handler that keep a request-controlled path inside a base
+// directory: strings.HasPrefix of the cleaned path and the base, filepath.IsLocal, or a filepath.Rel
+// result that does not start with "..".
+type pathContainment struct {
+	h     *handlerFunc
+	taint *requestTaint
+
+	// values maps the handler's variables to the expressions assigned to them.
+	values map[string][]ast.Expr
+}
+
+// newPathContainment collects the assignments of a handler's variables.
+func newPathContainment(h *handlerFunc, taint *requestTaint) *pathContainment {
+	p := &pathContainment{h: h, taint: taint, values: make(map[string][]ast.Expr)}
+	h.Inspect(func(n ast.Node) bool {
+		if assign, ok := n.(*ast.AssignStmt); ok {
+			for i, lhs := range assign.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok || ident.Name == "_" {
+					continue
+				}
+				// Multi-value assignments (rel, err := filepath.Rel(...)) record the single call
+				value := assign.Rhs[0]
+				if len(assign.Rhs) == len(assign.Lhs) {
+					value = assign.Rhs[i]
+				}
+				p.values[ident.Name] = append(p.values[ident.Name], value)
+			}
+		}
+		return true
+	})
+	return p
+}
+
+// Guards returns true if a containment check of the path passed to a call decides whether the call runs.
+func (p *pathContainment) Guards(call *ast.CallExpr, path ast.Expr) bool {
+	for _, g := range p.h.Guards(call) {
+		if g.Implies(func(test ast.Expr, holds bool) bool { return p.contains(test, holds, path) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// contains returns true if a test with the given value means the path stays inside a base directory.
+func (p *pathContainment) contains(test ast.Expr, holds bool, path ast.Expr) bool {
+	call, ok := test.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	name := astutil.GetCallName(call)
+	switch {
+	case strings.HasSuffix(name, ".IsLocal") && len(call.Args) == 1:
+		return holds && p.sameValue(call.Args[0], path)
+	case strings.HasSuffix(name, ".HasPrefix") && len(call.Args) == 2:
+		if holds {
+			return p.isCleaned(call.Args[0]) && p.isBaseDir(call.Args[1]) && p.sameValue(call.Args[0], path)
+		}
+		// strings.HasPrefix(rel, "..") on the result of filepath.Rel(base, path)
+		return strings.HasPrefix(astutil.GetStringValue(call.Args[1]), "..") && p.isRelativeTo(call.Args[0], path)
+	}
+	return false
+}
+
+// isCleaned returns true if an expression is, or is a variable assigned from, a cleaned path.
+func (p *pathContainment) isCleaned(expr ast.Expr) bool {
+	if call, ok := expr.(*ast.CallExpr); ok {
+		return pathCleaners[astutil.GetCallName(call)]
+	}
+	ident, ok := expr.(*ast.Ident)
+	if !ok || len(p.values[ident.Name]) == 0 {
+		return false
+	}
+	for _, value := range p.values[ident.Name] {
+		if !p.isCleaned(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// isBaseDir returns true if an expression can be a base directory: not request input, and not
+// a literal such as "/" or "." that every absolute or relative path starts with.
+func (p *pathContainment) isBaseDir(expr ast.Expr) bool {
+	if p.taint.Tainted(expr) {
+		return false
+	}
+	if lit, ok := expr.(*ast.BasicLit); ok {
+		return strings.Trim(astutil.GetStringValue(lit), "/.") != ""
+	}
+	return true
+}
+
+// isRelativeTo returns true if an expression is a variable assigned from filepath.Rel(base, path).
+func (p *pathContainment) isRelativeTo(expr ast.Expr, path ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	for _, value := range p.values[ident.Name] {
+		call, ok := value.(*ast.CallExpr)
+		if ok && strings.HasSuffix(astutil.GetCallName(call), ".Rel") && len(call.Args) == 2 && p.sameValue(call.Args[1], path) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameValue returns true if two expressions are the same, or share a request-controlled variable
+// directly or through the variables they are assigned from.
+func (p *pathContainment) sameValue(a, b ast.Expr) bool {
+	if astutil.ExprString(a) == astutil.ExprString(b) {
+		return true
+	}
+	shared := p.taintedVars(a, make(map[string]bool))
+	for name := range p.taintedVars(b, make(map[string]bool)) {
+		if shared[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// taintedVars returns the tainted variables an expression refers to, following their assignments.
+func (p *pathContainment) taintedVars(expr ast.Expr, seen map[string]bool) map[string]bool {
+	ast.Inspect(expr, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok || seen[ident.Name] || !p.taint.vars[ident.Name] {
+			return true
+		}
+		seen[ident.Name] = true
+		for _, value := range p.values[ident.Name] {
+			p.taintedVars(value, seen)
+		}
+		return true
+	})
+	return seen
+}
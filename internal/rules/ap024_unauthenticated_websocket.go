@@ -0,0 +1,50 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// AP024UnauthenticatedWebSocket flags WebSocket endpoints that upgrade connections without authentication.
+type AP024UnauthenticatedWebSocket struct{}
+
+// NewAP024UnauthenticatedWebSocket creates a new AP024 rule.
+func NewAP024UnauthenticatedWebSocket() *AP024UnauthenticatedWebSocket {
+	return &AP024UnauthenticatedWebSocket{}
+}
+
+// ID returns the rule ID.
+func (r *AP024UnauthenticatedWebSocket) ID() string {
+	return "AP024"
+}
+
+// Name returns the rule name.
+func (r *AP024UnauthenticatedWebSocket) Name() string {
+	return "Unauthenticated WebSocket upgrade"
+}
+
+// Severity returns the rule severity.
+func (r *AP024UnauthenticatedWebSocket) Severity() models.Severity {
+	return models.SeverityHigh
+}
+
+// Description returns the rule description.
+func (r *AP024UnauthenticatedWebSocket) Description() string {
+	return "WebSocket endpoint upgrades connections without authentication middleware. " +
+		"The connection is bidirectional, so anyone can send messages for as long as it stays open."
+}
+
+// Evaluate checks WebSocket endpoints for authentication.
+func (r *AP024UnauthenticatedWebSocket) Evaluate(endpoint *models.Endpoint) []*models.Finding {
+	if endpoint.Kind != models.EndpointKindWebSocket || endpoint.Classification != models.ClassificationPublic {
+		return nil
+	}
+
+	return []*models.Finding{
+		createFinding(r, endpoint,
+			fmt.Sprintf("WebSocket endpoint '%s' accepts connections without authentication", endpoint.FullRoute()),
+			"Authenticate the upgrade request with middleware, or validate a token before calling Upgrade/Accept",
+		),
+	}
+}
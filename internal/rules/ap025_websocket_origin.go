@@ -0,0 +1,168 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"path/filepath"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// AP025WebSocketOrigin flags WebSocket upgrades that accept connections from any origin.
+type AP025WebSocketOrigin struct{}
+
+// NewAP025WebSocketOrigin creates a new AP025 rule.
+func NewAP025WebSocketOrigin() *AP025WebSocketOrigin {
+	return &AP025WebSocketOrigin{}
+}
+
+// ID returns the rule ID.
+func (r *AP025WebSocketOrigin) ID() string {
+	return "AP025"
+}
+
+// Name returns the rule name.
+func (r *AP025WebSocketOrigin) Name() string {
+	return "WebSocket origin not checked"
+}
+
+// Severity returns the rule severity.
+func (r *AP025WebSocketOrigin) Severity() models.Severity {
+	return models.SeverityHigh
+}
+
+// Description returns the rule description.
+func (r *AP025WebSocketOrigin) Description() string {
+	return "WebSocket upgrade accepts any Origin (CheckOrigin returning true, InsecureSkipVerify, no allowed origins). " +
+		"Any website can open the socket with the visitor's cookies (cross-site WebSocket hijacking)."
+}
+
+// EvaluateProject finds WebSocket origin policies that accept any origin. Findings are located at the
+// upgrader configuration and list the WebSocket endpoints of its package; they are reported as
+// medium severity when none of those endpoints is authenticated.
+func (r *AP025WebSocketOrigin) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+
+	for _, source := range sources.Files {
+		for _, policy := range findAnyOriginUpgrades(source, sources) {
+			finding := createProjectFinding(r, source.FilePath, astutil.GetLineNumber(source.FileSet, policy.node),
+				fmt.Sprintf("WebSocket upgrade accepts any origin: %s", policy.reason),
+				"Check the Origin header against an allow-list of your own domains",
+			)
+
+			dir := filepath.Dir(source.FilePath)
+			authenticated := false
+			for _, e := range result.Endpoints {
+				if e.Kind == models.EndpointKindWebSocket && filepath.Dir(e.FilePath) == dir {
+					finding.RelatedEndpoints = append(finding.RelatedEndpoints, e)
+					authenticated = authenticated || e.Classification != models.ClassificationPublic
+				}
+			}
+			if !authenticated {
+				finding.Severity = models.SeverityMedium
+			}
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
+
+// anyOriginUpgrade is a WebSocket configuration that accepts any origin.
+type anyOriginUpgrade struct {
+	node   ast.Node
+	reason string
+}
+
+// findAnyOriginUpgrades finds the WebSocket configurations of a file that accept any origin.
+// A gorilla Upgrader without CheckOrigin is not reported: its default rejects cross-origin requests.
+func findAnyOriginUpgrades(source *astutil.ParsedSource, sources *astutil.SourceSet) []anyOriginUpgrade {
+	var found []anyOriginUpgrade
+	gorilla := source.GetImportAlias("github.com/gorilla/websocket")
+	nhooyr := source.GetImportAlias("nhooyr.io/websocket")
+	if nhooyr == "" {
+		nhooyr = source.GetImportAlias("github.com/coder/websocket")
+	}
+	fiber := source.GetImportAlias("github.com/gofiber/websocket/v2")
+	if fiber == "" {
+		fiber = source.GetImportAlias("github.com/gofiber/contrib/websocket")
+	}
+	xnet := source.GetImportAlias("golang.org/x/net/websocket")
+
+	checkOriginAlwaysTrue := func(value ast.Expr) bool {
+		return alwaysReturnsTrue(resolveFuncBody(value, source, sources))
+	}
+
+	ast.Inspect(source.AST, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CompositeLit:
+			alias, name := selectorParts(node.Type)
+			switch {
+			case alias == "":
+			case alias == gorilla && name == "Upgrader":
+				if value := fieldValue(node, "CheckOrigin"); value != nil && checkOriginAlwaysTrue(value) {
+					found = append(found, anyOriginUpgrade{node, "CheckOrigin always returns true"})
+				}
+			case alias == nhooyr && name == "AcceptOptions":
+				if value := fieldValue(node, "InsecureSkipVerify"); value != nil && isTrueLiteral(value) {
+					found = append(found, anyOriginUpgrade{node, "InsecureSkipVerify disables the origin check"})
+				} else if containsString(astutil.GetStringSlice(fieldValue(node, "OriginPatterns")), "*") {
+					found = append(found, anyOriginUpgrade{node, "OriginPatterns allows '*'"})
+				}
+			}
+		case *ast.AssignStmt:
+			// upgrader.CheckOrigin = func(r *http.Request) bool { return true }
+			if gorilla == "" {
+				return true
+			}
+			for i, lhs := range node.Lhs {
+				if sel, ok := lhs.(*ast.SelectorExpr); ok && sel.Sel.Name == "CheckOrigin" && i < len(node.Rhs) && checkOriginAlwaysTrue(node.Rhs[i]) {
+					found = append(found, anyOriginUpgrade{node, "CheckOrigin always returns true"})
+				}
+			}
+		case *ast.CallExpr:
+			name := astutil.GetCallName(node)
+			if xnet != "" && name == xnet+".Handler" {
+				found = append(found, anyOriginUpgrade{node, "websocket.Handler does not restrict the Origin"})
+			}
+			if fiber != "" && name == fiber+".New" && !fiberRestrictsOrigins(node) {
+				found = append(found, anyOriginUpgrade{node, "websocket.New without Origins allows every origin"})
+			}
+		}
+		return true
+	})
+
+	return found
+}
+
+// fiberRestrictsOrigins returns true if a Fiber websocket.New call passes a Config with specific Origins.
+func fiberRestrictsOrigins(call *ast.CallExpr) bool {
+	if len(call.Args) < 2 {
+		return false
+	}
+	for _, arg := range call.Args[1:] {
+		lit, ok := arg.(*ast.CompositeLit)
+		if !ok {
+			// A configuration built elsewhere is given the benefit of the doubt
+			return true
+		}
+		origins := astutil.GetStringSlice(fieldValue(lit, "Origins"))
+		if len(origins) > 0 && !containsString(origins, "*") {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldValue returns the value of a keyed field in a composite literal, or nil.
+func fieldValue(lit *ast.CompositeLit, field string) ast.Expr {
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok && key.Name == field {
+				return kv.Value
+			}
+		}
+	}
+	return nil
+}
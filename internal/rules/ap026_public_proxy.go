@@ -0,0 +1,62 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// AP026PublicProxy flags public endpoints that forward requests to an upstream service.
+type AP026PublicProxy struct{}
+
+// NewAP026PublicProxy creates a new AP026 rule.
+func NewAP026PublicProxy() *AP026PublicProxy {
+	return &AP026PublicProxy{}
+}
+
+// ID returns the rule ID.
+func (r *AP026PublicProxy) ID() string {
+	return "AP026"
+}
+
+// Name returns the rule name.
+func (r *AP026PublicProxy) Name() string {
+	return "Public reverse proxy"
+}
+
+// Severity returns the rule severity.
+func (r *AP026PublicProxy) Severity() models.Severity {
+	return models.SeverityHigh
+}
+
+// Description returns the rule description.
+func (r *AP026PublicProxy) Description() string {
+	return "Endpoint forwards requests to an upstream service without authentication. " +
+		"The upstream API, often an internal one that trusts its callers, becomes reachable by anyone."
+}
+
+// Evaluate checks proxy endpoints for authentication.
+func (r *AP026PublicProxy) Evaluate(endpoint *models.Endpoint) []*models.Finding {
+	if endpoint.Classification != models.ClassificationPublic {
+		return nil
+	}
+	proxy, ok := endpoint.Metadata[models.MetadataProxy]
+	if !ok {
+		return nil
+	}
+
+	return []*models.Finding{
+		createFinding(r, endpoint,
+			fmt.Sprintf("Public endpoint '%s' forwards requests to %s via %s", endpoint.FullRoute(), proxyUpstream(endpoint), proxy),
+			"Authenticate requests before they reach the proxy, or expose only the upstream routes that are meant to be public",
+		),
+	}
+}
+
+// proxyUpstream describes the upstream of a proxy endpoint.
+func proxyUpstream(endpoint *models.Endpoint) string {
+	if target := endpoint.Metadata[models.MetadataProxyTarget]; target != "" {
+		return fmt.Sprintf("'%s'", target)
+	}
+	return "an upstream service"
+}
@@ -0,0 +1,61 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// AP027WildcardProxy flags proxy endpoints that forward every path below a prefix.
+type AP027WildcardProxy struct{}
+
+// NewAP027WildcardProxy creates a new AP027 rule.
+func NewAP027WildcardProxy() *AP027WildcardProxy {
+	return &AP027WildcardProxy{}
+}
+
+// ID returns the rule ID.
+func (r *AP027WildcardProxy) ID() string {
+	return "AP027"
+}
+
+// Name returns the rule name.
+func (r *AP027WildcardProxy) Name() string {
+	return "Wildcard reverse proxy"
+}
+
+// Severity returns the rule severity.
+func (r *AP027WildcardProxy) Severity() models.Severity {
+	return models.SeverityMedium
+}
+
+// Description returns the rule description.
+func (r *AP027WildcardProxy) Description() string {
+	return "Proxy endpoint forwards every path below its prefix to the upstream. " +
+		"Upstream admin, debug and internal routes are exposed along with the intended ones."
+}
+
+// Evaluate checks the route of proxy endpoints for wildcards.
+func (r *AP027WildcardProxy) Evaluate(endpoint *models.Endpoint) []*models.Finding {
+	if _, ok := endpoint.Metadata[models.MetadataProxy]; !ok || !isWildcardRoute(endpoint) {
+		return nil
+	}
+
+	return []*models.Finding{
+		createFinding(r, endpoint,
+			fmt.Sprintf("Proxy endpoint '%s' forwards every path to %s", endpoint.FullRoute(), proxyUpstream(endpoint)),
+			"Register the upstream routes that should be reachable explicitly, or filter paths before forwarding",
+		),
+	}
+}
+
+// isWildcardRoute returns true if a route matches every path below a prefix: a wildcard segment
+// (/*, /*path, {path...}) or, for net/http, a pattern ending with a slash.
+func isWildcardRoute(endpoint *models.Endpoint) bool {
+	route := endpoint.FullRoute()
+	if strings.Contains(route, "*") || strings.Contains(route, "...}") {
+		return true
+	}
+	return endpoint.Framework == models.FrameworkNetHTTP && strings.HasSuffix(route, "/")
+}
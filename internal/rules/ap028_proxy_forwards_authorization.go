@@ -0,0 +1,186 @@
+package rules
+
+import (
+	"go/ast"
+	"path/filepath"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// AP028ProxyForwardsAuthorization flags reverse proxy Director and Rewrite functions that send
+// requests to another host without removing the client's Authorization header.
+type AP028ProxyForwardsAuthorization struct{}
+
+// NewAP028ProxyForwardsAuthorization creates a new AP028 rule.
+func NewAP028ProxyForwardsAuthorization() *AP028ProxyForwardsAuthorization {
+	return &AP028ProxyForwardsAuthorization{}
+}
+
+// ID returns the rule ID.
+func (r *AP028ProxyForwardsAuthorization) ID() string {
+	return "AP028"
+}
+
+// Name returns the rule name.
+func (r *AP028ProxyForwardsAuthorization) Name() string {
+	return "Proxy forwards Authorization header"
+}
+
+// Severity returns the rule severity.
+func (r *AP028ProxyForwardsAuthorization) Severity() models.Severity {
+	return models.SeverityHigh
+}
+
+// Description returns the rule description.
+func (r *AP028ProxyForwardsAuthorization) Description() string {
+	return "Reverse proxy Director rewrites the destination host but keeps the incoming Authorization header. " +
+		"Client tokens for this API are sent to the upstream, which can replay them."
+}
+
+// EvaluateProject finds Director and Rewrite functions of httputil reverse proxies that change the
+// destination host and never delete or replace the Authorization header. Findings list the proxy
+// endpoints of the package.
+func (r *AP028ProxyForwardsAuthorization) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+
+	for _, source := range sources.Files {
+		if !source.HasImport("net/http/httputil") {
+			continue
+		}
+		for _, director := range findProxyDirectors(source, sources) {
+			if !director.changesHost || director.handlesAuthorization {
+				continue
+			}
+
+			finding := createProjectFinding(r, source.FilePath, astutil.GetLineNumber(source.FileSet, director.node),
+				"Reverse proxy "+director.field+" sends requests to another host with the client's Authorization header",
+				"Delete the header in the "+director.field+" (req.Header.Del(\"Authorization\")) or replace it with credentials for the upstream",
+			)
+			dir := filepath.Dir(source.FilePath)
+			for _, e := range result.Endpoints {
+				if _, ok := e.Metadata[models.MetadataProxy]; ok && filepath.Dir(e.FilePath) == dir {
+					finding.RelatedEndpoints = append(finding.RelatedEndpoints, e)
+				}
+			}
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
+
+// proxyDirector is a Director or Rewrite function of a reverse proxy.
+type proxyDirector struct {
+	node  ast.Node
+	field string
+
+	// changesHost is true if the function sets the destination host, calls SetURL or calls the
+	// original director of a proxy built by httputil.NewSingleHostReverseProxy.
+	changesHost bool
+
+	// handlesAuthorization is true if the function deletes or replaces the Authorization header.
+	handlesAuthorization bool
+}
+
+// findProxyDirectors finds the Director and Rewrite functions of a file, set in an
+// httputil.ReverseProxy literal or assigned to an existing proxy (proxy.Director = ...).
+func findProxyDirectors(source *astutil.ParsedSource, sources *astutil.SourceSet) []proxyDirector {
+	httputil := source.GetImportAlias("net/http/httputil")
+	originals := originalDirectors(source)
+
+	var directors []proxyDirector
+	add := func(node ast.Node, field string, value ast.Expr) {
+		if body := resolveFuncBody(value, source, sources); body != nil {
+			directors = append(directors, analyzeDirector(node, field, body, originals))
+		}
+	}
+
+	ast.Inspect(source.AST, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CompositeLit:
+			if astutil.ExprString(node.Type) != httputil+".ReverseProxy" {
+				return true
+			}
+			for _, field := range []string{"Director", "Rewrite"} {
+				if value := fieldValue(node, field); value != nil {
+					add(node, field, value)
+				}
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				sel, ok := lhs.(*ast.SelectorExpr)
+				if ok && (sel.Sel.Name == "Director" || sel.Sel.Name == "Rewrite") && i < len(node.Rhs) {
+					add(node, sel.Sel.Name, node.Rhs[i])
+				}
+			}
+		}
+		return true
+	})
+
+	return directors
+}
+
+// originalDirectors returns the names of variables holding the Director of an existing proxy
+// (director := proxy.Director), which rewrites the host to the proxy target when called.
+func originalDirectors(source *astutil.ParsedSource) map[string]bool {
+	originals := make(map[string]bool)
+	for _, assign := range astutil.FindAssignments(source.AST) {
+		for i, rhs := range assign.Rhs {
+			sel, ok := rhs.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "Director" || i >= len(assign.Lhs) {
+				continue
+			}
+			if ident, ok := assign.Lhs[i].(*ast.Ident); ok {
+				originals[ident.Name] = true
+			}
+		}
+	}
+	return originals
+}
+
+// analyzeDirector records whether a Director or Rewrite body changes the destination host and
+// whether it removes or replaces the Authorization header.
+func analyzeDirector(node ast.Node, field string, body *ast.BlockStmt, originals map[string]bool) proxyDirector {
+	director := proxyDirector{node: node, field: field}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range stmt.Lhs {
+				if sel, ok := lhs.(*ast.SelectorExpr); ok && sel.Sel.Name == "Host" {
+					director.changesHost = true
+				}
+			}
+		case *ast.CallExpr:
+			switch fn := stmt.Fun.(type) {
+			case *ast.Ident:
+				if originals[fn.Name] {
+					director.changesHost = true
+				}
+				// delete(req.Header, "Authorization")
+				if fn.Name == "delete" && len(stmt.Args) == 2 && isAuthorizationHeader(stmt.Args[1]) {
+					director.handlesAuthorization = true
+				}
+			case *ast.SelectorExpr:
+				switch fn.Sel.Name {
+				case "SetURL":
+					director.changesHost = true
+				case "Del", "Set":
+					if len(stmt.Args) > 0 && isAuthorizationHeader(stmt.Args[0]) {
+						director.handlesAuthorization = true
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	return director
+}
+
+// isAuthorizationHeader returns true if an expression is the "Authorization" header name.
+func isAuthorizationHeader(expr ast.Expr) bool {
+	return strings.EqualFold(astutil.GetStringValue(expr), "Authorization")
+}
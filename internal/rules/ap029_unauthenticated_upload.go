@@ -0,0 +1,51 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// AP029UnauthenticatedUpload flags file upload endpoints without authentication.
+type AP029UnauthenticatedUpload struct{}
+
+// NewAP029UnauthenticatedUpload creates a new AP029 rule.
+func NewAP029UnauthenticatedUpload() *AP029UnauthenticatedUpload {
+	return &AP029UnauthenticatedUpload{}
+}
+
+// ID returns the rule ID.
+func (r *AP029UnauthenticatedUpload) ID() string {
+	return "AP029"
+}
+
+// Name returns the rule name.
+func (r *AP029UnauthenticatedUpload) Name() string {
+	return "Unauthenticated file upload"
+}
+
+// Severity returns the rule severity.
+func (r *AP029UnauthenticatedUpload) Severity() models.Severity {
+	return models.SeverityHigh
+}
+
+// Description returns the rule description.
+func (r *AP029UnauthenticatedUpload) Description() string {
+	return "Endpoint accepts multipart file uploads without authentication. " +
+		"Anyone can fill the disk, host malware on your domain or feed files to downstream parsers."
+}
+
+// Evaluate checks upload endpoints for authentication.
+func (r *AP029UnauthenticatedUpload) Evaluate(endpoint *models.Endpoint) []*models.Finding {
+	upload, ok := endpoint.Metadata[models.MetadataUpload]
+	if !ok || endpoint.Classification != models.ClassificationPublic {
+		return nil
+	}
+
+	return []*models.Finding{
+		createFinding(r, endpoint,
+			fmt.Sprintf("Public endpoint '%s' accepts file uploads (%s)", endpoint.FullRoute(), upload),
+			"Require authentication for uploads, or rate limit and quarantine anonymous uploads",
+		),
+	}
+}
@@ -0,0 +1,53 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// AP030UploadSizeLimit flags file upload endpoints without a request body size limit.
+type AP030UploadSizeLimit struct{}
+
+// NewAP030UploadSizeLimit creates a new AP030 rule.
+func NewAP030UploadSizeLimit() *AP030UploadSizeLimit {
+	return &AP030UploadSizeLimit{}
+}
+
+// ID returns the rule ID.
+func (r *AP030UploadSizeLimit) ID() string {
+	return "AP030"
+}
+
+// Name returns the rule name.
+func (r *AP030UploadSizeLimit) Name() string {
+	return "Upload without size limit"
+}
+
+// Severity returns the rule severity.
+func (r *AP030UploadSizeLimit) Severity() models.Severity {
+	return models.SeverityMedium
+}
+
+// Description returns the rule description.
+func (r *AP030UploadSizeLimit) Description() string {
+	return "File upload endpoint has no body size limit (http.MaxBytesReader, gin MaxMultipartMemory, fiber BodyLimit, " +
+		"body limit middleware). Large uploads can exhaust memory and disk."
+}
+
+// Evaluate checks upload endpoints for a body size limit.
+func (r *AP030UploadSizeLimit) Evaluate(endpoint *models.Endpoint) []*models.Finding {
+	if _, ok := endpoint.Metadata[models.MetadataUpload]; !ok {
+		return nil
+	}
+	if _, ok := endpoint.Metadata[models.MetadataBodyLimit]; ok {
+		return nil
+	}
+
+	return []*models.Finding{
+		createFinding(r, endpoint,
+			fmt.Sprintf("Upload endpoint '%s' does not limit the request body size", endpoint.FullRoute()),
+			"Wrap the body with http.MaxBytesReader or configure a body limit on the router",
+		),
+	}
+}
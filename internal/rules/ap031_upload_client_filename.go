@@ -0,0 +1,89 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// fileWritingCalls maps calls that write a file to the index of their destination path argument.
+// Method names match on any receiver; package functions match by full name.
+var fileWritingCalls = map[string]int{
+	"SaveUploadedFile": 1,
+	"SaveFile":         1,
+	"os.Create":        0,
+	"os.OpenFile":      0,
+	"os.WriteFile":     0,
+	"ioutil.WriteFile": 0,
+}
+
+// AP031UploadClientFilename flags handlers that save uploads to a path built from the client's file name.
+type AP031UploadClientFilename struct{}
+
+// NewAP031UploadClientFilename creates a new AP031 rule.
+func NewAP031UploadClientFilename() *AP031UploadClientFilename {
+	return &AP031UploadClientFilename{}
+}
+
+// ID returns the rule ID.
+func (r *AP031UploadClientFilename) ID() string {
+	return "AP031"
+}
+
+// Name returns the rule name.
+func (r *AP031UploadClientFilename) Name() string {
+	return "Upload saved to client-controlled path"
+}
+
+// Severity returns the rule severity.
+func (r *AP031UploadClientFilename) Severity() models.Severity {
+	return models.SeverityHigh
+}
+
+// Description returns the rule description.
+func (r *AP031UploadClientFilename) Description() string {
+	return "Handler writes an uploaded file to a path derived from FileHeader.Filename or other request input. " +
+		"A name such as ../../etc/cron.d/job overwrites files outside the upload directory."
+}
+
+// EvaluateProject checks the file-writing calls of each handler for destinations derived from
+// the client-supplied file name or other request input.
+func (r *AP031UploadClientFilename) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+
+	for _, source := range sources.Files {
+		for _, h := range findHandlerFuncs(source) {
+			taint := newRequestTaint(h, pathSanitizers)
+			containment := newPathContainment(h, taint)
+
+			h.Inspect(func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				name := astutil.GetCallName(call)
+				index, ok := fileWritingCalls[name]
+				if !ok && strings.Contains(name, ".") {
+					index, ok = fileWritingCalls[name[strings.LastIndex(name, ".")+1:]]
+				}
+				if !ok || index >= len(call.Args) || !taint.Tainted(call.Args[index]) || containment.Guards(call, call.Args[index]) {
+					return true
+				}
+
+				finding := createProjectFinding(r, source.FilePath, astutil.GetLineNumber(source.FileSet, call),
+					fmt.Sprintf("Handler '%s' saves a file with '%s' to a path derived from the client's file name or request input", h.Name(), name),
+					"Generate the stored file name on the server (e.g., a UUID), or reduce the client name with filepath.Base",
+				)
+				finding.Snippet = redactedSnippet(source, call, nil)
+				finding.RelatedEndpoints = h.Endpoints(result.Endpoints, sources)
+				findings = append(findings, finding)
+				return true
+			})
+		}
+	}
+
+	return findings
+}
@@ -0,0 +1,212 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// privilegedFields are the lowercase names of struct fields that grant privileges or ownership
+// and must never be set from a request body.
+var privilegedFields = []string{
+	"role", "roles", "isadmin", "admin", "issuperuser", "superuser", "permissions", "scopes",
+	"ownerid", "tenantid", "orgid", "organizationid", "accountid", "isverified", "emailverified",
+}
+
+// bodyBindingMethods maps the methods that decode a request body into a value to the index of
+// that value's argument (c.ShouldBindJSON(&u), c.BodyParser(&u), decoder.Decode(&u)).
+var bodyBindingMethods = map[string]int{
+	"Bind":               0,
+	"BindJSON":           0,
+	"ShouldBind":         0,
+	"ShouldBindJSON":     0,
+	"ShouldBindWith":     0,
+	"ShouldBindBodyWith": 0,
+	"MustBindWith":       0,
+	"BodyParser":         0,
+}
+
+// maxEmbeddedDepth limits how deep embedded structs are searched for privileged fields.
+const maxEmbeddedDepth = 3
+
+// AP032MassAssignment flags handlers that bind request bodies into structs with privileged fields.
+type AP032MassAssignment struct {
+	customFields []string
+}
+
+// NewAP032MassAssignment creates a new AP032 rule.
+// customFields contains additional names of privileged struct fields.
+func NewAP032MassAssignment(customFields []string) *AP032MassAssignment {
+	return &AP032MassAssignment{customFields: customFields}
+}
+
+// ID returns the rule ID.
+func (r *AP032MassAssignment) ID() string {
+	return "AP032"
+}
+
+// Name returns the rule name.
+func (r *AP032MassAssignment) Name() string {
+	return "Mass assignment of privileged fields"
+}
+
+// Severity returns the rule severity.
+func (r *AP032MassAssignment) Severity() models.Severity {
+	return models.SeverityHigh
+}
+
+// Description returns the rule description.
+func (r *AP032MassAssignment) Description() string {
+	return "Handler binds the request body into a struct with privileged fields (Role, IsAdmin, OwnerID, TenantID). " +
+		"Clients can set those fields themselves and escalate privileges or take over other tenants' records."
+}
+
+// EvaluateProject resolves the type each handler binds the request body into and reports exported
+// privileged fields that are not excluded from JSON with a "-" tag. Findings are reported against
+// the endpoints served by the handler.
+func (r *AP032MassAssignment) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+
+	for _, source := range sources.Files {
+		var info *astutil.PackageTypes
+		for _, h := range findHandlerFuncs(source) {
+			h.Inspect(func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				target := bodyBindingTarget(call)
+				if target == nil {
+					return true
+				}
+				endpoints := h.Endpoints(result.Endpoints, sources)
+				if len(endpoints) == 0 {
+					return true
+				}
+				if info == nil {
+					info = sources.Types(source.FilePath)
+				}
+
+				typeName, fields := r.boundPrivilegedFields(info.TypeOf(target))
+				if len(fields) == 0 {
+					return true
+				}
+				for _, e := range endpoints {
+					finding := createFinding(r, e,
+						fmt.Sprintf("Handler '%s' binds the request body into '%s' with privileged fields: %s",
+							h.Name(), typeName, strings.Join(fields, ", ")),
+						"Bind into a request DTO without these fields, or tag them `json:\"-\"` and set them on the server",
+					)
+					finding.Snippet = redactedSnippet(source, call, nil)
+					findings = append(findings, finding)
+				}
+				return true
+			})
+		}
+	}
+
+	return findings
+}
+
+// bodyBindingTarget returns the value a call decodes the request body into, or nil if the call
+// does not bind a request body. json.NewDecoder(...).Decode(&v) counts when the decoder reads a Body.
+func bodyBindingTarget(call *ast.CallExpr) ast.Expr {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	if index, ok := bodyBindingMethods[sel.Sel.Name]; ok && index < len(call.Args) {
+		return call.Args[index]
+	}
+	if sel.Sel.Name != "Decode" || len(call.Args) != 1 {
+		return nil
+	}
+	decoder, ok := sel.X.(*ast.CallExpr)
+	if !ok || !strings.HasSuffix(astutil.GetCallName(decoder), ".NewDecoder") || len(decoder.Args) != 1 {
+		return nil
+	}
+	if body, ok := decoder.Args[0].(*ast.SelectorExpr); ok && body.Sel.Name == "Body" {
+		return call.Args[0]
+	}
+	return nil
+}
+
+// boundPrivilegedFields returns the name of the struct type a value binds into and its privileged
+// fields that JSON decoding can set. Pointers and slices are followed to their element type.
+func (r *AP032MassAssignment) boundPrivilegedFields(t types.Type) (string, []string) {
+	t = elementType(t)
+	if t == nil {
+		return "", nil
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return "", nil
+	}
+
+	name := types.TypeString(t, func(p *types.Package) string { return p.Name() })
+	fields := r.privilegedStructFields(st, maxEmbeddedDepth)
+	sort.Strings(fields)
+	return name, fields
+}
+
+// elementType follows pointers and slices to the element type.
+func elementType(t types.Type) types.Type {
+	for {
+		switch typ := t.(type) {
+		case *types.Pointer:
+			t = typ.Elem()
+		case *types.Slice:
+			t = typ.Elem()
+		default:
+			return t
+		}
+	}
+}
+
+// privilegedStructFields returns the exported privileged fields of a struct and of its embedded structs.
+func (r *AP032MassAssignment) privilegedStructFields(st *types.Struct, depth int) []string {
+	var fields []string
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if !field.Exported() || jsonIgnored(st.Tag(i)) {
+			continue
+		}
+		if field.Embedded() && depth > 0 {
+			if embedded, ok := field.Type().Underlying().(*types.Struct); ok {
+				fields = append(fields, r.privilegedStructFields(embedded, depth-1)...)
+				continue
+			}
+		}
+		if r.isPrivileged(field.Name()) {
+			fields = append(fields, field.Name())
+		}
+	}
+	return fields
+}
+
+// isPrivileged returns true if a field name is a built-in or configured privileged field.
+func (r *AP032MassAssignment) isPrivileged(name string) bool {
+	lower := strings.ToLower(name)
+	for _, field := range privilegedFields {
+		if lower == field {
+			return true
+		}
+	}
+	for _, field := range r.customFields {
+		if strings.EqualFold(name, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonIgnored returns true if a struct tag excludes the field from JSON (`json:"-"`).
+func jsonIgnored(tag string) bool {
+	return reflect.StructTag(tag).Get("json") == "-"
+}
@@ -0,0 +1,338 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// responseMethods maps the methods that serialise a value as JSON response to the index of that
+// value's argument; -1 means the last argument (c.JSON(200, v), ctx.JSON(v), c.JSONP(200, cb, v)).
+var responseMethods = map[string]int{
+	"JSON":         -1,
+	"JSONP":        -1,
+	"IndentedJSON": 1,
+	"SecureJSON":   1,
+	"PureJSON":     1,
+	"AsciiJSON":    1,
+	"JSONPretty":   1,
+}
+
+// sensitiveFieldWords are the lowercase last words of field names holding credentials or secrets.
+var sensitiveFieldWords = map[string]bool{
+	"password": true,
+	"passwd":   true,
+	"pwd":      true,
+	"secret":   true,
+	"token":    true,
+	"ssn":      true,
+	"apikey":   true,
+}
+
+// sensitiveKeyQualifiers are the words that make a field ending in "Key" a secret (APIKey, PrivateKey).
+var sensitiveKeyQualifiers = map[string]bool{
+	"api":        true,
+	"private":    true,
+	"secret":     true,
+	"signing":    true,
+	"encryption": true,
+}
+
+// personalFieldWords are the lowercase last words of field names holding personal data.
+var personalFieldWords = map[string]bool{
+	"email":     true,
+	"phone":     true,
+	"mobile":    true,
+	"dob":       true,
+	"birth":     true,
+	"birthday":  true,
+	"birthdate": true,
+	"address":   true,
+	"passport":  true,
+}
+
+// personalIDQualifiers are the words that make a field ending in "ID" or "Number" personal data
+// (NationalID, TaxID, PhoneNumber, PassportNumber).
+var personalIDQualifiers = map[string]bool{
+	"national": true,
+	"tax":      true,
+	"passport": true,
+	"phone":    true,
+	"mobile":   true,
+}
+
+// networkAddressQualifiers are the words that make a field ending in "Address" a network address.
+var networkAddressQualifiers = map[string]bool{
+	"ip":     true,
+	"mac":    true,
+	"remote": true,
+	"local":  true,
+	"server": true,
+	"listen": true,
+	"wallet": true,
+}
+
+// maxResponseDepth limits how deep nested response structs are searched for sensitive fields.
+const maxResponseDepth = 3
+
+// AP033SensitiveResponseFields flags handlers that serialise credentials or secrets in JSON responses.
+type AP033SensitiveResponseFields struct{}
+
+// NewAP033SensitiveResponseFields creates a new AP033 rule.
+func NewAP033SensitiveResponseFields() *AP033SensitiveResponseFields {
+	return &AP033SensitiveResponseFields{}
+}
+
+// ID returns the rule ID.
+func (r *AP033SensitiveResponseFields) ID() string {
+	return "AP033"
+}
+
+// Name returns the rule name.
+func (r *AP033SensitiveResponseFields) Name() string {
+	return "Sensitive data in response"
+}
+
+// Severity returns the rule severity.
+func (r *AP033SensitiveResponseFields) Severity() models.Severity {
+	return models.SeverityMedium
+}
+
+// Description returns the rule description.
+func (r *AP033SensitiveResponseFields) Description() string {
+	return "Handler returns a struct whose password, password hash, token, secret, SSN or API key fields are serialised, " +
+		"or whose email, phone, date of birth, address or national ID fields are serialised on a public route. " +
+		"Clients receive data they should never see (OWASP API3: excessive data exposure)."
+}
+
+// EvaluateProject resolves the types each handler serialises through JSON responses and reports
+// exported sensitive fields not excluded with a "-" JSON tag. Findings are reported against the
+// endpoints served by the handler; on public endpoints personal data fields are reported too,
+// with high severity.
+func (r *AP033SensitiveResponseFields) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+
+	for _, source := range sources.Files {
+		var info *astutil.PackageTypes
+		for _, h := range findHandlerFuncs(source) {
+			h.Inspect(func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				value := responseValue(call)
+				if value == nil {
+					return true
+				}
+				endpoints := h.Endpoints(result.Endpoints, sources)
+				if len(endpoints) == 0 {
+					return true
+				}
+				if info == nil {
+					info = sources.Types(source.FilePath)
+				}
+
+				fields := sensitiveResponseFields(value, info, isSensitiveFieldName)
+				personal := sensitiveResponseFields(value, info, isPersonalFieldName)
+				if len(fields) == 0 && len(personal) == 0 {
+					return true
+				}
+				for _, e := range endpoints {
+					reported := fields
+					if isKnownPublicEndpoint(e.FullRoute()) {
+						reported = withoutTokenFields(fields)
+					}
+					if e.Classification == models.ClassificationPublic && len(personal) > 0 {
+						reported = append(append([]string(nil), reported...), personal...)
+						sort.Strings(reported)
+					}
+					if len(reported) == 0 {
+						continue
+					}
+					finding := createFinding(r, e,
+						fmt.Sprintf("Handler '%s' returns sensitive fields in its response: %s", h.Name(), strings.Join(reported, ", ")),
+						"Return a response DTO without these fields, or tag them `json:\"-\"`",
+					)
+					if e.Classification == models.ClassificationPublic {
+						finding.Severity = models.SeverityHigh
+					}
+					finding.Snippet = redactedSnippet(source, call, nil)
+					findings = append(findings, finding)
+				}
+				return true
+			})
+		}
+	}
+
+	return findings
+}
+
+// responseValue returns the value a call serialises as JSON response, or nil.
+// json.NewEncoder(w).Encode(v) counts as a response; any other encoder target is assumed to be a writer.
+func responseValue(call *ast.CallExpr) ast.Expr {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(call.Args) == 0 {
+		return nil
+	}
+	if index, ok := responseMethods[sel.Sel.Name]; ok {
+		if index < 0 || index >= len(call.Args) {
+			index = len(call.Args) - 1
+		}
+		return call.Args[index]
+	}
+	if sel.Sel.Name == "Encode" && len(call.Args) == 1 {
+		if encoder, ok := sel.X.(*ast.CallExpr); ok && strings.HasSuffix(astutil.GetCallName(encoder), ".NewEncoder") {
+			return call.Args[0]
+		}
+	}
+	return nil
+}
+
+// sensitiveResponseFields returns the fields matching a name check serialised from a response value,
+// as Type.Field paths. Map and struct literals (gin.H{"user": u}) are searched value by value.
+func sensitiveResponseFields(value ast.Expr, info *astutil.PackageTypes, match func(name string) bool) []string {
+	seen := make(map[string]bool)
+	var fields []string
+	add := func(found []string) {
+		for _, field := range found {
+			if !seen[field] {
+				seen[field] = true
+				fields = append(fields, field)
+			}
+		}
+	}
+
+	var visit func(expr ast.Expr)
+	visit = func(expr ast.Expr) {
+		t := elementType(info.TypeOf(expr))
+		if t != nil {
+			if _, ok := t.Underlying().(*types.Struct); ok {
+				add(sensitiveTypeFields(t, maxResponseDepth, match))
+				return
+			}
+		}
+		// Untyped or map literals: gin.H{"user": user}, map[string]any{"token": t}
+		if lit, ok := unwrapAddr(expr).(*ast.CompositeLit); ok {
+			for _, elt := range lit.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					visit(kv.Value)
+				}
+			}
+		}
+	}
+	visit(value)
+
+	sort.Strings(fields)
+	return fields
+}
+
+// sensitiveTypeFields returns the serialised fields matching a name check of a struct type and of
+// the structs it embeds or contains, as Type.Field paths.
+func sensitiveTypeFields(t types.Type, depth int, match func(name string) bool) []string {
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+	name := types.TypeString(t, func(p *types.Package) string { return p.Name() })
+
+	var fields []string
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if !field.Exported() || jsonIgnored(st.Tag(i)) {
+			continue
+		}
+		if match(field.Name()) {
+			fields = append(fields, name+"."+field.Name())
+			continue
+		}
+		if depth == 0 {
+			continue
+		}
+		nested := elementType(field.Type())
+		if m, ok := nested.(*types.Map); ok {
+			nested = elementType(m.Elem())
+		}
+		if _, ok := nested.Underlying().(*types.Struct); ok {
+			fields = append(fields, sensitiveTypeFields(nested, depth-1, match)...)
+		}
+	}
+	return fields
+}
+
+// withoutTokenFields removes token fields, which login, OAuth and refresh endpoints return by design.
+func withoutTokenFields(fields []string) []string {
+	var kept []string
+	for _, field := range fields {
+		words := astutil.SplitWords(field[strings.LastIndex(field, ".")+1:])
+		if words[len(words)-1] != "token" {
+			kept = append(kept, field)
+		}
+	}
+	return kept
+}
+
+// isSensitiveFieldName returns true if a field name ends in a credential or secret word
+// (Password, PasswordHash, AccessToken, ClientSecret, SSN, APIKey), so that related metadata
+// such as PasswordChangedAt or TokenExpiry is not reported.
+func isSensitiveFieldName(name string) bool {
+	words := astutil.SplitWords(name)
+	if len(words) == 0 {
+		return false
+	}
+	last := words[len(words)-1]
+	prev := ""
+	if len(words) > 1 {
+		prev = words[len(words)-2]
+	}
+
+	switch {
+	case sensitiveFieldWords[last]:
+		return true
+	case last == "key":
+		return sensitiveKeyQualifiers[prev]
+	case last == "hash" || last == "digest" || last == "salt":
+		for _, word := range words[:len(words)-1] {
+			if word == "password" || word == "passwd" || word == "pwd" {
+				return true
+			}
+		}
+	case last == "number":
+		return prev == "security" || prev == "ssn"
+	}
+	return false
+}
+
+// isPersonalFieldName returns true if a field name ends in a personal data word (Email, Phone,
+// DateOfBirth, BirthDate, Address, NationalID, PhoneNumber), except network addresses (IPAddress).
+func isPersonalFieldName(name string) bool {
+	words := astutil.SplitWords(name)
+	if len(words) == 0 {
+		return false
+	}
+	last := words[len(words)-1]
+	prev := ""
+	if len(words) > 1 {
+		prev = words[len(words)-2]
+	}
+
+	switch {
+	case last == "address":
+		return !networkAddressQualifiers[prev]
+	case personalFieldWords[last]:
+		return true
+	case last == "date":
+		return prev == "birth"
+	case last == "id" || last == "number":
+		for _, word := range words[:len(words)-1] {
+			if personalIDQualifiers[word] {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,249 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/routing"
+)
+
+// keyedPrincipalAccessors are the context getters that return the current principal when their key
+// names one (c.Get("userID"), c.MustGet("claims"), c.Locals("user"), ctx.Value(userKey)).
+var keyedPrincipalAccessors = map[string]bool{
+	"Get":       true,
+	"GetString": true,
+	"GetInt":    true,
+	"GetInt64":  true,
+	"GetUint":   true,
+	"MustGet":   true,
+	"Locals":    true,
+	"Value":     true,
+}
+
+// principalAccessors are the helper functions that return the current principal.
+var principalAccessors = map[string]bool{
+	"UserFromContext":      true,
+	"ClaimsFromContext":    true,
+	"PrincipalFromContext": true,
+	"CurrentUser":          true,
+	"CurrentUserID":        true,
+	"GetUser":              true,
+	"GetUserID":            true,
+	"GetClaims":            true,
+}
+
+// principalKeyMarkers are lowercase fragments of context keys holding the current principal.
+var principalKeyMarkers = []string{"user", "uid", "sub", "claims", "principal", "account", "owner", "identity", "session", "tenant"}
+
+// dataAccessPrefixes are the method name prefixes of repository and database calls.
+var dataAccessPrefixes = []string{
+	"Find", "First", "Get", "Where", "Query", "Exec", "Delete", "Update", "Load", "Fetch",
+	"Select", "Take", "Remove", "Save", "Read", "Lookup", "Retrieve",
+}
+
+// dataAccessReceivers are lowercase fragments of receiver names of repositories and database handles.
+var dataAccessReceivers = []string{"db", "repo", "store", "dao", "svc", "service", "queries", "model"}
+
+// ownershipCheckMarkers are lowercase fragments of the names of functions that check access to an object.
+var ownershipCheckMarkers = []string{"authoriz", "owner", "owns", "canaccess", "checkaccess", "enforce", "permission", "policy"}
+
+// AP034ObjectLevelAuthorization flags authenticated object-ID routes whose handler loads the object
+// without checking that it belongs to the current principal.
+type AP034ObjectLevelAuthorization struct {
+	customAccessors []string
+}
+
+// NewAP034ObjectLevelAuthorization creates a new AP034 rule.
+// customAccessors contains additional names of functions returning the current principal.
+func NewAP034ObjectLevelAuthorization(customAccessors []string) *AP034ObjectLevelAuthorization {
+	return &AP034ObjectLevelAuthorization{customAccessors: customAccessors}
+}
+
+// ID returns the rule ID.
+func (r *AP034ObjectLevelAuthorization) ID() string {
+	return "AP034"
+}
+
+// Name returns the rule name.
+func (r *AP034ObjectLevelAuthorization) Name() string {
+	return "Object access without ownership check"
+}
+
+// Severity returns the rule severity.
+func (r *AP034ObjectLevelAuthorization) Severity() models.Severity {
+	return models.SeverityMedium
+}
+
+// Description returns the rule description.
+func (r *AP034ObjectLevelAuthorization) Description() string {
+	return "Authenticated route with an object ID parameter passes the ID to a repository or database call " +
+		"without comparing the object to the current user. Any user can read or modify other users' objects by " +
+		"changing the ID (OWASP API1: broken object level authorization)."
+}
+
+// EvaluateProject checks the handlers of authenticated routes with object ID parameters. A handler
+// is reported when one of the route's object ID parameters reaches a data access call and the current principal is neither
+// compared, passed to a data access call, nor given to an access check function.
+func (r *AP034ObjectLevelAuthorization) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+
+	for _, source := range sources.Files {
+		for _, h := range findHandlerFuncs(source) {
+			var candidates []*models.Endpoint
+			for _, e := range h.Endpoints(result.Endpoints, sources) {
+				if e.Classification == models.ClassificationAuthenticated && len(objectIDParams(e.FullRoute())) > 0 {
+					candidates = append(candidates, e)
+				}
+			}
+			if len(candidates) == 0 {
+				continue
+			}
+
+			for _, e := range candidates {
+				access := r.unscopedDataAccess(h, newRouteParamTaint(h, objectIDParams(e.FullRoute())))
+				if access == nil {
+					continue
+				}
+				finding := createFinding(r, e,
+					fmt.Sprintf("Possible broken object level authorization (medium confidence): handler '%s' passes route parameter '%s' to '%s' without checking it against the current user",
+						h.Name(), strings.Join(objectIDParams(e.FullRoute()), "', '"), astutil.GetCallName(access)),
+					"Scope the query to the current user (e.g., WHERE id = ? AND owner_id = ?) or compare the object's owner with the authenticated principal",
+				)
+				finding.Snippet = redactedSnippet(source, access, nil)
+				findings = append(findings, finding)
+			}
+		}
+	}
+
+	return findings
+}
+
+// unscopedDataAccess returns the first data access call of a handler that receives a tainted route
+// parameter, or nil if there is none or the handler checks ownership.
+func (r *AP034ObjectLevelAuthorization) unscopedDataAccess(h *handlerFunc, input *requestTaint) *ast.CallExpr {
+	principal := newTaint(h, r.isPrincipalCall, nil, nil)
+
+	var access *ast.CallExpr
+	checked := false
+	h.Inspect(func(n ast.Node) bool {
+		if checked {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.BinaryExpr:
+			// user.ID == order.OwnerID, claims.Subject != id; err != nil does not compare the principal
+			if (node.Op == token.EQL || node.Op == token.NEQ) && !isNil(node.X) && !isNil(node.Y) &&
+				(principal.Tainted(node.X) || principal.Tainted(node.Y)) {
+				checked = true
+			}
+		case *ast.CallExpr:
+			name := strings.ToLower(astutil.GetCallName(node))
+			for _, marker := range ownershipCheckMarkers {
+				if strings.Contains(name, marker) {
+					checked = true
+				}
+			}
+			if !isDataAccessCall(node) || isRequestInputCall(node) || r.isPrincipalCall(node) {
+				return true
+			}
+			for _, arg := range node.Args {
+				if principal.Tainted(arg) {
+					// The query is scoped to the current principal
+					checked = true
+				}
+			}
+			if access == nil {
+				for _, arg := range node.Args {
+					if input.Tainted(arg) {
+						access = node
+						break
+					}
+				}
+			}
+		}
+		return !checked
+	})
+
+	if checked {
+		return nil
+	}
+	return access
+}
+
+// isPrincipalCall returns true if a call returns the current principal: a built-in or configured
+// accessor, or a context getter whose key names the principal.
+func (r *AP034ObjectLevelAuthorization) isPrincipalCall(call *ast.CallExpr) bool {
+	name := astutil.GetCallName(call)
+	method := name[strings.LastIndex(name, ".")+1:]
+	for _, custom := range r.customAccessors {
+		if name == custom || method == custom {
+			return true
+		}
+	}
+	if principalAccessors[method] {
+		return true
+	}
+	if !keyedPrincipalAccessors[method] || len(call.Args) == 0 || !strings.Contains(name, ".") {
+		return false
+	}
+
+	key, ok := call.Args[0].(*ast.BasicLit)
+	if !ok {
+		// Typed context keys (ctx.Value(userKey)) are assumed to hold the principal
+		return method == "Value" || containsPrincipalMarker(astutil.ExprString(call.Args[0]))
+	}
+	return containsPrincipalMarker(astutil.GetStringValue(key))
+}
+
+// containsPrincipalMarker returns true if a context key names the current principal.
+func containsPrincipalMarker(key string) bool {
+	lower := strings.ToLower(key)
+	for _, marker := range principalKeyMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// isDataAccessCall returns true if a call looks like a repository or database call: a method with a
+// data access prefix (FindByID, Where, Delete), or any method of a repository or database receiver.
+func isDataAccessCall(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	for _, prefix := range dataAccessPrefixes {
+		if strings.HasPrefix(sel.Sel.Name, prefix) {
+			return true
+		}
+	}
+	receiver := strings.ToLower(astutil.ExprString(sel.X))
+	for _, fragment := range dataAccessReceivers {
+		if strings.Contains(receiver, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNil returns true if the expression is the identifier nil.
+func isNil(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "nil"
+}
+
+// objectIDParams returns the route parameters that identify an object (id, userID, order_id).
+func objectIDParams(route string) []string {
+	var params []string
+	for _, name := range routing.ParamNames(route) {
+		if strings.HasSuffix(strings.ToLower(name), "id") {
+			params = append(params, name)
+		}
+	}
+	return params
+}
@@ -0,0 +1,52 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// AP035StateChangingGet flags read-only endpoints whose handlers change state.
+type AP035StateChangingGet struct{}
+
+// NewAP035StateChangingGet creates a new AP035 rule.
+func NewAP035StateChangingGet() *AP035StateChangingGet {
+	return &AP035StateChangingGet{}
+}
+
+// ID returns the rule ID.
+func (r *AP035StateChangingGet) ID() string {
+	return "AP035"
+}
+
+// Name returns the rule name.
+func (r *AP035StateChangingGet) Name() string {
+	return "State-changing GET"
+}
+
+// Severity returns the rule severity.
+func (r *AP035StateChangingGet) Severity() models.Severity {
+	return models.SeverityMedium
+}
+
+// Description returns the rule description.
+func (r *AP035StateChangingGet) Description() string {
+	return "GET handler changes state (database Exec, ORM create, update or delete). GET requests are sent by " +
+		"links, images and prefetching, are exempt from CSRF middleware and may be cached or retried."
+}
+
+// Evaluate checks if a read-only endpoint's handler changes state.
+func (r *AP035StateChangingGet) Evaluate(endpoint *models.Endpoint) []*models.Finding {
+	call, ok := endpoint.Metadata[models.MetadataStateChanging]
+	if !ok {
+		return nil
+	}
+
+	return []*models.Finding{
+		createFinding(r, endpoint,
+			fmt.Sprintf("Endpoint '%s' [%s] changes state (%s) although it only handles read methods",
+				endpoint.FullRoute(), endpoint.DisplayMethods(), call),
+			"Register state-changing handlers with POST, PUT, PATCH or DELETE so they are covered by authentication and CSRF protection",
+		),
+	}
+}
@@ -0,0 +1,344 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"path/filepath"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// authCookieMarkers are lowercase fragments of the names of session and authentication cookies.
+var authCookieMarkers = []string{"sess", "token", "jwt", "auth", "remember", "login"}
+
+// sessionOptionTypes maps session packages to their cookie option types and the fields holding
+// the Secure, HttpOnly and SameSite settings.
+var sessionOptionTypes = map[string]map[string][3]string{
+	"github.com/gorilla/sessions":                    {"Options": {"Secure", "HttpOnly", "SameSite"}},
+	"github.com/gin-contrib/sessions":                {"Options": {"Secure", "HttpOnly", "SameSite"}},
+	"github.com/gofiber/fiber/v2/middleware/session": {"Config": {"CookieSecure", "CookieHTTPOnly", "CookieSameSite"}},
+}
+
+// defaultInsecureStores maps session packages to the constructors whose default cookie options
+// set neither Secure nor HttpOnly.
+var defaultInsecureStores = map[string][]string{
+	"github.com/gorilla/sessions":                    {"NewCookieStore", "NewFilesystemStore"},
+	"github.com/gofiber/fiber/v2/middleware/session": {"New"},
+}
+
+// AP036InsecureCookie flags session and authentication cookies set without Secure or HttpOnly.
+type AP036InsecureCookie struct{}
+
+// NewAP036InsecureCookie creates a new AP036 rule.
+func NewAP036InsecureCookie() *AP036InsecureCookie {
+	return &AP036InsecureCookie{}
+}
+
+// ID returns the rule ID.
+func (r *AP036InsecureCookie) ID() string {
+	return "AP036"
+}
+
+// Name returns the rule name.
+func (r *AP036InsecureCookie) Name() string {
+	return "Insecure cookie"
+}
+
+// Severity returns the rule severity.
+func (r *AP036InsecureCookie) Severity() models.Severity {
+	return models.SeverityMedium
+}
+
+// Description returns the rule description.
+func (r *AP036InsecureCookie) Description() string {
+	return "Session or authentication cookie set without Secure or HttpOnly, or with SameSite=None without Secure. " +
+		"The cookie is sent over plain HTTP or readable by injected scripts."
+}
+
+// EvaluateProject checks the cookies set by handlers and the cookie options of session stores.
+// Cookie findings are reported against the endpoints whose handlers set them, session store
+// findings at the store configuration with the package's cookie-authenticated endpoints.
+func (r *AP036InsecureCookie) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+	recommendation := "Set Secure and HttpOnly on session cookies, and SameSite=Lax or Strict unless cross-site use is required"
+
+	for _, source := range sources.Files {
+		for _, h := range findHandlerFuncs(source) {
+			for _, cookie := range findInsecureCookies(h) {
+				message := fmt.Sprintf("Handler '%s' sets cookie '%s' %s", h.Name(), cookie.name, strings.Join(cookie.issues, ", "))
+				endpoints := h.CallerEndpoints(result.Endpoints, sources)
+				if len(endpoints) == 0 {
+					finding := createProjectFinding(r, source.FilePath, astutil.GetLineNumber(source.FileSet, cookie.node), message, recommendation)
+					finding.Snippet = redactedSnippet(source, cookie.node, nil)
+					findings = append(findings, finding)
+				}
+				for _, e := range endpoints {
+					finding := createFinding(r, e, message, recommendation)
+					finding.Snippet = redactedSnippet(source, cookie.node, nil)
+					findings = append(findings, finding)
+				}
+			}
+		}
+
+		for _, store := range findInsecureSessionOptions(source) {
+			finding := createProjectFinding(r, source.FilePath, astutil.GetLineNumber(source.FileSet, store.node),
+				fmt.Sprintf("Session store sets its cookie %s", strings.Join(store.issues, ", ")),
+				recommendation,
+			)
+			finding.Snippet = redactedSnippet(source, store.node, nil)
+			for _, e := range result.Endpoints {
+				if filepath.Dir(e.FilePath) == filepath.Dir(source.FilePath) && cookieAuthSource(e, sources) != "" {
+					finding.RelatedEndpoints = append(finding.RelatedEndpoints, e)
+				}
+			}
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
+
+// insecureCookie is a cookie or session cookie configuration missing security attributes.
+type insecureCookie struct {
+	node   ast.Node
+	name   string
+	issues []string
+}
+
+// findInsecureCookies finds the session and authentication cookies set by a handler without
+// Secure or HttpOnly: gin c.SetCookie(name, value, maxAge, path, domain, secure, httpOnly),
+// http.SetCookie(w, cookie), echo c.SetCookie(cookie) and fiber c.Cookie(&fiber.Cookie{...}).
+func findInsecureCookies(h *handlerFunc) []insecureCookie {
+	var found []insecureCookie
+	httpAlias := h.source.GetImportAlias("net/http")
+
+	// gin sets SameSite for the cookies of a context with c.SetSameSite
+	ginSameSiteNone := false
+	h.Inspect(func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && strings.HasSuffix(astutil.GetCallName(call), ".SetSameSite") && len(call.Args) == 1 {
+			ginSameSiteNone = isSameSiteNone(call.Args[0])
+		}
+		return true
+	})
+
+	h.Inspect(func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		var cookie ast.Expr
+		switch {
+		case sel.Sel.Name == "SetCookie" && len(call.Args) == 7:
+			if isAuthCookieName(call.Args[0]) {
+				if issues := cookieIssues(call.Args[5], call.Args[6], ginSameSiteNone); len(issues) > 0 {
+					found = append(found, insecureCookie{call, cookieName(call.Args[0]), issues})
+				}
+			}
+			return true
+		case sel.Sel.Name == "SetCookie" && len(call.Args) == 2 && httpAlias != "" && astutil.GetCallName(call) == httpAlias+".SetCookie":
+			cookie = call.Args[1]
+		case (sel.Sel.Name == "SetCookie" || sel.Sel.Name == "Cookie") && len(call.Args) == 1:
+			cookie = call.Args[0]
+		default:
+			return true
+		}
+
+		fields, ok := cookieFields(h, cookie)
+		if !ok || !isAuthCookieName(fields["Name"]) {
+			return true
+		}
+		httpOnly := fields["HttpOnly"]
+		if httpOnly == nil {
+			httpOnly = fields["HTTPOnly"]
+		}
+		if issues := cookieIssues(fields["Secure"], httpOnly, isSameSiteNone(fields["SameSite"])); len(issues) > 0 {
+			found = append(found, insecureCookie{call, cookieName(fields["Name"]), issues})
+		}
+		return true
+	})
+
+	return found
+}
+
+// cookieFields returns the fields of a cookie value: a Cookie literal, or a variable assigned one
+// in the handler together with the fields assigned to it afterwards (cookie.Secure = true).
+func cookieFields(h *handlerFunc, expr ast.Expr) (map[string]ast.Expr, bool) {
+	fields := make(map[string]ast.Expr)
+	addLiteral := func(expr ast.Expr) bool {
+		lit, ok := unwrapAddr(expr).(*ast.CompositeLit)
+		if !ok {
+			return false
+		}
+		if _, name := selectorParts(lit.Type); name != "Cookie" {
+			return false
+		}
+		for _, elt := range lit.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				if key, ok := kv.Key.(*ast.Ident); ok {
+					fields[key.Name] = kv.Value
+				}
+			}
+		}
+		return true
+	}
+
+	if addLiteral(expr) {
+		return fields, true
+	}
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return nil, false
+	}
+
+	declared := false
+	h.Inspect(func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok {
+			return true
+		}
+		for i, lhs := range assign.Lhs {
+			if i >= len(assign.Rhs) {
+				break
+			}
+			switch target := lhs.(type) {
+			case *ast.Ident:
+				if target.Name != ident.Name {
+					continue
+				}
+				if addLiteral(assign.Rhs[i]) {
+					declared = true
+				} else if call, ok := assign.Rhs[i].(*ast.CallExpr); ok && astutil.GetCallName(call) == "new" && len(call.Args) == 1 {
+					_, name := selectorParts(call.Args[0])
+					declared = name == "Cookie"
+				}
+			case *ast.SelectorExpr:
+				if x, ok := target.X.(*ast.Ident); ok && x.Name == ident.Name {
+					fields[target.Sel.Name] = assign.Rhs[i]
+				}
+			}
+		}
+		return true
+	})
+	return fields, declared
+}
+
+// findInsecureSessionOptions finds session store cookie options without Secure or HttpOnly, and
+// session stores created with default options that set neither.
+func findInsecureSessionOptions(source *astutil.ParsedSource) []insecureCookie {
+	var found []insecureCookie
+	configured := false
+
+	ast.Inspect(source.AST, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		alias, name := selectorParts(lit.Type)
+		for importPath, types := range sessionOptionTypes {
+			attrs, ok := types[name]
+			if !ok || alias == "" || source.GetImportAlias(importPath) != alias {
+				continue
+			}
+			configured = true
+			issues := cookieIssues(fieldValue(lit, attrs[0]), fieldValue(lit, attrs[1]), isSameSiteNone(fieldValue(lit, attrs[2])))
+			if len(issues) > 0 {
+				found = append(found, insecureCookie{node: lit, issues: issues})
+			}
+		}
+		return true
+	})
+	if configured {
+		return found
+	}
+
+	for _, call := range astutil.FindCallExprs(source.AST) {
+		name := astutil.GetCallName(call)
+		for importPath, constructors := range defaultInsecureStores {
+			alias := source.GetImportAlias(importPath)
+			if alias == "" || !strings.HasPrefix(name, alias+".") || !containsString(constructors, strings.TrimPrefix(name, alias+".")) {
+				continue
+			}
+			// Fiber's session.New takes its options as an optional Config, checked above
+			if len(call.Args) > 0 && importPath == "github.com/gofiber/fiber/v2/middleware/session" {
+				continue
+			}
+			found = append(found, insecureCookie{node: call, issues: []string{"with default options, without Secure", "without HttpOnly"}})
+		}
+	}
+	return found
+}
+
+// cookieIssues lists the missing security attributes of a cookie. Attributes set to anything
+// other than a false literal are assumed to be enabled.
+func cookieIssues(secure, httpOnly ast.Expr, sameSiteNone bool) []string {
+	var issues []string
+	if isDisabled(secure) {
+		if sameSiteNone {
+			issues = append(issues, "with SameSite=None without Secure")
+		} else {
+			issues = append(issues, "without Secure")
+		}
+	}
+	if isDisabled(httpOnly) {
+		issues = append(issues, "without HttpOnly")
+	}
+	return issues
+}
+
+// isDisabled returns true if a boolean attribute is missing or false.
+func isDisabled(expr ast.Expr) bool {
+	if expr == nil {
+		return true
+	}
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "false"
+}
+
+// isSameSiteNone returns true if a SameSite value is None (http.SameSiteNoneMode, "None").
+func isSameSiteNone(expr ast.Expr) bool {
+	if expr == nil {
+		return false
+	}
+	if value := astutil.GetStringValue(expr); value != "" {
+		return strings.EqualFold(value, "none")
+	}
+	return strings.HasSuffix(astutil.ExprString(expr), "SameSiteNoneMode")
+}
+
+// isAuthCookieName returns true if a cookie name, or the constant or variable holding it, names a
+// session or authentication cookie. CSRF cookies are excluded: double-submit tokens must be readable.
+func isAuthCookieName(expr ast.Expr) bool {
+	if expr == nil {
+		return false
+	}
+	name := strings.ToLower(cookieName(expr))
+	if strings.Contains(name, "csrf") || strings.Contains(name, "xsrf") {
+		return false
+	}
+	for _, marker := range authCookieMarkers {
+		if strings.Contains(name, marker) {
+			return true
+		}
+	}
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '.' || r == '_' || r == '-' }) {
+		if part == "sid" {
+			return true
+		}
+	}
+	return false
+}
+
+// cookieName returns a cookie name literal, or the expression naming it.
+func cookieName(expr ast.Expr) string {
+	if value := astutil.GetStringValue(expr); value != "" {
+		return value
+	}
+	return astutil.ExprString(expr)
+}
@@ -0,0 +1,145 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// AP037SpoofableIPAllowlist flags IP allowlists whose client IP can be set by the client.
+type AP037SpoofableIPAllowlist struct{}
+
+// NewAP037SpoofableIPAllowlist creates a new AP037 rule.
+func NewAP037SpoofableIPAllowlist() *AP037SpoofableIPAllowlist {
+	return &AP037SpoofableIPAllowlist{}
+}
+
+// ID returns the rule ID.
+func (r *AP037SpoofableIPAllowlist) ID() string {
+	return "AP037"
+}
+
+// Name returns the rule name.
+func (r *AP037SpoofableIPAllowlist) Name() string {
+	return "Spoofable client IP in IP allowlist"
+}
+
+// Severity returns the rule severity.
+func (r *AP037SpoofableIPAllowlist) Severity() models.Severity {
+	return models.SeverityHigh
+}
+
+// Description returns the rule description.
+func (r *AP037SpoofableIPAllowlist) Description() string {
+	return "Endpoint is protected by an IP allowlist, but the client IP is taken from X-Forwarded-For without a " +
+		"trusted-proxy configuration (gin without SetTrustedProxies, echo without IPExtractor). Any client can claim an allowed IP."
+}
+
+// EvaluateProject checks how the IP allowlist middleware of each endpoint obtains the client IP,
+// together with the project's trusted-proxy configuration.
+func (r *AP037SpoofableIPAllowlist) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+	var trust *proxyTrust
+
+	for _, e := range result.Endpoints {
+		allowlist, ok := e.Metadata[models.MetadataIPAllowlist]
+		if !ok {
+			continue
+		}
+		if trust == nil {
+			trust = findProxyTrust(sources)
+		}
+
+		reason := spoofableClientIP(e, e.Metadata[models.MetadataClientIP], trust)
+		if reason == "" {
+			continue
+		}
+		findings = append(findings, createFinding(r, e,
+			fmt.Sprintf("Endpoint '%s' is restricted by IP allowlist '%s', but the client IP is spoofable: %s",
+				e.FullRoute(), allowlist, reason),
+			"Configure the trusted proxies (gin SetTrustedProxies, echo IPExtractor, fiber EnableTrustedProxyCheck), "+
+				"or use the connection address, and do not rely on an IP allowlist as the only authentication",
+		))
+	}
+
+	return findings
+}
+
+// proxyTrust is the trusted-proxy configuration of a project.
+type proxyTrust struct {
+	// ginConfigured is true if gin's trusted proxies or platform are set.
+	ginConfigured bool
+
+	// echoConfigured is true if echo's IPExtractor is set.
+	echoConfigured bool
+
+	// fiberUnchecked is true if a fiber.Config sets a ProxyHeader without EnableTrustedProxyCheck.
+	fiberUnchecked bool
+}
+
+// findProxyTrust collects the trusted-proxy configuration across the project.
+func findProxyTrust(sources *astutil.SourceSet) *proxyTrust {
+	trust := &proxyTrust{}
+	for _, source := range sources.Files {
+		fiber := source.GetImportAlias("github.com/gofiber/fiber/v2")
+		ast.Inspect(source.AST, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.CallExpr:
+				if sel, ok := node.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "SetTrustedProxies" {
+					trust.ginConfigured = true
+				}
+			case *ast.AssignStmt:
+				for _, lhs := range node.Lhs {
+					if sel, ok := lhs.(*ast.SelectorExpr); ok {
+						switch sel.Sel.Name {
+						case "TrustedPlatform", "ForwardedByClientIP":
+							trust.ginConfigured = true
+						case "IPExtractor":
+							trust.echoConfigured = true
+						}
+					}
+				}
+			case *ast.CompositeLit:
+				if alias, name := selectorParts(node.Type); fiber != "" && alias == fiber && name == "Config" {
+					header := fieldValue(node, "ProxyHeader")
+					checked := fieldValue(node, "EnableTrustedProxyCheck")
+					if header != nil && (checked == nil || !isTrueLiteral(checked)) {
+						trust.fiberUnchecked = true
+					}
+				}
+			}
+			return true
+		})
+	}
+	return trust
+}
+
+// spoofableClientIP returns why the client IP read by an IP allowlist can be set by the client,
+// or "" if it cannot or the way it is read is unknown.
+func spoofableClientIP(e *models.Endpoint, clientIP string, trust *proxyTrust) string {
+	switch {
+	case clientIP == "":
+		return ""
+	case !strings.Contains(clientIP, "."):
+		return fmt.Sprintf("it reads the client-supplied %s header", clientIP)
+	case strings.HasSuffix(clientIP, ".ClientIP") && !trust.ginConfigured:
+		return "gin trusts X-Forwarded-For from any proxy unless SetTrustedProxies is called"
+	case strings.HasSuffix(clientIP, ".RealIP") && !trust.echoConfigured:
+		return "echo's RealIP reads X-Forwarded-For and X-Real-IP unless an IPExtractor is configured"
+	case strings.HasSuffix(clientIP, ".IPs"):
+		return "fiber's IPs returns the client-supplied X-Forwarded-For list"
+	case strings.HasSuffix(clientIP, ".IP") && trust.fiberUnchecked:
+		return "fiber.Config sets ProxyHeader without EnableTrustedProxyCheck"
+	case strings.HasSuffix(clientIP, ".RemoteAddr"):
+		// chi's RealIP and gorilla's ProxyHeaders rewrite RemoteAddr from forwarded headers
+		for _, mw := range e.Middleware {
+			if strings.HasSuffix(mw, ".RealIP") || strings.HasSuffix(mw, ".ProxyHeaders") {
+				return fmt.Sprintf("%s rewrites RemoteAddr from X-Forwarded-For", mw)
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,119 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/discovery"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// accessDenials are the names of the methods and constants that reject a request.
+var accessDenials = map[string]bool{
+	"Abort":               true,
+	"AbortWithStatus":     true,
+	"AbortWithStatusJSON": true,
+	"AbortWithError":      true,
+	"StatusForbidden":     true,
+	"StatusUnauthorized":  true,
+	"ErrForbidden":        true,
+	"ErrUnauthorized":     true,
+}
+
+// AP038ForwardedIPAccessControl flags access decisions based on client IP headers read directly.
+type AP038ForwardedIPAccessControl struct{}
+
+// NewAP038ForwardedIPAccessControl creates a new AP038 rule.
+func NewAP038ForwardedIPAccessControl() *AP038ForwardedIPAccessControl {
+	return &AP038ForwardedIPAccessControl{}
+}
+
+// ID returns the rule ID.
+func (r *AP038ForwardedIPAccessControl) ID() string {
+	return "AP038"
+}
+
+// Name returns the rule name.
+func (r *AP038ForwardedIPAccessControl) Name() string {
+	return "Forwarded IP header used for access control"
+}
+
+// Severity returns the rule severity.
+func (r *AP038ForwardedIPAccessControl) Severity() models.Severity {
+	return models.SeverityHigh
+}
+
+// Description returns the rule description.
+func (r *AP038ForwardedIPAccessControl) Description() string {
+	return "Handler reads X-Forwarded-For or X-Real-IP and rejects or admits the request based on it. " +
+		"The client sets these headers, so it can claim any IP."
+}
+
+// EvaluateProject finds if statements whose condition uses a forwarded IP header and that reject
+// the request or pass it on. IP allowlist middleware is left to AP037, which reports it per endpoint.
+func (r *AP038ForwardedIPAccessControl) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+
+	allowlists := make(map[*ast.FuncDecl]bool)
+	for _, e := range result.Endpoints {
+		if mw, ok := e.Metadata[models.MetadataIPAllowlist]; ok {
+			if _, fn := sources.ResolveFunc(e.FilePath, mw); fn != nil {
+				allowlists[fn] = true
+			}
+		}
+	}
+
+	for _, source := range sources.Files {
+		for _, h := range findHandlerFuncs(source) {
+			if h.decl != nil && allowlists[h.decl] {
+				continue
+			}
+			taint := newTaint(h, isForwardedIPRead, nil, nil)
+
+			h.Inspect(func(n ast.Node) bool {
+				stmt, ok := n.(*ast.IfStmt)
+				if !ok || !taint.Tainted(stmt.Cond) || !decidesAccess(stmt) {
+					return true
+				}
+				finding := createProjectFinding(r, source.FilePath, astutil.GetLineNumber(source.FileSet, stmt),
+					fmt.Sprintf("Handler '%s' decides access on a client-supplied IP header: if %s", h.Name(), astutil.ExprString(stmt.Cond)),
+					"Use the connection address or the framework's client IP with trusted proxies configured, and authenticate the caller",
+				)
+				finding.RelatedEndpoints = h.CallerEndpoints(result.Endpoints, sources)
+				findings = append(findings, finding)
+				return true
+			})
+		}
+	}
+
+	return findings
+}
+
+// isForwardedIPRead returns true if a call reads a forwarded IP header.
+func isForwardedIPRead(call *ast.CallExpr) bool {
+	return discovery.ForwardedIPHeader(call) != ""
+}
+
+// decidesAccess returns true if a branch of an if statement rejects the request
+// (c.AbortWithStatus, http.StatusForbidden, a literal 401 or 403) or passes it on (c.Next, next.ServeHTTP).
+func decidesAccess(stmt *ast.IfStmt) bool {
+	found := false
+	inspect := func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.BasicLit:
+			found = found || (node.Kind == token.INT && (node.Value == "401" || node.Value == "403"))
+		case *ast.SelectorExpr:
+			found = found || accessDenials[node.Sel.Name] || node.Sel.Name == "Next" || node.Sel.Name == "ServeHTTP"
+		case *ast.Ident:
+			found = found || accessDenials[node.Name]
+		}
+		return !found
+	}
+	ast.Inspect(stmt.Body, inspect)
+	if stmt.Else != nil {
+		ast.Inspect(stmt.Else, inspect)
+	}
+	return found
+}
@@ -0,0 +1,251 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// secretWords are the identifier words naming secrets and credentials
+// (apiKey, cfg.Secret, expectedMAC, "X-Signature").
+var secretWords = map[string]bool{
+	"key":           true,
+	"keys":          true,
+	"authorization": true,
+	"secret":        true,
+	"token":         true,
+	"password":      true,
+	"passwd":        true,
+	"signature":     true,
+	"sig":           true,
+	"hmac":          true,
+	"mac":           true,
+	"digest":        true,
+	"hash":          true,
+	"expected":      true,
+}
+
+// variableTimeEquals are the comparison functions whose running time depends on the matching prefix.
+var variableTimeEquals = map[string]bool{
+	"bytes.Equal":       true,
+	"bytes.Compare":     true,
+	"strings.Compare":   true,
+	"strings.EqualFold": true,
+	"reflect.DeepEqual": true,
+}
+
+// AP039TimingUnsafeComparison flags auth middleware comparing request values to secrets in variable time.
+type AP039TimingUnsafeComparison struct {
+	authFunctions []string
+}
+
+// NewAP039TimingUnsafeComparison creates a new AP039 rule. authFunctions are additional functions
+// that check credentials, whose parameters are treated as request values.
+func NewAP039TimingUnsafeComparison(authFunctions []string) *AP039TimingUnsafeComparison {
+	return &AP039TimingUnsafeComparison{authFunctions: authFunctions}
+}
+
+// ID returns the rule ID.
+func (r *AP039TimingUnsafeComparison) ID() string {
+	return "AP039"
+}
+
+// Name returns the rule name.
+func (r *AP039TimingUnsafeComparison) Name() string {
+	return "Non-constant-time secret comparison"
+}
+
+// Severity returns the rule severity.
+func (r *AP039TimingUnsafeComparison) Severity() models.Severity {
+	return models.SeverityMedium
+}
+
+// Description returns the rule description.
+func (r *AP039TimingUnsafeComparison) Description() string {
+	return "Auth middleware compares a request header, query value or cookie with a secret using ==, bytes.Equal or " +
+		"strings.EqualFold. The comparison time leaks how much of the secret matches."
+}
+
+// EvaluateProject inspects the auth middleware of the endpoints and the configured auth functions.
+// Findings are located at the comparison and list the endpoints using the middleware.
+func (r *AP039TimingUnsafeComparison) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+
+	// Each middleware is inspected once, with the endpoints it protects
+	type authFunc struct {
+		source     *astutil.ParsedSource
+		decl       *ast.FuncDecl
+		configured bool
+		endpoints  []*models.Endpoint
+	}
+	var order []*ast.FuncDecl
+	funcs := make(map[*ast.FuncDecl]*authFunc)
+	add := func(source *astutil.ParsedSource, decl *ast.FuncDecl, configured bool) *authFunc {
+		if f, ok := funcs[decl]; ok {
+			f.configured = f.configured || configured
+			return f
+		}
+		f := &authFunc{source: source, decl: decl, configured: configured}
+		funcs[decl] = f
+		order = append(order, decl)
+		return f
+	}
+
+	for _, e := range result.Endpoints {
+		for _, dep := range e.Authorization.AuthDependencies {
+			if source, fn := sources.ResolveFunc(e.FilePath, dep); fn != nil && fn.Body != nil {
+				f := add(source, fn, false)
+				f.endpoints = append(f.endpoints, e)
+			}
+		}
+	}
+	for _, source := range sources.Files {
+		for _, fn := range astutil.FindFuncDecls(source.AST) {
+			if fn.Body != nil && r.isConfigured(fn) {
+				add(source, fn, true)
+			}
+		}
+	}
+
+	for _, decl := range order {
+		f := funcs[decl]
+		for _, cmp := range timingUnsafeComparisons(f.source, f.decl, f.configured) {
+			finding := createProjectFinding(r, f.source.FilePath, astutil.GetLineNumber(f.source.FileSet, cmp.node),
+				fmt.Sprintf("Auth function '%s' compares a request value with a secret using %s: %s", f.decl.Name.Name, cmp.operator, cmp.text),
+				"Compare secrets with subtle.ConstantTimeCompare, or hmac.Equal for MACs",
+			)
+			finding.RelatedEndpoints = f.endpoints
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
+
+// isConfigured returns true if a function is one of the configured auth functions,
+// matched by name ("CheckAPIKey") or qualified name ("auth.CheckAPIKey").
+func (r *AP039TimingUnsafeComparison) isConfigured(fn *ast.FuncDecl) bool {
+	for _, name := range r.authFunctions {
+		if name[strings.LastIndex(name, ".")+1:] == fn.Name.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// secretComparison is a variable-time comparison of a request value with a secret.
+type secretComparison struct {
+	node     ast.Node
+	operator string
+	text     string
+}
+
+// timingUnsafeComparisons finds the comparisons in a function, including the closures it returns,
+// between request values and secrets that do not run in constant time. The parameters of
+// configured auth functions are request values.
+func timingUnsafeComparisons(source *astutil.ParsedSource, decl *ast.FuncDecl, configured bool) []secretComparison {
+	h := &handlerFunc{source: source, body: decl.Body, decl: decl}
+	taint := newTaint(h, isCredentialInputCall, nil, nil)
+	if configured {
+		for _, field := range decl.Type.Params.List {
+			for _, name := range field.Names {
+				taint.vars[name.Name] = true
+			}
+		}
+	}
+
+	// One operand holds the request value, the other a secret: a value named as one, or a
+	// literal compared with a request value named as a credential (c.GetHeader("X-API-Key") == "k3y")
+	compares := func(a, b ast.Expr) bool {
+		for _, pair := range [2][2]ast.Expr{{a, b}, {b, a}} {
+			input, other := pair[0], pair[1]
+			if !taint.Tainted(input) || taint.Tainted(other) || isNil(other) {
+				continue
+			}
+			if lit, ok := other.(*ast.BasicLit); ok {
+				if lit.Kind == token.STRING && astutil.GetStringValue(lit) != "" && namesSecret(input) {
+					return true
+				}
+			} else if namesSecret(other) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var found []secretComparison
+	h.Inspect(func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.BinaryExpr:
+			if (node.Op == token.EQL || node.Op == token.NEQ) && compares(node.X, node.Y) {
+				found = append(found, secretComparison{node, node.Op.String(), astutil.ExprString(node)})
+			}
+		case *ast.CallExpr:
+			name := astutil.GetCallName(node)
+			if variableTimeEquals[name] && len(node.Args) == 2 && compares(node.Args[0], node.Args[1]) {
+				found = append(found, secretComparison{node, name, astutil.ExprString(node)})
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// isCredentialInputCall returns true if a call reads request input that may carry a credential:
+// request input accessors, header lookups (r.Header.Get, c.Get("X-API-Key")) and cookies.
+func isCredentialInputCall(call *ast.CallExpr) bool {
+	if isRequestInputCall(call) {
+		return true
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	switch sel.Sel.Name {
+	case "Cookie", "Cookies", "BasicAuth":
+		return true
+	case "Get", "Values":
+		// r.Header.Get(...) reads a header; fiber's c.Get reads one when given a header name
+		if strings.HasSuffix(astutil.ExprString(sel.X), "Header") {
+			return true
+		}
+		if len(call.Args) == 1 {
+			header := astutil.GetStringValue(call.Args[0])
+			return header == "Authorization" || strings.HasPrefix(header, "X-")
+		}
+	}
+	return false
+}
+
+// namesSecret returns true if an expression names a secret through its identifiers or string
+// literals (cfg.APIKey, expectedMAC, os.Getenv("API_TOKEN"), c.GetHeader("X-API-Key")).
+func namesSecret(expr ast.Expr) bool {
+	secret := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		var name string
+		switch node := n.(type) {
+		case *ast.Ident:
+			name = node.Name
+		case *ast.SelectorExpr:
+			name = node.Sel.Name
+		case *ast.BasicLit:
+			name = strings.ReplaceAll(astutil.GetStringValue(node), "-", "_")
+		case *ast.CallExpr:
+			// len(key) and other calls on the secret do not compare its content
+			if ident, ok := node.Fun.(*ast.Ident); ok && ident.Name == "len" {
+				return false
+			}
+		default:
+			return true
+		}
+		for _, word := range astutil.SplitWords(name) {
+			secret = secret || secretWords[word]
+		}
+		return !secret
+	})
+	return secret
+}
@@ -0,0 +1,63 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// AP040UnsignedWebhook flags webhook endpoints that do not verify the sender's signature.
+type AP040UnsignedWebhook struct{}
+
+// NewAP040UnsignedWebhook creates a new AP040 rule.
+func NewAP040UnsignedWebhook() *AP040UnsignedWebhook {
+	return &AP040UnsignedWebhook{}
+}
+
+// ID returns the rule ID.
+func (r *AP040UnsignedWebhook) ID() string {
+	return "AP040"
+}
+
+// Name returns the rule name.
+func (r *AP040UnsignedWebhook) Name() string {
+	return "Unsigned webhook"
+}
+
+// Severity returns the rule severity.
+func (r *AP040UnsignedWebhook) Severity() models.Severity {
+	return models.SeverityHigh
+}
+
+// Description returns the rule description.
+func (r *AP040UnsignedWebhook) Description() string {
+	return "Webhook endpoint is public and its handler does not verify a signature (hmac.Equal, stripe webhook.ConstructEvent, " +
+		"github.ValidatePayload). Anyone who finds the URL can send forged events."
+}
+
+// Evaluate checks if a webhook endpoint is neither signed nor authenticated.
+func (r *AP040UnsignedWebhook) Evaluate(endpoint *models.Endpoint) []*models.Finding {
+	if !isUnsignedWebhook(endpoint) {
+		return nil
+	}
+
+	return []*models.Finding{
+		createFinding(r, endpoint,
+			fmt.Sprintf("Webhook endpoint '%s' [%s] does not verify the request signature", endpoint.FullRoute(), endpoint.DisplayMethods()),
+			"Verify the provider's signature over the raw body (hmac.New with hmac.Equal, or the provider SDK) before processing the event",
+		),
+	}
+}
+
+// isUnsignedWebhook returns true for a webhook endpoint without signature verification or other
+// authentication. These are reported by AP040 rather than the generic missing-authentication rules.
+func isUnsignedWebhook(e *models.Endpoint) bool {
+	if e.Metadata[models.MetadataWebhook] != "true" {
+		return false
+	}
+	if _, ok := e.Metadata[models.MetadataWebhookVerifier]; ok {
+		return false
+	}
+	auth := &e.Authorization
+	return !auth.RequiresAuth && len(auth.AuthDependencies) == 0 && !auth.HasSpecificRequirements()
+}
@@ -0,0 +1,243 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"path/filepath"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// responseWriters are the response methods whose arguments are sent to the client
+// (c.JSON(500, gin.H{"error": msg}), c.String(500, "%v", err), c.SendString(msg)).
+var responseWriters = map[string]bool{
+	"JSON":                true,
+	"IndentedJSON":        true,
+	"PureJSON":            true,
+	"SecureJSON":          true,
+	"JSONP":               true,
+	"AbortWithStatusJSON": true,
+	"XML":                 true,
+	"YAML":                true,
+	"HTML":                true,
+	"String":              true,
+	"SendString":          true,
+	"Send":                true,
+}
+
+// errorResponses are the constructors of errors whose message the framework sends to the client
+// (fiber.NewError(500, msg), echo.NewHTTPError(500, msg)).
+var errorResponses = map[string]bool{
+	"NewError":     true,
+	"NewHTTPError": true,
+}
+
+// errorFormatters are the calls that turn their arguments, including errors, into text.
+var errorFormatters = map[string]bool{
+	"fmt.Sprintf":  true,
+	"fmt.Sprint":   true,
+	"fmt.Sprintln": true,
+	"fmt.Errorf":   true,
+	"fmt.Fprintf":  true,
+	"fmt.Fprint":   true,
+	"fmt.Fprintln": true,
+}
+
+// AP041ErrorDetailLeak flags handlers and error handlers that send internal error details to clients.
+type AP041ErrorDetailLeak struct{}
+
+// NewAP041ErrorDetailLeak creates a new AP041 rule.
+func NewAP041ErrorDetailLeak() *AP041ErrorDetailLeak {
+	return &AP041ErrorDetailLeak{}
+}
+
+// ID returns the rule ID.
+func (r *AP041ErrorDetailLeak) ID() string {
+	return "AP041"
+}
+
+// Name returns the rule name.
+func (r *AP041ErrorDetailLeak) Name() string {
+	return "Error details in response"
+}
+
+// Severity returns the rule severity.
+func (r *AP041ErrorDetailLeak) Severity() models.Severity {
+	return models.SeverityMedium
+}
+
+// Description returns the rule description.
+func (r *AP041ErrorDetailLeak) Description() string {
+	return "Handler sends err.Error(), a formatted error or a recovered panic value to the client, or a custom error " +
+		"handler returns raw errors. Internal errors reveal SQL, file paths, hosts and library versions."
+}
+
+// EvaluateProject checks the responses of each handler, and the echo HTTPErrorHandler and fiber
+// ErrorHandler functions, for error details. Handler findings are reported against the endpoints
+// the handler serves, with high severity on public endpoints. Debug mode is reported by AP020.
+func (r *AP041ErrorDetailLeak) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+	recommendation := "Log the error on the server and return a generic message or error code to the client"
+
+	for _, source := range sources.Files {
+		for _, h := range findHandlerFuncs(source) {
+			leaks := errorLeaks(h)
+			if len(leaks) == 0 {
+				continue
+			}
+			endpoints := h.CallerEndpoints(result.Endpoints, sources)
+			for _, leak := range leaks {
+				for _, e := range endpoints {
+					finding := createFinding(r, e,
+						fmt.Sprintf("Handler '%s' sends error details to the client: %s", h.Name(), astutil.ExprString(leak)),
+						recommendation,
+					)
+					if e.Classification == models.ClassificationPublic {
+						finding.Severity = models.SeverityHigh
+					}
+					finding.Snippet = redactedSnippet(source, leak, nil)
+					findings = append(findings, finding)
+				}
+			}
+		}
+
+		for _, handler := range findErrorHandlers(source, sources) {
+			for _, leak := range errorLeaks(handler.fn) {
+				finding := createProjectFinding(r, handler.fn.source.FilePath, astutil.GetLineNumber(handler.fn.source.FileSet, leak),
+					fmt.Sprintf("%s returns raw error details to clients: %s", handler.name, astutil.ExprString(leak)),
+					recommendation,
+				)
+				finding.Snippet = redactedSnippet(handler.fn.source, leak, nil)
+				for _, e := range result.Endpoints {
+					if filepath.Dir(e.FilePath) != filepath.Dir(source.FilePath) {
+						continue
+					}
+					finding.RelatedEndpoints = append(finding.RelatedEndpoints, e)
+					if e.Classification == models.ClassificationPublic {
+						finding.Severity = models.SeverityHigh
+					}
+				}
+				findings = append(findings, finding)
+			}
+		}
+	}
+
+	return findings
+}
+
+// errorLeaks returns the calls of a handler that send an error message or panic value to the client.
+func errorLeaks(h *handlerFunc) []*ast.CallExpr {
+	taint := newTaint(h, isErrorDetailCall, nil, nil)
+	httpAlias := h.source.GetImportAlias("net/http")
+
+	var leaks []*ast.CallExpr
+	h.Inspect(func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || !isClientResponse(call, httpAlias) {
+			return true
+		}
+		for _, arg := range call.Args {
+			if taint.Tainted(arg) || (isErrorValue(arg) && formatsArgs(call)) {
+				leaks = append(leaks, call)
+				break
+			}
+		}
+		return true
+	})
+	return leaks
+}
+
+// isClientResponse returns true if a call sends its arguments to the client: a response method,
+// http.Error, an error constructor the framework renders, or fmt.Fprint to the ResponseWriter.
+func isClientResponse(call *ast.CallExpr, httpAlias string) bool {
+	name := astutil.GetCallName(call)
+	if httpAlias != "" && name == httpAlias+".Error" {
+		return true
+	}
+	if strings.HasPrefix(name, "fmt.Fprint") && len(call.Args) > 0 {
+		writer := astutil.ExprString(call.Args[0])
+		return writer == "w" || writer == "rw" || writer == "c.Writer" || strings.HasSuffix(writer, "Response()")
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && (responseWriters[sel.Sel.Name] || errorResponses[sel.Sel.Name])
+}
+
+// formatsArgs returns true if a response call formats its trailing arguments into the body
+// (c.String(500, "failed: %v", err), fmt.Fprintf(w, "%v", err)), so a plain error value is printed.
+func formatsArgs(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && (sel.Sel.Name == "String" || strings.HasPrefix(sel.Sel.Name, "Fprint"))
+}
+
+// isErrorDetailCall returns true if a call produces an error message or panic value:
+// err.Error(), recover(), or formatting an error (fmt.Sprintf("%v", err)).
+func isErrorDetailCall(call *ast.CallExpr) bool {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		return fun.Name == "recover" && len(call.Args) == 0
+	case *ast.SelectorExpr:
+		if fun.Sel.Name == "Error" && len(call.Args) == 0 {
+			return true
+		}
+	}
+	if !errorFormatters[astutil.GetCallName(call)] {
+		return false
+	}
+	for _, arg := range call.Args {
+		if isErrorValue(arg) {
+			return true
+		}
+	}
+	return false
+}
+
+// isErrorValue returns true if an expression is an error variable by name (err, dbErr, bindErr).
+func isErrorValue(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && (ident.Name == "err" || strings.HasSuffix(ident.Name, "Err"))
+}
+
+// errorHandler is a custom framework error handler.
+type errorHandler struct {
+	name string
+	fn   *handlerFunc
+}
+
+// findErrorHandlers finds the custom error handlers configured in a file:
+// e.HTTPErrorHandler = ... (echo) and fiber.Config{ErrorHandler: ...}.
+func findErrorHandlers(source *astutil.ParsedSource, sources *astutil.SourceSet) []errorHandler {
+	var handlers []errorHandler
+	add := func(name string, value ast.Expr) {
+		switch fn := value.(type) {
+		case *ast.FuncLit:
+			handlers = append(handlers, errorHandler{name, &handlerFunc{source: source, body: fn.Body}})
+		case *ast.Ident, *ast.SelectorExpr:
+			if fnSource, decl := sources.ResolveFunc(source.FilePath, astutil.ExprString(fn)); decl != nil && decl.Body != nil {
+				handlers = append(handlers, errorHandler{name, &handlerFunc{source: fnSource, body: decl.Body, decl: decl}})
+			}
+		}
+	}
+
+	echoAlias := source.GetImportAlias("github.com/labstack/echo/v4")
+	fiberAlias := source.GetImportAlias("github.com/gofiber/fiber/v2")
+	ast.Inspect(source.AST, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				if sel, ok := lhs.(*ast.SelectorExpr); ok && echoAlias != "" && sel.Sel.Name == "HTTPErrorHandler" && i < len(node.Rhs) {
+					add("Echo HTTPErrorHandler", node.Rhs[i])
+				}
+			}
+		case *ast.CompositeLit:
+			if alias, name := selectorParts(node.Type); fiberAlias != "" && alias == fiberAlias && name == "Config" {
+				if value := fieldValue(node, "ErrorHandler"); value != nil {
+					add("Fiber ErrorHandler", value)
+				}
+			}
+		}
+		return true
+	})
+	return handlers
+}
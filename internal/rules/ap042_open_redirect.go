@@ -0,0 +1,330 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// redirectValidationMarkers are lowercase fragments of the names of functions that validate
+// a redirect target (isSafeRedirect, validateReturnURL, allowedHost).
+var redirectValidationMarkers = []string{"safe", "valid", "allow", "trust", "whitelist", "sanitize"}
+
+// oauthRedirectSegments are the route segments of OAuth flows, where an open redirect leaks
+// authorization codes and tokens to the attacker's site.
+var oauthRedirectSegments = map[string]bool{"callback": true, "authorize": true, "oauth": true}
+
+// AP042OpenRedirect flags redirects to targets taken from the request without validation.
+type AP042OpenRedirect struct{}
+
+// NewAP042OpenRedirect creates a new AP042 rule.
+func NewAP042OpenRedirect() *AP042OpenRedirect {
+	return &AP042OpenRedirect{}
+}
+
+// ID returns the rule ID.
+func (r *AP042OpenRedirect) ID() string {
+	return "AP042"
+}
+
+// Name returns the rule name.
+func (r *AP042OpenRedirect) Name() string {
+	return "Open redirect"
+}
+
+// Severity returns the rule severity.
+func (r *AP042OpenRedirect) Severity() models.Severity {
+	return models.SeverityMedium
+}
+
+// Description returns the rule description.
+func (r *AP042OpenRedirect) Description() string {
+	return "Handler redirects to a URL taken from a query parameter, form value or header without checking it against " +
+		"a fixed host or allow-list. Attackers use the trusted domain to send users, and OAuth codes, to their own site."
+}
+
+// EvaluateProject checks the redirect targets of each handler for unvalidated request input.
+// Findings are reported against the endpoints the handler serves, with high severity on OAuth
+// callback and authorize routes.
+func (r *AP042OpenRedirect) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+	recommendation := "Only redirect to relative paths or to hosts on an allow-list, and reject targets with another scheme or host"
+
+	for _, source := range sources.Files {
+		for _, h := range findHandlerFuncs(source) {
+			targets := openRedirects(h)
+			if len(targets) == 0 {
+				continue
+			}
+			endpoints := h.CallerEndpoints(result.Endpoints, sources)
+			for _, call := range targets {
+				message := fmt.Sprintf("Handler '%s' redirects to a request-controlled URL: %s", h.Name(), astutil.ExprString(call))
+				if len(endpoints) == 0 {
+					finding := createProjectFinding(r, source.FilePath, astutil.GetLineNumber(source.FileSet, call), message, recommendation)
+					finding.Snippet = redactedSnippet(source, call, nil)
+					findings = append(findings, finding)
+				}
+				for _, e := range endpoints {
+					finding := createFinding(r, e, message, recommendation)
+					if isOAuthRedirectRoute(e.FullRoute()) {
+						finding.Severity = models.SeverityHigh
+					}
+					finding.Snippet = redactedSnippet(source, call, nil)
+					findings = append(findings, finding)
+				}
+			}
+		}
+	}
+
+	return findings
+}
+
+// openRedirects returns the redirects of a handler whose target is request input that the handler
+// does not validate. A validation helper, or a condition deciding whether the redirect runs that
+// compares the target's host with a fixed host, looks it up in an allow-list, or restricts it to
+// relative paths (starting with "/", but neither with "//" nor with a backslash), counts as validation.
+func openRedirects(h *handlerFunc) []*ast.CallExpr {
+	sanitizers := make(map[string]bool)
+	for _, call := range astutil.FindCallExprs(h.body) {
+		name := astutil.GetCallName(call)
+		if isRedirectValidator(name) {
+			sanitizers[name] = true
+		}
+	}
+	taint := newTaint(h, isRedirectSourceCall, nil, sanitizers)
+
+	httpAlias := h.source.GetImportAlias("net/http")
+	var found []*ast.CallExpr
+	h.Inspect(func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if target := redirectTarget(call, httpAlias); target != nil && taint.Tainted(target) && !validatesRedirect(h, taint, call, target) {
+			found = append(found, call)
+		}
+		return true
+	})
+	return found
+}
+
+// redirectTarget returns the target URL argument of a redirect: http.Redirect(w, r, url, code),
+// gin and echo c.Redirect(code, url), fiber c.Redirect(url), or w.Header().Set("Location", url).
+func redirectTarget(call *ast.CallExpr, httpAlias string) ast.Expr {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	switch {
+	case httpAlias != "" && astutil.GetCallName(call) == httpAlias+".Redirect" && len(call.Args) == 4:
+		return call.Args[2]
+	case sel.Sel.Name == "Redirect" && len(call.Args) >= 2 && isStatusCode(call.Args[0]):
+		return call.Args[1]
+	case sel.Sel.Name == "Redirect" && len(call.Args) >= 1:
+		return call.Args[0]
+	case (sel.Sel.Name == "Set" || sel.Sel.Name == "Add") && len(call.Args) == 2 && strings.EqualFold(astutil.GetStringValue(call.Args[0]), "Location"):
+		return call.Args[1]
+	}
+	return nil
+}
+
+// isStatusCode returns true if an expression is an HTTP status code (302, http.StatusFound).
+func isStatusCode(expr ast.Expr) bool {
+	if lit, ok := expr.(*ast.BasicLit); ok {
+		return lit.Kind == token.INT
+	}
+	_, name := selectorParts(expr)
+	return strings.HasPrefix(name, "Status")
+}
+
+// isRedirectSourceCall returns true if a call reads request input that may carry a redirect
+// target: query and form values, headers, cookies and the Referer.
+func isRedirectSourceCall(call *ast.CallExpr) bool {
+	if isCredentialInputCall(call) {
+		return true
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "Referer" && len(call.Args) == 0
+}
+
+// isRedirectValidator returns true if a function name suggests it validates a redirect target.
+func isRedirectValidator(name string) bool {
+	lower := strings.ToLower(name[strings.LastIndex(name, ".")+1:])
+	for _, marker := range redirectValidationMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// validatesRedirect returns true if the conditions deciding whether a redirect runs validate its
+// target. An if statement replacing the target with a fixed value (if !ok { next = "/" }) decides
+// the target like one that returns.
+func validatesRedirect(h *handlerFunc, taint *requestTaint, call *ast.CallExpr, target ast.Expr) bool {
+	resets := func(body *ast.BlockStmt) bool {
+		if len(body.List) == 0 {
+			return false
+		}
+		assign, ok := body.List[len(body.List)-1].(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != len(assign.Rhs) {
+			return false
+		}
+		for i, lhs := range assign.Lhs {
+			if !refersTo(target, lhs) || taint.Tainted(assign.Rhs[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	guards := h.GuardsWith(call, resets)
+	implied := func(match func(g guard, test ast.Expr, holds bool) bool) bool {
+		for _, g := range guards {
+			if g.Implies(func(test ast.Expr, holds bool) bool { return match(g, test, holds) }) {
+				return true
+			}
+		}
+		return false
+	}
+
+	validated := implied(func(g guard, test ast.Expr, holds bool) bool {
+		switch {
+		case holds && isRedirectValidatorCall(test):
+			return taint.Tainted(test)
+		case holds && isAllowListLookup(test, g.init, taint):
+			return true
+		}
+		return isHostEquality(test, holds, taint)
+	})
+	if validated {
+		return true
+	}
+
+	// A relative path check counts once targets starting with "//" or containing a backslash
+	// (/\evil.com, which browsers follow to evil.com) are rejected as well
+	relative := implied(func(_ guard, test ast.Expr, holds bool) bool {
+		fn, value := stringCheck(test)
+		return holds && fn == "HasPrefix" && value == "/" && taint.Tainted(test)
+	})
+	doubleSlash := implied(func(_ guard, test ast.Expr, holds bool) bool {
+		_, value := stringCheck(test)
+		return !holds && value == "//" && taint.Tainted(test)
+	})
+	backslash := implied(func(_ guard, test ast.Expr, holds bool) bool {
+		fn, value := stringCheck(test)
+		return !holds && ((fn == "HasPrefix" && value == `/\`) || (fn == "Contains" && value == `\`)) && taint.Tainted(test)
+	})
+	return relative && doubleSlash && backslash
+}
+
+// isRedirectValidatorCall returns true if a test calls a redirect validation helper (isSafeRedirect(next)).
+func isRedirectValidatorCall(test ast.Expr) bool {
+	call, ok := test.(*ast.CallExpr)
+	return ok && isRedirectValidator(astutil.GetCallName(call))
+}
+
+// isHostEquality returns true if a test with the given value means the host of a tainted URL equals
+// a fixed, non-empty host (u.Host == "app.example.com", u.Hostname() != cfg.Host when false).
+func isHostEquality(test ast.Expr, holds bool, taint *requestTaint) bool {
+	bin, ok := test.(*ast.BinaryExpr)
+	if !ok || !((bin.Op == token.EQL && holds) || (bin.Op == token.NEQ && !holds)) {
+		return false
+	}
+	isHost := func(expr ast.Expr) bool {
+		if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 0 {
+			expr = call.Fun
+		}
+		sel, ok := expr.(*ast.SelectorExpr)
+		return ok && (sel.Sel.Name == "Host" || sel.Sel.Name == "Hostname") && taint.Tainted(sel.X)
+	}
+	isFixed := func(expr ast.Expr) bool {
+		if lit, ok := expr.(*ast.BasicLit); ok {
+			return astutil.GetStringValue(lit) != ""
+		}
+		return !taint.Tainted(expr)
+	}
+	return (isHost(bin.X) && isFixed(bin.Y)) || (isHost(bin.Y) && isFixed(bin.X))
+}
+
+// isAllowListLookup returns true if a test looks a tainted value up in an allow-list: a map index
+// (allowed[next], or ok from _, ok := allowed[next]), or a Contains call (slices.Contains(allowed, host)).
+func isAllowListLookup(test ast.Expr, init ast.Stmt, taint *requestTaint) bool {
+	switch node := test.(type) {
+	case *ast.IndexExpr:
+		return taint.Tainted(node.Index)
+	case *ast.Ident:
+		return isInitLookup(init, node, taint)
+	case *ast.CallExpr:
+		name := astutil.GetCallName(node)
+		if !strings.HasSuffix(name, ".Contains") || name == "strings.Contains" {
+			return false
+		}
+		for _, arg := range node.Args {
+			if taint.Tainted(arg) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isInitLookup returns true if a test is the ok result of a map lookup of a tainted value in an
+// if statement's init (if _, ok := allowed[next]; ok).
+func isInitLookup(init ast.Stmt, test ast.Expr, taint *requestTaint) bool {
+	ident, ok := test.(*ast.Ident)
+	assign, isAssign := init.(*ast.AssignStmt)
+	if !ok || !isAssign || len(assign.Lhs) != 2 || len(assign.Rhs) != 1 {
+		return false
+	}
+	index, ok := assign.Rhs[0].(*ast.IndexExpr)
+	return ok && astutil.ExprString(assign.Lhs[1]) == ident.Name && taint.Tainted(index.Index)
+}
+
+// stringCheck returns the strings function ("HasPrefix" or "Contains") and the literal a test
+// checks a value with, or "" and "".
+func stringCheck(test ast.Expr) (string, string) {
+	call, ok := test.(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 {
+		return "", ""
+	}
+	name := astutil.GetCallName(call)
+	fn := name[strings.LastIndex(name, ".")+1:]
+	if name != "strings."+fn || (fn != "HasPrefix" && fn != "Contains") {
+		return "", ""
+	}
+	if lit, ok := call.Args[1].(*ast.BasicLit); ok {
+		return fn, astutil.GetStringValue(lit)
+	}
+	return "", ""
+}
+
+// refersTo returns true if an expression uses the variable named by ident.
+func refersTo(expr, ident ast.Expr) bool {
+	name, ok := ident.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && id.Name == name.Name {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// isOAuthRedirectRoute returns true if a route is part of an OAuth flow (/oauth/callback, /authorize).
+func isOAuthRedirectRoute(route string) bool {
+	for _, seg := range routeKeywordSegments(route) {
+		if oauthRedirectSegments[seg] {
+			return true
+		}
+	}
+	return false
+}
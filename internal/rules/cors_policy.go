@@ -0,0 +1,439 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// corsLibrary describes how a CORS middleware package is configured.
+type corsLibrary struct {
+	importPath string
+
+	// configType is the configuration struct passed to the middleware constructor.
+	configType string
+
+	// wildcardCalls construct a middleware that allows any origin without a configuration.
+	wildcardCalls []string
+
+	// emptyOriginsAllowAll is true if a configuration without origins allows any origin.
+	emptyOriginsAllowAll bool
+}
+
+// corsLibraries lists the supported CORS middleware packages.
+var corsLibraries = []corsLibrary{
+	{importPath: "github.com/gin-contrib/cors", configType: "Config", wildcardCalls: []string{"Default"}},
+	{importPath: "github.com/labstack/echo/v4/middleware", configType: "CORSConfig", wildcardCalls: []string{"CORS"}, emptyOriginsAllowAll: true},
+	{importPath: "github.com/gofiber/fiber/v2/middleware/cors", configType: "Config", wildcardCalls: []string{"New"}, emptyOriginsAllowAll: true},
+	{importPath: "github.com/go-chi/cors", configType: "Options", wildcardCalls: []string{"AllowAll"}, emptyOriginsAllowAll: true},
+	{importPath: "github.com/rs/cors", configType: "Options", wildcardCalls: []string{"AllowAll", "Default"}, emptyOriginsAllowAll: true},
+}
+
+// corsOriginFuncFields are the configuration fields holding an origin validation callback.
+var corsOriginFuncFields = map[string]bool{
+	"AllowOriginFunc":            true,
+	"AllowOriginsFunc":           true,
+	"AllowOriginWithContextFunc": true,
+	"AllowOriginRequestFunc":     true,
+	"AllowOriginVaryRequestFunc": true,
+}
+
+// corsPolicy is a CORS middleware configuration found in the source.
+type corsPolicy struct {
+	FilePath   string
+	LineNumber int
+
+	// Middleware is the call the configuration is passed to (e.g., "cors.New"),
+	// as it appears in the middleware chain of the covered endpoints.
+	Middleware string
+
+	AllowCredentials bool
+
+	// ReflectsAnyOrigin is true if the origin callback accepts every origin.
+	ReflectsAnyOrigin bool
+
+	library        corsLibrary
+	wildcardOrigin bool
+	originsSet     bool
+	originFuncOK   bool
+
+	// routers tracks the router variables of the policy's package; router is the router
+	// the middleware is applied to with Use() or wraps as a handler, and routeLine the line
+	// of a route registration it is passed to directly.
+	routers   *routerTree
+	router    types.Object
+	routeLine int
+
+	// constructor is the middleware call the configuration is passed to.
+	constructor *ast.CallExpr
+}
+
+// Location returns a short display string for the policy location.
+func (p *corsPolicy) Location() string {
+	return fmt.Sprintf("%s:%d", filepath.Base(p.FilePath), p.LineNumber)
+}
+
+// AllowsAnyOrigin returns true if the policy accepts requests from any origin.
+func (p *corsPolicy) AllowsAnyOrigin() bool {
+	if p.wildcardOrigin {
+		return true
+	}
+	return !p.originsSet && !p.originFuncOK && p.library.emptyOriginsAllowAll
+}
+
+// coveredEndpoints returns the endpoints the policy's middleware is applied to: the route it is
+// passed to, or the routes registered on the router it is applied to with Use() or wraps
+// (e.g., rs/cors Handler(mux)). When the router cannot be resolved, endpoints in the same file
+// whose middleware chain contains it are used.
+func (p *corsPolicy) coveredEndpoints(endpoints []*models.Endpoint) []*models.Endpoint {
+	switch {
+	case p.routeLine > 0:
+		var covered []*models.Endpoint
+		for _, e := range endpoints {
+			if e.FilePath == p.FilePath && e.LineNumber == p.routeLine {
+				covered = append(covered, e)
+			}
+		}
+		return covered
+	case p.router != nil:
+		return p.routers.RegisteredOn(endpoints, p.router, filepath.Dir(p.FilePath))
+	default:
+		return endpointsUsingMiddleware(endpoints, p.FilePath, p.Middleware)
+	}
+}
+
+// findCORSPolicies finds all CORS middleware configurations in the scanned sources.
+func findCORSPolicies(sources *astutil.SourceSet) []*corsPolicy {
+	var policies []*corsPolicy
+	trees := make(map[string]*routerTree)
+	for _, source := range sources.Files {
+		dir := filepath.Dir(source.FilePath)
+		filePolicies := findFileCORSPolicies(source, sources)
+		if len(filePolicies) > 0 && trees[dir] == nil {
+			trees[dir] = newRouterTree(sources, source.FilePath)
+		}
+		for _, policy := range filePolicies {
+			policy.bind(source, trees[dir])
+		}
+		policies = append(policies, filePolicies...)
+	}
+	return policies
+}
+
+// findFileCORSPolicies finds the CORS configurations of a single file. Configurations can be
+// composite literals, zero-argument constructors such as cors.Default(), or variables whose
+// fields are assigned afterwards (config := cors.DefaultConfig(); config.AllowAllOrigins = true).
+func findFileCORSPolicies(source *astutil.ParsedSource, sources *astutil.SourceSet) []*corsPolicy {
+	libraries := make(map[string]corsLibrary)
+	for _, lib := range corsLibraries {
+		if alias := source.GetImportAlias(lib.importPath); alias != "" {
+			libraries[alias] = lib
+		}
+	}
+	if len(libraries) == 0 {
+		return nil
+	}
+
+	var policies []*corsPolicy
+	byNode := make(map[ast.Node]*corsPolicy)
+	byVar := make(map[string]*corsPolicy)
+
+	newPolicy := func(node ast.Node, lib corsLibrary) *corsPolicy {
+		policy := &corsPolicy{
+			FilePath:   source.FilePath,
+			LineNumber: astutil.GetLineNumber(source.FileSet, node),
+			library:    lib,
+		}
+		policies = append(policies, policy)
+		byNode[node] = policy
+		return policy
+	}
+
+	// First pass: configuration literals and constructors
+	ast.Inspect(source.AST, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CompositeLit:
+			alias, name := selectorParts(node.Type)
+			lib, ok := libraries[alias]
+			if !ok || name != lib.configType {
+				return true
+			}
+			policy := newPolicy(node, lib)
+			for _, elt := range node.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					if key, ok := kv.Key.(*ast.Ident); ok {
+						policy.applyField(key.Name, kv.Value, source, sources)
+					}
+				}
+			}
+		case *ast.CallExpr:
+			alias, name := selectorParts(node.Fun)
+			lib, ok := libraries[alias]
+			if !ok {
+				return true
+			}
+			if name == "DefaultConfig" {
+				newPolicy(node, lib)
+				return true
+			}
+			if len(node.Args) == 0 && containsString(lib.wildcardCalls, name) {
+				policy := newPolicy(node, lib)
+				policy.wildcardOrigin = true
+				policy.Middleware = alias + "." + name
+				policy.constructor = node
+			}
+		}
+		return true
+	})
+
+	// Second pass: variables holding a configuration and their field assignments
+	ast.Inspect(source.AST, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				if i >= len(node.Rhs) {
+					break
+				}
+				switch target := lhs.(type) {
+				case *ast.Ident:
+					if policy := byNode[unwrapAddr(node.Rhs[i])]; policy != nil {
+						byVar[target.Name] = policy
+					}
+				case *ast.SelectorExpr:
+					if ident, ok := target.X.(*ast.Ident); ok {
+						if policy := byVar[ident.Name]; policy != nil {
+							policy.applyField(target.Sel.Name, node.Rhs[i], source, sources)
+						}
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			for i, name := range node.Names {
+				if i < len(node.Values) {
+					if policy := byNode[unwrapAddr(node.Values[i])]; policy != nil {
+						byVar[name.Name] = policy
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	// Third pass: find the middleware constructor each configuration is passed to
+	ast.Inspect(source.AST, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		for _, arg := range call.Args {
+			arg = unwrapAddr(arg)
+			policy := byNode[arg]
+			if ident, ok := arg.(*ast.Ident); ok && policy == nil {
+				policy = byVar[ident.Name]
+			}
+			if policy != nil && policy.Middleware == "" {
+				policy.Middleware = astutil.GetCallName(call)
+				policy.constructor = call
+			}
+		}
+		return true
+	})
+
+	return policies
+}
+
+// bind finds where the policy's middleware is applied: the router of a Use() call, the
+// router wrapped by the middleware, or a route registration it is passed to directly.
+// The middleware may be held in a variable first (c := cors.New(opts); c.Handler(mux)).
+func (p *corsPolicy) bind(source *astutil.ParsedSource, routers *routerTree) {
+	p.routers = routers
+	if p.constructor == nil {
+		return
+	}
+
+	var middlewareVar types.Object
+	ast.Inspect(source.AST, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok && i < len(node.Rhs) && node.Rhs[i] == p.constructor {
+					middlewareVar = routers.info.ObjectOf(ident)
+				}
+			}
+		case *ast.ValueSpec:
+			for i, name := range node.Names {
+				if i < len(node.Values) && node.Values[i] == p.constructor {
+					middlewareVar = routers.info.ObjectOf(name)
+				}
+			}
+		}
+		return true
+	})
+	isMiddleware := func(expr ast.Expr) bool {
+		if expr == p.constructor {
+			return true
+		}
+		ident, ok := expr.(*ast.Ident)
+		return ok && middlewareVar != nil && routers.info.ObjectOf(ident) == middlewareVar
+	}
+
+	passedTo := func(call *ast.CallExpr) bool {
+		for _, arg := range call.Args {
+			if isMiddleware(arg) {
+				return true
+			}
+		}
+		return false
+	}
+
+	bound := false
+	ast.Inspect(source.AST, func(n ast.Node) bool {
+		if bound || p.router != nil || p.routeLine > 0 {
+			return false
+		}
+
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			// A sub-router created with the middleware: api := r.Group("/api", mw)
+			for i, lhs := range node.Lhs {
+				call, ok := unwrapAddr(rhsAt(node, i)).(*ast.CallExpr)
+				if ident, isIdent := lhs.(*ast.Ident); ok && isIdent && passedTo(call) {
+					if _, isSel := call.Fun.(*ast.SelectorExpr); isSel {
+						p.router = routers.info.ObjectOf(ident)
+					}
+				}
+			}
+		case *ast.CallExpr:
+			// Wrapping a router: c.Handler(mux) or cors.Handler(opts)(mux)
+			if sel, ok := node.Fun.(*ast.SelectorExpr); ok && isMiddleware(sel.X) && len(node.Args) > 0 {
+				p.router = routers.RouterOf(node.Args[0])
+			} else if isMiddleware(node.Fun) && len(node.Args) > 0 {
+				p.router = routers.RouterOf(node.Args[0])
+			} else if sel, ok := node.Fun.(*ast.SelectorExpr); ok && passedTo(node) {
+				if sel.Sel.Name == "Use" {
+					p.router = routers.RouterOf(sel.X)
+				} else {
+					p.routeLine = astutil.GetLineNumber(source.FileSet, node)
+				}
+				// An unresolved Use() receiver falls back to the middleware chains
+				bound = true
+			}
+		}
+		return true
+	})
+}
+
+// rhsAt returns the value assigned to the i-th left-hand side of an assignment, or nil.
+func rhsAt(assign *ast.AssignStmt, i int) ast.Expr {
+	if i < len(assign.Rhs) && len(assign.Lhs) == len(assign.Rhs) {
+		return assign.Rhs[i]
+	}
+	return nil
+}
+
+// applyField records a configuration field on the policy.
+func (p *corsPolicy) applyField(field string, value ast.Expr, source *astutil.ParsedSource, sources *astutil.SourceSet) {
+	switch {
+	case field == "AllowAllOrigins":
+		p.wildcardOrigin = p.wildcardOrigin || isTrueLiteral(value)
+	case field == "AllowOrigins" || field == "AllowedOrigins":
+		p.originsSet = true
+		origins := astutil.GetStringSlice(value)
+		if s := astutil.GetStringValue(value); s != "" {
+			origins = strings.Split(s, ",")
+		}
+		for _, origin := range origins {
+			if strings.TrimSpace(origin) == "*" {
+				p.wildcardOrigin = true
+			}
+		}
+	case field == "AllowCredentials":
+		p.AllowCredentials = isTrueLiteral(value)
+	case corsOriginFuncFields[field]:
+		p.originFuncOK = true
+		if body := resolveFuncBody(value, source, sources); body != nil && alwaysReturnsTrue(body) {
+			p.ReflectsAnyOrigin = true
+		}
+	}
+}
+
+// resolveFuncBody returns the body of a function literal or of a named function in the same package.
+func resolveFuncBody(expr ast.Expr, source *astutil.ParsedSource, sources *astutil.SourceSet) *ast.BlockStmt {
+	switch e := expr.(type) {
+	case *ast.FuncLit:
+		return e.Body
+	case *ast.Ident:
+		if _, fn := sources.ResolveFunc(source.FilePath, e.Name); fn != nil {
+			return fn.Body
+		}
+	}
+	return nil
+}
+
+// alwaysReturnsTrue returns true if every return statement of a function body
+// (ignoring nested function literals) returns the literal true as its first result.
+func alwaysReturnsTrue(body *ast.BlockStmt) bool {
+	if body == nil {
+		return false
+	}
+
+	returns := 0
+	allTrue := true
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			returns++
+			if len(node.Results) == 0 || !isTrueLiteral(node.Results[0]) {
+				allTrue = false
+			}
+		}
+		return true
+	})
+
+	return returns > 0 && allTrue
+}
+
+// isTrueLiteral returns true if the expression is the identifier true.
+func isTrueLiteral(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "true"
+}
+
+// selectorParts splits a pkg.Name selector into its parts.
+func selectorParts(expr ast.Expr) (string, string) {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return "", ""
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return "", ""
+	}
+	return ident.Name, sel.Sel.Name
+}
+
+// unwrapAddr strips a leading & from an expression.
+func unwrapAddr(expr ast.Expr) ast.Expr {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		return unary.X
+	}
+	return expr
+}
+
+// containsString returns true if a slice contains a string.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -1,18 +1,26 @@
 package rules
 
 import (
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
 	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
 )
 
 // Engine evaluates security rules against endpoints.
 type Engine struct {
 	rules        []Rule
+	projectRules []ProjectRule
 	enabledRules map[string]bool
 }
 
-// NewEngine creates a new rule engine.
+// NewEngine creates a new rule engine with default settings.
 // If enabledRules is nil, all rules are enabled.
 func NewEngine(enabledRules []string) *Engine {
+	return NewEngineWithSettings(enabledRules, Settings{})
+}
+
+// NewEngineWithSettings creates a new rule engine whose rules use the given settings.
+// If enabledRules is nil, all rules are enabled.
+func NewEngineWithSettings(enabledRules []string, settings Settings) *Engine {
 	allRules := []Rule{
 		NewAP001PublicWithoutIntent(),
 		NewAP002AnonymousOnWrite(),
@@ -22,10 +30,48 @@ func NewEngine(enabledRules []string) *Engine {
 		NewAP006WeakRoleNaming(),
 		NewAP007SensitiveKeywords(),
 		NewAP008EndpointWithoutAuth(),
+		NewAP009ConditionalAuth(),
+		NewAP021DirectoryListing(),
+		NewAP024UnauthenticatedWebSocket(),
+		NewAP026PublicProxy(),
+		NewAP027WildcardProxy(),
+		NewAP029UnauthenticatedUpload(),
+		NewAP030UploadSizeLimit(),
+		NewAP035StateChangingGet(),
+		NewAP040UnsignedWebhook(),
+	}
+
+	allProjectRules := []ProjectRule{
+		NewAP010RouteConflict(),
+		NewAP011InconsistentSiblings(),
+		NewAP012MixedHandlerExposure(),
+		NewAP013CORSWildcardCredentials(),
+		NewAP014CORSReflectedOrigin(),
+		NewAP015CORSWildcardAuthenticated(),
+		NewAP016MissingCSRF(),
+		NewAP017MissingRateLimit(settings.RateLimitMiddleware),
+		NewAP018HardcodedCredentials(),
+		NewAP019JWTWeakness(),
+		NewAP020DebugMode(),
+		NewAP022SensitiveStaticDir(),
+		NewAP023UserControlledFilePath(),
+		NewAP025WebSocketOrigin(),
+		NewAP028ProxyForwardsAuthorization(),
+		NewAP031UploadClientFilename(),
+		NewAP032MassAssignment(settings.PrivilegedFields),
+		NewAP033SensitiveResponseFields(),
+		NewAP034ObjectLevelAuthorization(settings.PrincipalAccessors),
+		NewAP036InsecureCookie(),
+		NewAP037SpoofableIPAllowlist(),
+		NewAP038ForwardedIPAccessControl(),
+		NewAP039TimingUnsafeComparison(settings.AuthFunctions),
+		NewAP041ErrorDetailLeak(),
+		NewAP042OpenRedirect(),
 	}
 
 	engine := &Engine{
-		rules: allRules,
+		rules:        allRules,
+		projectRules: allProjectRules,
 	}
 
 	if enabledRules != nil {
@@ -43,12 +89,22 @@ func (e *Engine) Rules() []Rule {
 	return e.rules
 }
 
+// ProjectRules returns all available project-level rules.
+func (e *Engine) ProjectRules() []ProjectRule {
+	return e.projectRules
+}
+
+// isEnabled returns true if a rule is enabled in this engine.
+func (e *Engine) isEnabled(ruleID string) bool {
+	return e.enabledRules == nil || e.enabledRules[ruleID]
+}
+
 // Evaluate evaluates all enabled rules against an endpoint.
 func (e *Engine) Evaluate(endpoint *models.Endpoint) []*models.Finding {
 	var findings []*models.Finding
 
 	for _, rule := range e.rules {
-		if e.enabledRules != nil && !e.enabledRules[rule.ID()] {
+		if !e.isEnabled(rule.ID()) {
 			continue
 		}
 
@@ -70,6 +126,21 @@ func (e *Engine) EvaluateAll(endpoints []*models.Endpoint) []*models.Finding {
 	return findings
 }
 
+// EvaluateProject evaluates all enabled project-level rules against a scan result and its sources.
+func (e *Engine) EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding {
+	var findings []*models.Finding
+
+	for _, rule := range e.projectRules {
+		if !e.isEnabled(rule.ID()) {
+			continue
+		}
+
+		findings = append(findings, rule.EvaluateProject(result, sources)...)
+	}
+
+	return findings
+}
+
 // GetRule returns a rule by its ID.
 func (e *Engine) GetRule(ruleID string) Rule {
 	for _, rule := range e.rules {
@@ -0,0 +1,156 @@
+package rules
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+)
+
+// guard is a condition that has a known value whenever a statement runs.
+type guard struct {
+	// cond is the condition: an if condition, or the comparison of a switch tag with a case.
+	cond ast.Expr
+
+	// init is the init statement of the if or switch statement (if _, ok := allowed[next]; ok).
+	init ast.Stmt
+
+	// holds is the value of the condition when the statement runs.
+	holds bool
+}
+
+// Guards returns the conditions that decide whether a node of the handler body runs: the if
+// statements and switch cases it is nested in, and the earlier if statements of its enclosing
+// blocks whose body leaves the block (if !ok { return }).
+func (h *handlerFunc) Guards(node ast.Node) []guard {
+	return h.GuardsWith(node, nil)
+}
+
+// GuardsWith is like Guards, and also counts the earlier if statements whose body satisfies
+// exits, such as a body replacing the checked value with a default (if !ok { next = "/" }).
+func (h *handlerFunc) GuardsWith(node ast.Node, exits func(body *ast.BlockStmt) bool) []guard {
+	var path, stack []ast.Node
+	ast.Inspect(h.body, func(n ast.Node) bool {
+		if path != nil {
+			return false
+		}
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		stack = append(stack, n)
+		if n == node {
+			path = append([]ast.Node(nil), stack...)
+			return false
+		}
+		return true
+	})
+
+	var guards []guard
+	for i := 0; i+1 < len(path); i++ {
+		child := path[i+1]
+		switch parent := path[i].(type) {
+		case *ast.IfStmt:
+			if child == parent.Body {
+				guards = append(guards, guard{cond: parent.Cond, init: parent.Init, holds: true})
+			} else if child == parent.Else {
+				guards = append(guards, guard{cond: parent.Cond, init: parent.Init, holds: false})
+			}
+		case *ast.SwitchStmt:
+			if i+2 >= len(path) {
+				continue
+			}
+			if clause, ok := path[i+2].(*ast.CaseClause); ok && len(clause.List) > 0 {
+				guards = append(guards, guard{cond: caseCondition(parent.Tag, clause.List), init: parent.Init, holds: true})
+			}
+		case *ast.BlockStmt:
+			guards = append(guards, earlyExits(parent.List, child, exits)...)
+		case *ast.CaseClause:
+			guards = append(guards, earlyExits(parent.Body, child, exits)...)
+		case *ast.CommClause:
+			guards = append(guards, earlyExits(parent.Body, child, exits)...)
+		}
+	}
+	return guards
+}
+
+// caseCondition returns the condition under which a switch case runs: tag == value for each of
+// its values, or the values themselves in a switch without a tag, joined with ||.
+func caseCondition(tag ast.Expr, values []ast.Expr) ast.Expr {
+	var cond ast.Expr
+	for _, value := range values {
+		if tag != nil {
+			value = &ast.BinaryExpr{X: tag, Op: token.EQL, Y: value}
+		}
+		if cond == nil {
+			cond = value
+		} else {
+			cond = &ast.BinaryExpr{X: cond, Op: token.LOR, Y: value}
+		}
+	}
+	return cond
+}
+
+// earlyExits returns the if statements before a statement of a list that leave the block, or
+// satisfy exits, when their condition holds, so the condition is false when the statement runs.
+func earlyExits(list []ast.Stmt, stmt ast.Node, exits func(body *ast.BlockStmt) bool) []guard {
+	var guards []guard
+	for _, s := range list {
+		if s == stmt {
+			break
+		}
+		ifStmt, ok := s.(*ast.IfStmt)
+		if ok && ifStmt.Else == nil && (leavesBlock(ifStmt.Body) || (exits != nil && exits(ifStmt.Body))) {
+			guards = append(guards, guard{cond: ifStmt.Cond, init: ifStmt.Init, holds: false})
+		}
+	}
+	return guards
+}
+
+// leavesBlock returns true if a block ends by returning, branching, panicking or exiting.
+func leavesBlock(block *ast.BlockStmt) bool {
+	if len(block.List) == 0 {
+		return false
+	}
+	switch last := block.List[len(block.List)-1].(type) {
+	case *ast.ReturnStmt, *ast.BranchStmt:
+		return true
+	case *ast.ExprStmt:
+		call, ok := last.X.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		name := astutil.GetCallName(call)
+		return name == "panic" || name == "os.Exit" || strings.HasSuffix(name, ".Fatal") || strings.HasSuffix(name, ".Fatalf")
+	}
+	return false
+}
+
+// Implies returns true if the tests of the guard's condition that are known to have a value whenever
+// the guarded statement runs satisfy match. Tests are the operands of the condition's && and ||
+// operators, with negations folded into the value: one of the operands of a true && or a false ||
+// must match, and every operand of a true || or a false &&, since either may have decided it.
+func (g guard) Implies(match func(test ast.Expr, holds bool) bool) bool {
+	return impliesTest(g.cond, g.holds, match)
+}
+
+// impliesTest returns true if match accepts a test that has a known value when expr has the value holds.
+func impliesTest(expr ast.Expr, holds bool, match func(test ast.Expr, holds bool) bool) bool {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return impliesTest(e.X, holds, match)
+	case *ast.UnaryExpr:
+		if e.Op == token.NOT {
+			return impliesTest(e.X, !holds, match)
+		}
+	case *ast.BinaryExpr:
+		switch {
+		case (e.Op == token.LAND && holds) || (e.Op == token.LOR && !holds):
+			return impliesTest(e.X, holds, match) || impliesTest(e.Y, holds, match)
+		case e.Op == token.LAND || e.Op == token.LOR:
+			return impliesTest(e.X, holds, match) && impliesTest(e.Y, holds, match)
+		}
+	}
+	return match(expr, holds)
+}
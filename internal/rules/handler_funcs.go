@@ -0,0 +1,195 @@
+package rules
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// handlerFunc is a function that may serve requests: a function declaration, or a function
+// literal passed as an argument to a call such as a route registration.
+type handlerFunc struct {
+	source *astutil.ParsedSource
+	body   *ast.BlockStmt
+
+	// decl is the declaration of a named function; nil for function literals.
+	decl *ast.FuncDecl
+
+	// line is the line of the call a function literal is passed to.
+	line int
+
+	// nested are the function literals passed as call arguments inside the body,
+	// which are analysed as handlers of their own.
+	nested map[*ast.FuncLit]bool
+}
+
+// Name returns the function name, or "<anonymous>" for a function literal.
+func (h *handlerFunc) Name() string {
+	if h.decl != nil {
+		return h.decl.Name.Name
+	}
+	return "<anonymous>"
+}
+
+// Inspect walks the handler body, skipping nested handler literals.
+func (h *handlerFunc) Inspect(fn func(ast.Node) bool) {
+	ast.Inspect(h.body, func(n ast.Node) bool {
+		if lit, ok := n.(*ast.FuncLit); ok && h.nested[lit] {
+			return false
+		}
+		return fn(n)
+	})
+}
+
+// Endpoints returns the endpoints served by the handler: endpoints whose handler refers to the
+// declaration, or for a function literal, endpoints registered by the call it is passed to.
+func (h *handlerFunc) Endpoints(endpoints []*models.Endpoint, sources *astutil.SourceSet) []*models.Endpoint {
+	var served []*models.Endpoint
+	for _, e := range endpoints {
+		if h.decl == nil {
+			if e.FilePath == h.source.FilePath && e.LineNumber == h.line {
+				served = append(served, e)
+			}
+			continue
+		}
+		if e.FunctionName == "" || e.FunctionName == "<anonymous>" {
+			continue
+		}
+		if h.refersTo(sources, e.FilePath, handlerExpr(e, sources), e.FunctionName) {
+			served = append(served, e)
+		}
+	}
+	return served
+}
+
+// CallerEndpoints returns the endpoints served by the handler or, for a helper function
+// (setSessionCookie(c, token)), the endpoints served by the package's handlers calling it.
+func (h *handlerFunc) CallerEndpoints(endpoints []*models.Endpoint, sources *astutil.SourceSet) []*models.Endpoint {
+	served := h.Endpoints(endpoints, sources)
+	if len(served) > 0 || h.decl == nil {
+		return served
+	}
+
+	for _, source := range sources.Package(h.source.FilePath) {
+		for _, caller := range findHandlerFuncs(source) {
+			calls := false
+			caller.Inspect(func(n ast.Node) bool {
+				if call, ok := n.(*ast.CallExpr); ok && !calls {
+					calls = h.refersTo(sources, source.FilePath, call.Fun, astutil.GetCallName(call))
+				}
+				return !calls
+			})
+			if calls {
+				served = append(served, caller.Endpoints(endpoints, sources)...)
+			}
+		}
+	}
+	return served
+}
+
+// refersTo returns true if an expression of a file naming a function (Create, h.Create, pkg.Create)
+// refers to the handler's declaration. The expression is resolved through the type information of
+// its package, so h.Create only matches the Create method of h's type; a function the types do not
+// resolve, such as one named by an endpoint without its registration call, is resolved by name.
+func (h *handlerFunc) refersTo(sources *astutil.SourceSet, path string, expr ast.Expr, name string) bool {
+	if fn := funcObject(sources, path, expr); fn != nil {
+		return fn == sources.Types(h.source.FilePath).ObjectOf(h.decl.Name)
+	}
+
+	_, fn := sources.ResolveFunc(path, name)
+	return fn == h.decl
+}
+
+// funcObject returns the function or method an expression of a file names (Create, h.Create,
+// pkg.Create), or nil if the type information of its package does not resolve it.
+func funcObject(sources *astutil.SourceSet, path string, expr ast.Expr) *types.Func {
+	var ident *ast.Ident
+	switch x := expr.(type) {
+	case *ast.Ident:
+		ident = x
+	case *ast.SelectorExpr:
+		ident = x.Sel
+	default:
+		return nil
+	}
+	if fn, ok := sources.Types(path).ObjectOf(ident).(*types.Func); ok {
+		return fn.Origin()
+	}
+	return nil
+}
+
+// handlerExpr returns the expression naming an endpoint's handler in its registration call
+// (h.Create, handlers.Create, Create), or nil if no call on the endpoint's line names it.
+func handlerExpr(e *models.Endpoint, sources *astutil.SourceSet) ast.Expr {
+	source := sources.Get(e.FilePath)
+	if source == nil || e.FunctionName == "" {
+		return nil
+	}
+
+	var found ast.Expr
+	ast.Inspect(source.AST, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || found != nil {
+			return found == nil
+		}
+		if astutil.GetLineNumber(source.FileSet, call) != e.LineNumber {
+			return true
+		}
+		for _, arg := range call.Args {
+			switch arg.(type) {
+			case *ast.Ident, *ast.SelectorExpr:
+				if astutil.ExprString(arg) == e.FunctionName {
+					found = arg
+				}
+			}
+		}
+		return found == nil
+	})
+	return found
+}
+
+// findHandlerFuncs returns the function declarations of a file and the function literals
+// passed as call arguments.
+func findHandlerFuncs(source *astutil.ParsedSource) []*handlerFunc {
+	var handlers []*handlerFunc
+	literals := make(map[*ast.FuncLit]bool)
+
+	ast.Inspect(source.AST, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		for _, arg := range call.Args {
+			if lit, ok := arg.(*ast.FuncLit); ok {
+				literals[lit] = true
+				handlers = append(handlers, &handlerFunc{
+					source: source,
+					body:   lit.Body,
+					line:   astutil.GetLineNumber(source.FileSet, call),
+				})
+			}
+		}
+		return true
+	})
+
+	for _, fn := range astutil.FindFuncDecls(source.AST) {
+		if fn.Body != nil {
+			handlers = append(handlers, &handlerFunc{source: source, body: fn.Body, decl: fn})
+		}
+	}
+
+	// Each handler skips the literals passed to calls in its body
+	for _, h := range handlers {
+		h.nested = make(map[*ast.FuncLit]bool)
+		ast.Inspect(h.body, func(n ast.Node) bool {
+			if lit, ok := n.(*ast.FuncLit); ok && literals[lit] {
+				h.nested[lit] = true
+			}
+			return true
+		})
+	}
+
+	return handlers
+}
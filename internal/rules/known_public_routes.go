@@ -19,32 +19,87 @@ var knownPublicSegments = map[string]bool{
 	"ping": true, "ready": true, "alive": true, "startup": true,
 	// public docs
 	"swagger": true, "openapi": true, "docs": true, "redoc": true,
-	// public webhooks and SSO
-	"webhook": true, "webhooks": true, "sso": true, "saml": true, "wsfed": true,
+	// SSO
+	"sso": true, "saml": true, "wsfed": true,
 	// public grant endpoints
 	"grant": true,
 }
 
+// authEntryPointSegments are route segments of authentication entry points. These routes
+// accept credentials, one-time codes or reset tokens and are therefore brute-force targets.
+var authEntryPointSegments = map[string]bool{
+	"login": true, "signin": true, "signup": true, "register": true, "registration": true,
+	"forgot": true,
+	"2fa":    true, "totp": true, "mfa": true, "otp": true,
+}
+
+// authActionSegments are route segments that are authentication entry points only next to an
+// authentication resource segment (/auth/token, /password/reset, /session/refresh). On other
+// resources they are business actions, such as /orders/:id/confirm or /payments/:id/verify.
+var authActionSegments = map[string]bool{
+	"reset": true, "password": true, "verify": true, "confirm": true,
+	"token": true, "refresh": true,
+}
+
+// authResourceSegments are route segments of the resources authentication actions belong to.
+var authResourceSegments = map[string]bool{
+	"auth": true, "oauth": true, "oauth2": true, "sso": true,
+	"session": true, "sessions": true, "account": true, "accounts": true,
+	"user": true, "identity": true, "email": true, "password": true, "token": true,
+}
+
 // isKnownPublicEndpoint returns true if the route is a well-known public endpoint
 // that should never require authentication (auth entry points, health checks, etc.)
 func isKnownPublicEndpoint(route string) bool {
+	for _, seg := range routeKeywordSegments(route) {
+		if knownPublicSegments[seg] {
+			return true
+		}
+	}
+	return false
+}
+
+// authEntryPointSegment returns the segment that makes a route an authentication
+// entry point (login, token, otp, ...), or an empty string.
+func authEntryPointSegment(route string) string {
+	segments := routeKeywordSegments(route)
+	for i, seg := range segments {
+		if authEntryPointSegments[seg] || (authActionSegments[seg] && hasAuthResource(segments, i)) {
+			return seg
+		}
+	}
+	return ""
+}
+
+// hasAuthResource returns true if a segment other than the one at skip is an authentication resource.
+func hasAuthResource(segments []string, skip int) bool {
+	for i, seg := range segments {
+		if i != skip && authResourceSegments[seg] {
+			return true
+		}
+	}
+	return false
+}
+
+// routeKeywordSegments splits a route into lowercase keyword segments,
+// skipping parameter segments like :id, {id} and *.
+func routeKeywordSegments(route string) []string {
 	if route == "" || route == "/" {
-		return false
+		return nil
 	}
 	lower := strings.ToLower(route)
 	// Split on path separators and common delimiters
 	segments := strings.FieldsFunc(lower, func(r rune) bool {
 		return r == '/' || r == '-' || r == '_' || r == '.'
 	})
+
+	var keywords []string
 	for _, seg := range segments {
-		// Skip parameter segments like :id, {id}, *
 		if seg == "" || seg == "*" || strings.HasPrefix(seg, ":") ||
 			(strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}")) {
 			continue
 		}
-		if knownPublicSegments[seg] {
-			return true
-		}
+		keywords = append(keywords, seg)
 	}
-	return false
+	return keywords
 }
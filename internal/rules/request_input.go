@@ -0,0 +1,256 @@
+package rules
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+)
+
+// requestInputAccessors are the method and function names that return request-controlled values
+// (c.Param, c.Query, r.FormValue, chi.URLParam, mux.Vars, ...).
+var requestInputAccessors = map[string]bool{
+	"Param":           true,
+	"Params":          true,
+	"ParamValues":     true,
+	"Query":           true,
+	"DefaultQuery":    true,
+	"QueryParam":      true,
+	"QueryParams":     true,
+	"QueryArray":      true,
+	"GetQuery":        true,
+	"PostForm":        true,
+	"DefaultPostForm": true,
+	"FormValue":       true,
+	"PostFormValue":   true,
+	"PathValue":       true,
+	"URLParam":        true,
+	"Vars":            true,
+	"GetHeader":       true,
+}
+
+// requestInputFields are the fields of request-derived values that hold client-controlled data
+// (the client-supplied name of a multipart.FileHeader).
+var requestInputFields = map[string]bool{
+	"Filename": true,
+}
+
+// routeParamReaders maps the methods and functions that read a route parameter by name to the index
+// of the name argument (c.Param("id"), c.Params("id"), r.PathValue("id"), chi.URLParam(r, "id")).
+var routeParamReaders = map[string]int{
+	"Param":           0,
+	"Params":          0,
+	"ParamsInt":       0,
+	"ByName":          0,
+	"PathValue":       0,
+	"URLParam":        1,
+	"URLParamFromCtx": 1,
+}
+
+// pathSanitizers are the calls that reduce a request value to a safe file name.
+var pathSanitizers = map[string]bool{
+	"filepath.Base": true,
+	"path.Base":     true,
+	"SecureJoin":    true,
+}
+
+// requestTaint tracks the variables of a handler that hold values from a source: request input,
+// or any other calls and fields chosen by the caller.
+type requestTaint struct {
+	vars       map[string]bool
+	sanitizers map[string]bool
+	isSource   func(call *ast.CallExpr) bool
+	fields     map[string]bool
+
+	// isSourceIndex, if set, reports map lookups that are sources (mux.Vars(r)["id"]).
+	isSourceIndex func(index *ast.IndexExpr) bool
+}
+
+// newRequestTaint finds the variables of a handler assigned from request input, directly or through
+// other tainted variables, in source order. Values passed through a sanitizer are not tainted.
+func newRequestTaint(h *handlerFunc, sanitizers map[string]bool) *requestTaint {
+	return newTaint(h, isRequestInputCall, requestInputFields, sanitizers)
+}
+
+// newTaint finds the variables of a handler assigned from source calls or fields, directly or through
+// other tainted variables, in source order. Values passed through a sanitizer are not tainted.
+func newTaint(h *handlerFunc, isSource func(call *ast.CallExpr) bool, fields, sanitizers map[string]bool) *requestTaint {
+	t := &requestTaint{vars: make(map[string]bool), sanitizers: sanitizers, isSource: isSource, fields: fields}
+	t.collect(h)
+	return t
+}
+
+// newRouteParamTaint finds the variables of a handler assigned from the named route parameters:
+// reads of a parameter by name, and lookups of the name in the map returned by mux.Vars.
+func newRouteParamTaint(h *handlerFunc, names []string) *requestTaint {
+	wanted := make(map[string]bool)
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	// vars := mux.Vars(r)
+	varsMaps := make(map[string]bool)
+	h.Inspect(func(n ast.Node) bool {
+		if assign, ok := n.(*ast.AssignStmt); ok {
+			for i, lhs := range assign.Lhs {
+				ident, isIdent := lhs.(*ast.Ident)
+				call, isCall := rhsAt(assign, i).(*ast.CallExpr)
+				if isIdent && isCall && isVarsCall(call) {
+					varsMaps[ident.Name] = true
+				}
+			}
+		}
+		return true
+	})
+
+	t := &requestTaint{
+		vars: make(map[string]bool),
+		isSource: func(call *ast.CallExpr) bool {
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return false
+			}
+			index, ok := routeParamReaders[sel.Sel.Name]
+			return ok && index < len(call.Args) && wanted[astutil.GetStringValue(call.Args[index])]
+		},
+		isSourceIndex: func(index *ast.IndexExpr) bool {
+			if !wanted[astutil.GetStringValue(index.Index)] {
+				return false
+			}
+			switch x := index.X.(type) {
+			case *ast.CallExpr:
+				return isVarsCall(x)
+			case *ast.Ident:
+				return varsMaps[x.Name]
+			}
+			return false
+		},
+	}
+	t.collect(h)
+	return t
+}
+
+// isVarsCall returns true if a call returns the route variables of a gorilla/mux request (mux.Vars(r)).
+func isVarsCall(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "Vars" && len(call.Args) == 1
+}
+
+// collect records the variables of a handler assigned from tainted values, in source order.
+// Assigning an untainted value clears a variable only outside conditional branches, since
+// if !valid(next) { next = "/" } leaves next tainted when the condition is false.
+func (t *requestTaint) collect(h *handlerFunc) {
+	unconditional := make(map[ast.Stmt]bool)
+	for _, stmt := range h.body.List {
+		unconditional[stmt] = true
+	}
+
+	h.Inspect(func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok || ident.Name == "_" {
+					continue
+				}
+				// Multi-value assignments (v, err := ...) take the taint of the single call
+				rhs := node.Rhs[0]
+				if len(node.Rhs) == len(node.Lhs) {
+					rhs = node.Rhs[i]
+				}
+				if t.Tainted(rhs) {
+					t.vars[ident.Name] = true
+				} else if node.Tok == token.DEFINE || (len(node.Rhs) == len(node.Lhs) && unconditional[node]) {
+					delete(t.vars, ident.Name)
+				}
+			}
+		case *ast.ValueSpec:
+			for i, name := range node.Names {
+				if i < len(node.Values) && t.Tainted(node.Values[i]) {
+					t.vars[name.Name] = true
+				}
+			}
+		case *ast.RangeStmt:
+			// for _, v := range c.QueryArray("ids")
+			if t.Tainted(node.X) {
+				for _, expr := range []ast.Expr{node.Key, node.Value} {
+					if ident, ok := expr.(*ast.Ident); ok {
+						t.vars[ident.Name] = true
+					}
+				}
+			}
+		}
+		return true
+	})
+}
+
+// Tainted returns true if an expression contains a source call or field, or a tainted variable,
+// outside of a sanitizer call.
+func (t *requestTaint) Tainted(expr ast.Expr) bool {
+	if expr == nil {
+		return false
+	}
+
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			name := astutil.GetCallName(node)
+			if t.isSanitizer(name) {
+				return false
+			}
+			if t.isSource(node) {
+				found = true
+			}
+		case *ast.SelectorExpr:
+			if t.fields[node.Sel.Name] {
+				found = true
+			}
+		case *ast.IndexExpr:
+			if t.isSourceIndex != nil && t.isSourceIndex(node) {
+				found = true
+			}
+		case *ast.Ident:
+			found = t.vars[node.Name]
+		case *ast.FuncLit:
+			return false
+		}
+		return !found
+	})
+	return found
+}
+
+// isSanitizer returns true if a call name matches one of the sanitizers, by full name or by function name.
+func (t *requestTaint) isSanitizer(name string) bool {
+	if t.sanitizers[name] {
+		return true
+	}
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return t.sanitizers[name[i+1:]]
+	}
+	return false
+}
+
+// isRequestInputCall returns true if a call is a request input accessor. Plain function calls
+// (without a receiver or package) are not, so local helpers named Query are ignored.
+func isRequestInputCall(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !requestInputAccessors[sel.Sel.Name] {
+		return false
+	}
+
+	// r.URL.Query() and c.Query("name"), but not db.Query("SELECT ...", args...)
+	if sel.Sel.Name == "Query" {
+		if len(call.Args) > 1 {
+			return false
+		}
+		if len(call.Args) == 1 && strings.Contains(astutil.GetStringValue(call.Args[0]), " ") {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,222 @@
+package rules
+
+import (
+	"go/ast"
+	"go/types"
+	"path/filepath"
+
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
+)
+
+// defaultServeMux stands for http.DefaultServeMux, which routes registered with
+// http.Handle and http.HandleFunc are added to.
+var defaultServeMux = types.NewVar(0, nil, "DefaultServeMux", nil)
+
+// routerTree links the router variables of a package to the router each one was derived
+// from (api := r.Group("/api"), r.Route("/api", func(r chi.Router) {...})), so middleware
+// applied to one router can be matched to the routes registered on it and its sub-routers.
+type routerTree struct {
+	sources *astutil.SourceSet
+	info    *astutil.PackageTypes
+
+	// parent maps a sub-router to the router it was created from.
+	parent map[types.Object]types.Object
+
+	// alias maps a function parameter to the router passed for it (setupRoutes(r)).
+	alias map[types.Object]types.Object
+}
+
+// newRouterTree builds the router tree of the package containing a file.
+func newRouterTree(sources *astutil.SourceSet, path string) *routerTree {
+	t := &routerTree{
+		sources: sources,
+		info:    sources.Types(path),
+		parent:  make(map[types.Object]types.Object),
+		alias:   make(map[types.Object]types.Object),
+	}
+
+	funcs := make(map[types.Object]*ast.FuncDecl)
+	pkg := sources.Package(path)
+	for _, source := range pkg {
+		for _, fn := range astutil.FindFuncDecls(source.AST) {
+			if obj := t.info.ObjectOf(fn.Name); obj != nil {
+				funcs[obj] = fn
+			}
+		}
+	}
+
+	for _, source := range pkg {
+		ast.Inspect(source.AST, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.AssignStmt:
+				for i, lhs := range node.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok && i < len(node.Rhs) {
+						t.link(ident, node.Rhs[i])
+					}
+				}
+			case *ast.ValueSpec:
+				for i, name := range node.Names {
+					if i < len(node.Values) {
+						t.link(name, node.Values[i])
+					}
+				}
+			case *ast.CallExpr:
+				t.linkCall(node, funcs)
+			}
+			return true
+		})
+	}
+
+	return t
+}
+
+// link records a variable assigned from a method call on a router as its sub-router.
+func (t *routerTree) link(ident *ast.Ident, value ast.Expr) {
+	call, ok := value.(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	if _, isSel := call.Fun.(*ast.SelectorExpr); !isSel {
+		return
+	}
+	if obj, parent := t.info.ObjectOf(ident), t.RouterOf(call.Fun); obj != nil && parent != nil && obj != parent {
+		t.parent[obj] = parent
+	}
+}
+
+// linkCall records the parameters of router callbacks (r.Route("/api", func(r chi.Router) {...}))
+// as sub-routers, and the parameters of package functions as aliases of the routers passed to them.
+func (t *routerTree) linkCall(call *ast.CallExpr, funcs map[types.Object]*ast.FuncDecl) {
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+		parent := t.RouterOf(sel.X)
+		for _, arg := range call.Args {
+			lit, ok := arg.(*ast.FuncLit)
+			if !ok || parent == nil || len(lit.Type.Params.List) == 0 || len(lit.Type.Params.List[0].Names) == 0 {
+				continue
+			}
+			if obj := t.info.ObjectOf(lit.Type.Params.List[0].Names[0]); obj != nil {
+				t.parent[obj] = parent
+			}
+		}
+		return
+	}
+
+	ident, ok := call.Fun.(*ast.Ident)
+	if !ok {
+		return
+	}
+	fn := funcs[t.info.ObjectOf(ident)]
+	if fn == nil {
+		return
+	}
+	var params []*ast.Ident
+	for _, field := range fn.Type.Params.List {
+		params = append(params, field.Names...)
+	}
+	for i, arg := range call.Args {
+		if i >= len(params) {
+			break
+		}
+		param, router := t.info.ObjectOf(params[i]), t.RouterOf(arg)
+		if _, seen := t.alias[param]; param != nil && router != nil && !seen {
+			t.alias[param] = router
+		}
+	}
+}
+
+// RouterOf returns the router variable an expression is rooted at ("api" for
+// api.Group("/v1").GET), or nil if it is not a resolvable variable.
+func (t *routerTree) RouterOf(expr ast.Expr) types.Object {
+	for {
+		switch e := expr.(type) {
+		case *ast.SelectorExpr:
+			if ident, ok := e.X.(*ast.Ident); ok {
+				if pkg, ok := t.info.ObjectOf(ident).(*types.PkgName); ok {
+					if pkg.Imported().Path() == "net/http" && (e.Sel.Name == "DefaultServeMux" || isHandleFunc(e.Sel.Name)) {
+						return defaultServeMux
+					}
+					return nil
+				}
+			}
+			expr = e.X
+		case *ast.CallExpr:
+			expr = e.Fun
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.UnaryExpr:
+			expr = e.X
+		case *ast.Ident:
+			if obj, ok := t.info.ObjectOf(e).(*types.Var); ok {
+				return t.canonical(obj)
+			}
+			return nil
+		default:
+			return nil
+		}
+	}
+}
+
+// isHandleFunc returns true for the net/http functions registering on the default mux.
+func isHandleFunc(name string) bool {
+	return name == "Handle" || name == "HandleFunc"
+}
+
+// canonical follows parameter aliases to the router passed in by the caller.
+func (t *routerTree) canonical(obj types.Object) types.Object {
+	for i := 0; i < maxRouterDepth; i++ {
+		next, ok := t.alias[obj]
+		if !ok {
+			break
+		}
+		obj = next
+	}
+	return obj
+}
+
+// maxRouterDepth bounds router chains, guarding against cycles.
+const maxRouterDepth = 32
+
+// Descends returns true if a router is the ancestor router or one of its sub-routers.
+func (t *routerTree) Descends(router, ancestor types.Object) bool {
+	for i := 0; i < maxRouterDepth && router != nil; i++ {
+		if router == ancestor {
+			return true
+		}
+		router = t.canonical(t.parent[router])
+	}
+	return false
+}
+
+// EndpointRouter returns the router an endpoint is registered on, found from its
+// registration call, or nil if the registration could not be resolved.
+func (t *routerTree) EndpointRouter(e *models.Endpoint) types.Object {
+	source := t.sources.Get(e.FilePath)
+	if source == nil {
+		return nil
+	}
+
+	var router types.Object
+	ast.Inspect(source.AST, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || router != nil {
+			return router == nil
+		}
+		if _, isSel := call.Fun.(*ast.SelectorExpr); isSel && astutil.GetLineNumber(source.FileSet, call) == e.LineNumber {
+			router = t.RouterOf(call.Fun)
+		}
+		return router == nil
+	})
+	return router
+}
+
+// RegisteredOn returns the endpoints of the tree's package registered on a router or its sub-routers.
+func (t *routerTree) RegisteredOn(endpoints []*models.Endpoint, router types.Object, dir string) []*models.Endpoint {
+	var registered []*models.Endpoint
+	for _, e := range endpoints {
+		if filepath.Dir(e.FilePath) == dir && t.Descends(t.EndpointRouter(e), router) {
+			registered = append(registered, e)
+		}
+	}
+	return registered
+}
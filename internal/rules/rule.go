@@ -2,11 +2,12 @@
 package rules
 
 import (
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
 	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
 )
 
-// Rule is the interface for security rules.
-type Rule interface {
+// RuleInfo describes a security rule.
+type RuleInfo interface {
 	// ID returns the unique rule identifier (e.g., "AP001").
 	ID() string
 
@@ -18,13 +19,27 @@ type Rule interface {
 
 	// Description returns the rule description.
 	Description() string
+}
+
+// Rule is the interface for security rules evaluated against one endpoint at a time.
+type Rule interface {
+	RuleInfo
 
 	// Evaluate evaluates the rule against an endpoint.
 	Evaluate(endpoint *models.Endpoint) []*models.Finding
 }
 
+// ProjectRule is the interface for security rules that evaluate the project as a whole,
+// such as rules comparing endpoints with each other or inspecting service-wide configuration.
+type ProjectRule interface {
+	RuleInfo
+
+	// EvaluateProject evaluates the rule against the full scan result and the parsed sources.
+	EvaluateProject(result *models.ScanResult, sources *astutil.SourceSet) []*models.Finding
+}
+
 // createFinding is a helper to create a finding with standard fields.
-func createFinding(rule Rule, endpoint *models.Endpoint, message, recommendation string) *models.Finding {
+func createFinding(rule RuleInfo, endpoint *models.Endpoint, message, recommendation string) *models.Finding {
 	return &models.Finding{
 		RuleID:         rule.ID(),
 		RuleName:       rule.Name(),
@@ -34,3 +49,17 @@ func createFinding(rule Rule, endpoint *models.Endpoint, message, recommendation
 		Recommendation: recommendation,
 	}
 }
+
+// createProjectFinding is a helper to create a finding located at a source position
+// rather than an endpoint.
+func createProjectFinding(rule RuleInfo, filePath string, lineNumber int, message, recommendation string) *models.Finding {
+	return &models.Finding{
+		RuleID:         rule.ID(),
+		RuleName:       rule.Name(),
+		Severity:       rule.Severity(),
+		Message:        message,
+		FilePath:       filePath,
+		LineNumber:     lineNumber,
+		Recommendation: recommendation,
+	}
+}
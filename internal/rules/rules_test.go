@@ -1,11 +1,16 @@
 package rules
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/BlagoCuljak/ApiPosture.Go/internal/astutil"
 	"github.com/BlagoCuljak/ApiPosture.Go/internal/models"
 )
 
@@ -314,6 +319,3568 @@ func TestAP007_SensitiveKeywords(t *testing.T) {
 	}
 }
 
+func TestAP009_ConditionalAuth(t *testing.T) {
+	rule := NewAP009ConditionalAuth()
+
+	tests := []struct {
+		name          string
+		auth          models.AuthorizationInfo
+		expectFinding bool
+	}{
+		{
+			name: "auth registered behind env flag",
+			auth: models.AuthorizationInfo{
+				RequiresAuth:     true,
+				AuthDependencies: []string{"JWTAuth"},
+				Conditions:       map[string]string{"JWTAuth": `os.Getenv("AUTH_ENABLED") == "true"`},
+			},
+			expectFinding: true,
+		},
+		{
+			name: "conditional auth backed by unconditional auth",
+			auth: models.AuthorizationInfo{
+				RequiresAuth:     true,
+				AuthDependencies: []string{"JWTAuth", "SessionAuth"},
+				Conditions:       map[string]string{"JWTAuth": "cfg.AuthEnabled"},
+			},
+			expectFinding: false,
+		},
+		{
+			name: "unconditional auth",
+			auth: models.AuthorizationInfo{
+				RequiresAuth:     true,
+				AuthDependencies: []string{"JWTAuth"},
+			},
+			expectFinding: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint := &models.Endpoint{
+				Route:          "/api/users",
+				Methods:        []models.HTTPMethod{models.MethodGET},
+				Classification: models.ClassificationAuthenticated,
+				Authorization:  tt.auth,
+			}
+			findings := rule.Evaluate(endpoint)
+			if tt.expectFinding {
+				require.Len(t, findings, 1)
+				assert.Equal(t, "AP009", findings[0].RuleID)
+				assert.Contains(t, findings[0].Message, "environment")
+			} else {
+				assert.Empty(t, findings)
+			}
+		})
+	}
+}
+
+func TestAP010_RouteConflict(t *testing.T) {
+	rule := NewAP010RouteConflict()
+
+	result := models.NewScanResult("/project")
+	result.Endpoints = []*models.Endpoint{
+		{
+			Route:         "/*",
+			Methods:       []models.HTTPMethod{models.MethodGET},
+			FilePath:      "routes.go",
+			LineNumber:    12,
+			Framework:     models.FrameworkFiber,
+			Authorization: models.NewAuthorizationInfo(),
+		},
+		{
+			Route:      "/admin/x",
+			Methods:    []models.HTTPMethod{models.MethodGET},
+			FilePath:   "routes.go",
+			LineNumber: 30,
+			Framework:  models.FrameworkFiber,
+			Authorization: models.AuthorizationInfo{
+				RequiresAuth:     true,
+				AuthDependencies: []string{"AuthMiddleware"},
+			},
+		},
+	}
+
+	findings := rule.EvaluateProject(result, astutil.NewSourceSet("/project"))
+	require.Len(t, findings, 1)
+	assert.Equal(t, "AP010", findings[0].RuleID)
+	assert.Equal(t, models.SeverityHigh, findings[0].Severity)
+	assert.Equal(t, "/admin/x", findings[0].Endpoint.Route)
+	require.Len(t, findings[0].RelatedEndpoints, 1)
+	assert.Equal(t, 12, findings[0].RelatedEndpoints[0].LineNumber)
+}
+
+func TestAP010_RouteConflict_SeparateMains(t *testing.T) {
+	loader := astutil.NewSourceLoader()
+	sources := astutil.NewSourceSet("/project")
+	for path, code := range map[string]string{
+		"/project/cmd/a/main.go": `package main
+
+import "example.com/app/internal/api"
+
+func main() {
+	r := gin.New()
+	r.GET("/health", health)
+	r.GET("/users", listUsers)
+	api.Register(r)
+}`,
+		"/project/cmd/b/main.go": `package main
+
+import "example.com/app/internal/api"
+
+func main() {
+	r := gin.New()
+	r.GET("/health", health)
+	api.Register(r)
+}`,
+		"/project/internal/api/routes.go": `package api
+
+func Register(r *gin.Engine) {
+	r.GET("/users", listUsers)
+}`,
+		"/project/internal/api/routes_test.go": `package api
+
+func TestHealth(t *testing.T) {
+	r := gin.New()
+	r.GET("/health", health)
+}`,
+	} {
+		source, err := loader.ParseContent(path, code)
+		require.NoError(t, err)
+		sources.Add(source)
+	}
+
+	endpoint := func(route, file string, line int) *models.Endpoint {
+		return &models.Endpoint{
+			Route: route, Methods: []models.HTTPMethod{models.MethodGET}, FilePath: file, LineNumber: line,
+			Framework: models.FrameworkGin, Authorization: models.NewAuthorizationInfo(),
+		}
+	}
+	result := models.NewScanResult("/project")
+	result.Endpoints = []*models.Endpoint{
+		endpoint("/health", "/project/cmd/a/main.go", 7),
+		endpoint("/users", "/project/cmd/a/main.go", 8),
+		endpoint("/health", "/project/cmd/b/main.go", 7),
+		endpoint("/users", "/project/internal/api/routes.go", 4),
+		endpoint("/health", "/project/internal/api/routes_test.go", 5),
+	}
+
+	// Only /users collides: both registrations are served by cmd/a
+	findings := NewAP010RouteConflict().EvaluateProject(result, sources)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "/users", findings[0].Endpoint.Route)
+	assert.Contains(t, findings[0].Message, "registered more than once")
+}
+
+func TestAP011_InconsistentSiblings(t *testing.T) {
+	rule := NewAP011InconsistentSiblings()
+
+	protected := models.AuthorizationInfo{
+		RequiresAuth:     true,
+		AuthDependencies: []string{"JWTMiddleware"},
+	}
+
+	result := models.NewScanResult("/project")
+	result.Endpoints = []*models.Endpoint{
+		{
+			Route: "/users/:id", Methods: []models.HTTPMethod{models.MethodGET},
+			FilePath: "users.go", LineNumber: 10, Framework: models.FrameworkGin,
+			Authorization: protected, Classification: models.ClassificationAuthenticated,
+		},
+		{
+			Route: "/users/:id", Methods: []models.HTTPMethod{models.MethodPUT},
+			FilePath: "users.go", LineNumber: 11, Framework: models.FrameworkGin,
+			Authorization: models.NewAuthorizationInfo(), Classification: models.ClassificationPublic,
+		},
+		{
+			Route: "/v1/orders", Methods: []models.HTTPMethod{models.MethodGET},
+			FilePath: "orders.go", LineNumber: 5, Framework: models.FrameworkGin,
+			Authorization: protected, Classification: models.ClassificationAuthenticated,
+		},
+		{
+			Route: "/v2/orders", Methods: []models.HTTPMethod{models.MethodGET},
+			FilePath: "orders.go", LineNumber: 6, Framework: models.FrameworkGin,
+			Authorization: models.NewAuthorizationInfo(), Classification: models.ClassificationPublic,
+		},
+		{
+			Route: "/health", Methods: []models.HTTPMethod{models.MethodGET},
+			FilePath: "orders.go", LineNumber: 7, Framework: models.FrameworkGin,
+			Authorization: models.NewAuthorizationInfo(), Classification: models.ClassificationPublic,
+		},
+	}
+
+	findings := rule.EvaluateProject(result, astutil.NewSourceSet("/project"))
+	require.Len(t, findings, 2)
+
+	flagged := make(map[string]models.Severity)
+	for _, f := range findings {
+		assert.Equal(t, "AP011", f.RuleID)
+		assert.NotEmpty(t, f.RelatedEndpoints)
+		flagged[f.Endpoint.FullRoute()+" "+f.Endpoint.DisplayMethods()] = f.Severity
+	}
+	assert.Equal(t, models.SeverityHigh, flagged["/users/:id PUT"])
+	assert.Equal(t, models.SeverityMedium, flagged["/v2/orders GET"])
+}
+
+func TestAP011_InconsistentSiblings_WeakerWrite(t *testing.T) {
+	rule := NewAP011InconsistentSiblings()
+
+	result := models.NewScanResult("/project")
+	result.Endpoints = []*models.Endpoint{
+		{
+			Route: "/items/:id", Methods: []models.HTTPMethod{models.MethodGET},
+			FilePath: "items.go", LineNumber: 10, Framework: models.FrameworkEcho,
+			Authorization:  models.AuthorizationInfo{RequiresAuth: true, AuthDependencies: []string{"JWT"}},
+			Classification: models.ClassificationAuthenticated,
+		},
+		{
+			Route: "/items/:id", Methods: []models.HTTPMethod{models.MethodPUT},
+			FilePath: "items.go", LineNumber: 11, Framework: models.FrameworkEcho,
+			Authorization: models.NewAuthorizationInfo(), Classification: models.ClassificationPublic,
+		},
+	}
+
+	findings := rule.EvaluateProject(result, astutil.NewSourceSet("/project"))
+	require.Len(t, findings, 1)
+	assert.Equal(t, "/items/:id", findings[0].Endpoint.Route)
+	assert.Equal(t, "PUT", findings[0].Endpoint.DisplayMethods())
+	assert.Equal(t, models.SeverityHigh, findings[0].Severity)
+}
+
+func TestAP011_InconsistentSiblings_PublicMajority(t *testing.T) {
+	rule := NewAP011InconsistentSiblings()
+
+	public := func(route string, line int) *models.Endpoint {
+		return &models.Endpoint{
+			Route: route, Methods: []models.HTTPMethod{models.MethodGET},
+			FilePath: "main.go", LineNumber: line, Framework: models.FrameworkGin, RouterPrefix: "/api",
+			Authorization: models.NewAuthorizationInfo(), Classification: models.ClassificationPublic,
+		}
+	}
+
+	result := models.NewScanResult("/project")
+	result.Endpoints = []*models.Endpoint{
+		public("/products", 10),
+		public("/categories", 11),
+		public("/orders", 12),
+		public("/reviews", 13),
+		{
+			Route: "/orders", Methods: []models.HTTPMethod{models.MethodPOST},
+			FilePath: "main.go", LineNumber: 14, Framework: models.FrameworkGin, RouterPrefix: "/api",
+			Authorization:  models.AuthorizationInfo{RequiresAuth: true, AuthDependencies: []string{"AuthRequired"}},
+			Classification: models.ClassificationAuthenticated,
+		},
+	}
+
+	assert.Empty(t, rule.EvaluateProject(result, astutil.NewSourceSet("/project")))
+}
+
+func TestAP011_InconsistentSiblings_ReceiverType(t *testing.T) {
+	rule := NewAP011InconsistentSiblings()
+	loader := astutil.NewSourceLoader()
+
+	source, err := loader.ParseContent("/project/main.go", `package main
+
+import "github.com/gin-gonic/gin"
+
+type UserHandler struct{}
+type OrderHandler struct{}
+
+func (h *UserHandler) List(c *gin.Context)   {}
+func (h *UserHandler) Get(c *gin.Context)    {}
+func (h *UserHandler) Export(c *gin.Context) {}
+func (h *OrderHandler) List(c *gin.Context)  {}
+
+func users(r *gin.Engine, h *UserHandler) {
+	r.GET("/people", h.List)
+	r.GET("/members", h.Get)
+	r.GET("/export", h.Export)
+}
+
+func orders(r *gin.Engine, h *OrderHandler) {
+	r.GET("/shipments", h.List)
+}
+`)
+	require.NoError(t, err)
+	sources := astutil.NewSourceSet("/project")
+	sources.Add(source)
+
+	protected := models.AuthorizationInfo{RequiresAuth: true, AuthDependencies: []string{"JWTMiddleware"}}
+	endpoint := func(route, handler string, line int, auth models.AuthorizationInfo, class models.SecurityClassification) *models.Endpoint {
+		return &models.Endpoint{
+			Route: route, Methods: []models.HTTPMethod{models.MethodGET}, FunctionName: handler,
+			FilePath: "/project/main.go", LineNumber: line, Framework: models.FrameworkGin,
+			Authorization: auth, Classification: class,
+		}
+	}
+
+	result := models.NewScanResult("/project")
+	result.Endpoints = []*models.Endpoint{
+		endpoint("/people", "h.List", 14, protected, models.ClassificationAuthenticated),
+		endpoint("/members", "h.Get", 15, protected, models.ClassificationAuthenticated),
+		endpoint("/export", "h.Export", 16, models.NewAuthorizationInfo(), models.ClassificationPublic),
+		endpoint("/shipments", "h.List", 20, models.NewAuthorizationInfo(), models.ClassificationPublic),
+	}
+
+	findings := rule.EvaluateProject(result, sources)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "/export", findings[0].Endpoint.Route)
+	assert.Len(t, findings[0].RelatedEndpoints, 2)
+}
+
+func TestAP012_MixedHandlerExposure(t *testing.T) {
+	rule := NewAP012MixedHandlerExposure()
+	loader := astutil.NewSourceLoader()
+
+	legacy, err := loader.ParseContent("/project/legacy/routes.go", `package legacy
+
+import "example.com/app/handlers"
+
+func Register() {}
+`)
+	require.NoError(t, err)
+	handlers, err := loader.ParseContent("/project/handlers/routes.go", `package handlers
+
+func GetUser() {}
+`)
+	require.NoError(t, err)
+
+	sources := astutil.NewSourceSet("/project")
+	sources.Add(legacy)
+	sources.Add(handlers)
+
+	result := models.NewScanResult("/project")
+	result.Endpoints = []*models.Endpoint{
+		{
+			Route: "/api/v2/users/:id", Methods: []models.HTTPMethod{models.MethodGET},
+			FilePath: "/project/handlers/routes.go", LineNumber: 10, Framework: models.FrameworkGin,
+			FunctionName: "GetUser", Classification: models.ClassificationAuthenticated,
+			Authorization: models.AuthorizationInfo{RequiresAuth: true, AuthDependencies: []string{"JWTAuth"}},
+		},
+		{
+			Route: "/users/:id", Methods: []models.HTTPMethod{models.MethodGET},
+			FilePath: "/project/legacy/routes.go", LineNumber: 20, Framework: models.FrameworkGin,
+			FunctionName: "handlers.GetUser", Classification: models.ClassificationPublic,
+			Authorization: models.NewAuthorizationInfo(),
+		},
+		{
+			Route: "/status", Methods: []models.HTTPMethod{models.MethodGET},
+			FilePath: "/project/legacy/routes.go", LineNumber: 21, Framework: models.FrameworkGin,
+			FunctionName: "GetUser", Classification: models.ClassificationPublic,
+			Authorization: models.NewAuthorizationInfo(),
+		},
+	}
+
+	findings := rule.EvaluateProject(result, sources)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "AP012", findings[0].RuleID)
+	assert.Equal(t, "/users/:id", findings[0].Endpoint.Route)
+	require.Len(t, findings[0].RelatedEndpoints, 1)
+	assert.Equal(t, "/api/v2/users/:id", findings[0].RelatedEndpoints[0].Route)
+}
+
+func TestAP012_MixedHandlerExposure_ReceiverType(t *testing.T) {
+	rule := NewAP012MixedHandlerExposure()
+	loader := astutil.NewSourceLoader()
+
+	source, err := loader.ParseContent("/project/main.go", `package main
+
+import "github.com/gin-gonic/gin"
+
+type UserHandler struct{}
+type OrderHandler struct{}
+
+func (h *UserHandler) List(c *gin.Context)  {}
+func (h *OrderHandler) List(c *gin.Context) {}
+
+func users(r *gin.RouterGroup, h *UserHandler) {
+	r.GET("/users", h.List)
+}
+
+func orders(r *gin.Engine, h *OrderHandler) {
+	r.GET("/orders", h.List)
+}
+
+func legacy(r *gin.Engine, uh *UserHandler) {
+	r.GET("/all-users", uh.List)
+}
+`)
+	require.NoError(t, err)
+	sources := astutil.NewSourceSet("/project")
+	sources.Add(source)
+
+	result := models.NewScanResult("/project")
+	result.Endpoints = []*models.Endpoint{
+		{
+			Route: "/users", Methods: []models.HTTPMethod{models.MethodGET},
+			FilePath: "/project/main.go", LineNumber: 12, Framework: models.FrameworkGin,
+			FunctionName: "h.List", Classification: models.ClassificationAuthenticated,
+			Authorization: models.AuthorizationInfo{RequiresAuth: true, AuthDependencies: []string{"JWTAuth"}},
+		},
+		{
+			Route: "/orders", Methods: []models.HTTPMethod{models.MethodGET},
+			FilePath: "/project/main.go", LineNumber: 16, Framework: models.FrameworkGin,
+			FunctionName: "h.List", Classification: models.ClassificationPublic,
+			Authorization: models.NewAuthorizationInfo(),
+		},
+		{
+			Route: "/all-users", Methods: []models.HTTPMethod{models.MethodGET},
+			FilePath: "/project/main.go", LineNumber: 20, Framework: models.FrameworkGin,
+			FunctionName: "uh.List", Classification: models.ClassificationPublic,
+			Authorization: models.NewAuthorizationInfo(),
+		},
+	}
+
+	// The public /orders route serves OrderHandler.List, not the protected UserHandler.List
+	findings := rule.EvaluateProject(result, sources)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "/all-users", findings[0].Endpoint.Route)
+	require.Len(t, findings[0].RelatedEndpoints, 1)
+	assert.Equal(t, "/users", findings[0].RelatedEndpoints[0].Route)
+}
+
+func TestFindCORSPolicies(t *testing.T) {
+	loader := astutil.NewSourceLoader()
+
+	tests := []struct {
+		name        string
+		code        string
+		middleware  string
+		anyOrigin   bool
+		credentials bool
+		reflects    bool
+	}{
+		{
+			name: "gin-contrib literal",
+			code: `package main
+
+import "github.com/gin-contrib/cors"
+
+func setup() {
+	r.Use(cors.New(cors.Config{AllowOrigins: []string{"*"}, AllowCredentials: true}))
+}`,
+			middleware: "cors.New", anyOrigin: true, credentials: true,
+		},
+		{
+			name: "gin-contrib default config with assignments",
+			code: `package main
+
+import "github.com/gin-contrib/cors"
+
+func setup() {
+	config := cors.DefaultConfig()
+	config.AllowAllOrigins = true
+	r.Use(cors.New(config))
+}`,
+			middleware: "cors.New", anyOrigin: true,
+		},
+		{
+			name: "echo reflected origin",
+			code: `package main
+
+import "github.com/labstack/echo/v4/middleware"
+
+func setup() {
+	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOriginFunc:  func(origin string) (bool, error) { return true, nil },
+		AllowCredentials: true,
+	}))
+}`,
+			middleware: "middleware.CORSWithConfig", credentials: true, reflects: true,
+		},
+		{
+			name: "fiber default",
+			code: `package main
+
+import "github.com/gofiber/fiber/v2/middleware/cors"
+
+func setup() {
+	app.Use(cors.New())
+}`,
+			middleware: "cors.New", anyOrigin: true,
+		},
+		{
+			name: "chi named origin func",
+			code: `package main
+
+import "github.com/go-chi/cors"
+
+func setup() {
+	r.Use(cors.Handler(cors.Options{AllowOriginFunc: allowAll}))
+}
+
+func allowAll(r *http.Request, origin string) bool { return true }`,
+			middleware: "cors.Handler", reflects: true,
+		},
+		{
+			name: "rs/cors explicit origins",
+			code: `package main
+
+import "github.com/rs/cors"
+
+func setup() {
+	c := cors.New(cors.Options{AllowedOrigins: []string{"https://app.example.com"}, AllowCredentials: true})
+	http.ListenAndServe(":8080", c.Handler(mux))
+}`,
+			middleware: "cors.New", credentials: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := loader.ParseContent("/project/main.go", tt.code)
+			require.NoError(t, err)
+			sources := astutil.NewSourceSet("/project")
+			sources.Add(source)
+
+			policies := findCORSPolicies(sources)
+			require.Len(t, policies, 1)
+			assert.Equal(t, tt.middleware, policies[0].Middleware)
+			assert.Equal(t, tt.anyOrigin, policies[0].AllowsAnyOrigin())
+			assert.Equal(t, tt.credentials, policies[0].AllowCredentials)
+			assert.Equal(t, tt.reflects, policies[0].ReflectsAnyOrigin)
+		})
+	}
+}
+
+// corsScanResult returns endpoints where only the /api group is covered by the CORS middleware.
+// The endpoints are registered by the source returned by corsSources.
+func corsScanResult() *models.ScanResult {
+	result := models.NewScanResult("/project")
+	result.Endpoints = []*models.Endpoint{
+		{
+			Route: "/health", Methods: []models.HTTPMethod{models.MethodGET},
+			FilePath: "/project/main.go", LineNumber: 11, Framework: models.FrameworkGin,
+			Classification: models.ClassificationPublic, Authorization: models.NewAuthorizationInfo(),
+			Middleware: []string{"gin.Logger"},
+		},
+		{
+			Route: "/users", RouterPrefix: "/api", Methods: []models.HTTPMethod{models.MethodGET},
+			FilePath: "/project/main.go", LineNumber: 12, Framework: models.FrameworkGin,
+			Classification: models.ClassificationAuthenticated,
+			Authorization:  models.AuthorizationInfo{RequiresAuth: true, AuthDependencies: []string{"JWTAuth"}},
+			Middleware:     []string{"cors.New", "JWTAuth"},
+		},
+		{
+			Route: "/docs", RouterPrefix: "/api", Methods: []models.HTTPMethod{models.MethodGET},
+			FilePath: "/project/main.go", LineNumber: 13, Framework: models.FrameworkGin,
+			Classification: models.ClassificationPublic, Authorization: models.NewAuthorizationInfo(),
+			Middleware: []string{"cors.New"},
+		},
+	}
+	return result
+}
+
+// corsSources parses a gin-contrib/cors configuration into a source set.
+func corsSources(t *testing.T, config string) *astutil.SourceSet {
+	source, err := astutil.NewSourceLoader().ParseContent("/project/main.go", `package main
+
+import (
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+func setup(r *gin.Engine) {
+	api := r.Group("/api")
+	api.Use(cors.New(corsConfig))
+	r.GET("/health", health)
+	api.GET("/users", JWTAuth(), users)
+	api.GET("/docs", docs)
+}
+
+var corsConfig = `+config)
+	require.NoError(t, err)
+
+	sources := astutil.NewSourceSet("/project")
+	sources.Add(source)
+	return sources
+}
+
+func TestAP013_CORSWildcardCredentials(t *testing.T) {
+	rule := NewAP013CORSWildcardCredentials()
+
+	findings := rule.EvaluateProject(corsScanResult(),
+		corsSources(t, `cors.Config{AllowAllOrigins: true, AllowCredentials: true}`))
+	require.Len(t, findings, 2)
+	for _, f := range findings {
+		assert.Equal(t, "AP013", f.RuleID)
+		assert.Equal(t, "/api", f.Endpoint.RouterPrefix)
+	}
+
+	assert.Empty(t, rule.EvaluateProject(corsScanResult(),
+		corsSources(t, `cors.Config{AllowOrigins: []string{"https://app.example.com"}, AllowCredentials: true}`)))
+}
+
+func TestAP013_CORSWildcardCredentials_WrappedMux(t *testing.T) {
+	rule := NewAP013CORSWildcardCredentials()
+
+	source, err := astutil.NewSourceLoader().ParseContent("/project/main.go", `package main
+
+import (
+	"net/http"
+
+	"github.com/rs/cors"
+)
+
+func main() {
+	c := cors.New(cors.Options{AllowedOrigins: []string{"*"}, AllowCredentials: true})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/widgets", widgets)
+	admin := http.NewServeMux()
+	admin.HandleFunc("/admin/users", users)
+	go http.ListenAndServe(":9090", admin)
+	http.ListenAndServe(":8080", c.Handler(mux))
+}
+`)
+	require.NoError(t, err)
+	sources := astutil.NewSourceSet("/project")
+	sources.Add(source)
+
+	result := models.NewScanResult("/project")
+	result.Endpoints = []*models.Endpoint{
+		{
+			Route: "/widgets", Methods: []models.HTTPMethod{models.MethodGET},
+			FilePath: "/project/main.go", LineNumber: 12, Framework: models.FrameworkNetHTTP,
+			Classification: models.ClassificationPublic, Authorization: models.NewAuthorizationInfo(),
+		},
+		{
+			Route: "/admin/users", Methods: []models.HTTPMethod{models.MethodGET},
+			FilePath: "/project/main.go", LineNumber: 14, Framework: models.FrameworkNetHTTP,
+			Classification: models.ClassificationPublic, Authorization: models.NewAuthorizationInfo(),
+		},
+	}
+
+	findings := rule.EvaluateProject(result, sources)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "/widgets", findings[0].Endpoint.Route)
+}
+
+func TestAP014_CORSReflectedOrigin(t *testing.T) {
+	rule := NewAP014CORSReflectedOrigin()
+
+	findings := rule.EvaluateProject(corsScanResult(),
+		corsSources(t, `cors.Config{AllowOriginFunc: func(origin string) bool { return true }, AllowCredentials: true}`))
+	require.Len(t, findings, 2)
+	assert.Equal(t, models.SeverityCritical, findings[0].Severity)
+
+	// A callback that validates the origin is not reported
+	assert.Empty(t, rule.EvaluateProject(corsScanResult(),
+		corsSources(t, `cors.Config{AllowOriginFunc: func(origin string) bool {
+		if origin == "https://app.example.com" {
+			return true
+		}
+		return false
+	}}`)))
+}
+
+func TestAP015_CORSWildcardAuthenticated(t *testing.T) {
+	rule := NewAP015CORSWildcardAuthenticated()
+
+	findings := rule.EvaluateProject(corsScanResult(), corsSources(t, `cors.Config{AllowAllOrigins: true}`))
+	require.Len(t, findings, 1)
+	assert.Equal(t, "/api/users", findings[0].Endpoint.FullRoute())
+
+	// Wildcard with credentials is reported by AP013 only
+	assert.Empty(t, rule.EvaluateProject(corsScanResult(),
+		corsSources(t, `cors.Config{AllowAllOrigins: true, AllowCredentials: true}`)))
+}
+
+func TestAP015_CORSWildcardAuthenticated_PolicyPerGroup(t *testing.T) {
+	rule := NewAP015CORSWildcardAuthenticated()
+
+	source, err := astutil.NewSourceLoader().ParseContent("/project/main.go", `package main
+
+import (
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+func main() {
+	r := gin.New()
+	public := r.Group("/public")
+	public.Use(cors.New(cors.Config{AllowOrigins: []string{"*"}}))
+	api := r.Group("/api")
+	api.Use(cors.New(cors.Config{AllowOrigins: []string{"https://app.example.com"}, AllowCredentials: true}))
+	setupRoutes(public, api)
+}
+
+func setupRoutes(public, api *gin.RouterGroup) {
+	public.GET("/profile", JWTAuth(), profile)
+	api.GET("/me", JWTAuth(), me)
+}
+`)
+	require.NoError(t, err)
+	sources := astutil.NewSourceSet("/project")
+	sources.Add(source)
+
+	protected := models.AuthorizationInfo{RequiresAuth: true, AuthDependencies: []string{"JWTAuth"}}
+	result := models.NewScanResult("/project")
+	result.Endpoints = []*models.Endpoint{
+		{
+			Route: "/profile", RouterPrefix: "/public", Methods: []models.HTTPMethod{models.MethodGET},
+			FilePath: "/project/main.go", LineNumber: 18, Framework: models.FrameworkGin,
+			Classification: models.ClassificationAuthenticated, Authorization: protected,
+			Middleware: []string{"cors.New", "JWTAuth"},
+		},
+		{
+			Route: "/me", RouterPrefix: "/api", Methods: []models.HTTPMethod{models.MethodGET},
+			FilePath: "/project/main.go", LineNumber: 19, Framework: models.FrameworkGin,
+			Classification: models.ClassificationAuthenticated, Authorization: protected,
+			Middleware: []string{"cors.New", "JWTAuth"},
+		},
+	}
+
+	findings := rule.EvaluateProject(result, sources)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "/public/profile", findings[0].Endpoint.FullRoute())
+}
+
+func TestAP016_MissingCSRF(t *testing.T) {
+	rule := NewAP016MissingCSRF()
+	loader := astutil.NewSourceLoader()
+
+	tests := []struct {
+		name     string
+		code     string
+		endpoint *models.Endpoint
+		flagged  bool
+	}{
+		{
+			name: "gin session middleware without csrf",
+			code: `package main
+
+import "github.com/gin-contrib/sessions"
+`,
+			endpoint: &models.Endpoint{
+				Route: "/profile", Methods: []models.HTTPMethod{models.MethodPOST},
+				Middleware:    []string{"sessions.Sessions", "RequireLogin"},
+				Authorization: models.AuthorizationInfo{RequiresAuth: true, AuthDependencies: []string{"RequireLogin"}},
+			},
+			flagged: true,
+		},
+		{
+			name: "auth middleware reading a cookie",
+			code: `package main
+
+func AuthRequired() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, _ := c.Cookie("token")
+		_ = token
+	}
+}
+`,
+			endpoint: &models.Endpoint{
+				Route: "/orders", Methods: []models.HTTPMethod{models.MethodDELETE},
+				Middleware:    []string{"AuthRequired"},
+				Authorization: models.AuthorizationInfo{RequiresAuth: true, AuthDependencies: []string{"AuthRequired"}},
+			},
+			flagged: true,
+		},
+		{
+			name: "csrf middleware in chain",
+			code: `package main
+
+import "github.com/gofiber/fiber/v2/middleware/csrf"
+`,
+			endpoint: &models.Endpoint{
+				Route: "/profile", Methods: []models.HTTPMethod{models.MethodPOST},
+				Middleware:    []string{"csrf.New", "sessionAuth"},
+				Authorization: models.AuthorizationInfo{RequiresAuth: true, AuthDependencies: []string{"sessionAuth"}},
+			},
+		},
+		{
+			name: "router wrapped with gorilla csrf",
+			code: `package main
+
+import (
+	"net/http"
+
+	"github.com/gorilla/csrf"
+)
+
+func main() {
+	r := http.NewServeMux()
+	r.HandleFunc("/profile", sessionAuth(profile))
+	http.ListenAndServe(":8000", csrf.Protect(key)(r))
+}
+`,
+			endpoint: &models.Endpoint{
+				Route: "/profile", Methods: []models.HTTPMethod{models.MethodPOST}, LineNumber: 11,
+				Authorization: models.AuthorizationInfo{RequiresAuth: true, AuthDependencies: []string{"sessionAuth"}},
+			},
+		},
+		{
+			name: "another router wrapped with nosurf",
+			code: `package main
+
+import (
+	"net/http"
+
+	"github.com/justinas/nosurf"
+)
+
+func main() {
+	r := http.NewServeMux()
+	r.HandleFunc("/profile", sessionAuth(profile))
+	forms := http.NewServeMux()
+	go http.ListenAndServe(":8001", nosurf.New(forms))
+	http.ListenAndServe(":8000", r)
+}
+`,
+			endpoint: &models.Endpoint{
+				Route: "/profile", Methods: []models.HTTPMethod{models.MethodPOST}, LineNumber: 11,
+				Authorization: models.AuthorizationInfo{RequiresAuth: true, AuthDependencies: []string{"sessionAuth"}},
+			},
+			flagged: true,
+		},
+		{
+			name: "csrf token helper without the middleware",
+			code: `package main
+
+import "github.com/gorilla/csrf"
+
+func form(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-CSRF-Token", csrf.Token(r))
+}
+`,
+			endpoint: &models.Endpoint{
+				Route: "/profile", Methods: []models.HTTPMethod{models.MethodPOST},
+				Authorization: models.AuthorizationInfo{RequiresAuth: true, AuthDependencies: []string{"sessionAuth"}},
+			},
+			flagged: true,
+		},
+		{
+			name: "bearer token auth",
+			code: `package main
+`,
+			endpoint: &models.Endpoint{
+				Route: "/profile", Methods: []models.HTTPMethod{models.MethodPOST},
+				Middleware:    []string{"JWTAuth"},
+				Authorization: models.AuthorizationInfo{RequiresAuth: true, AuthDependencies: []string{"JWTAuth"}},
+			},
+		},
+		{
+			name: "read endpoint",
+			code: `package main
+`,
+			endpoint: &models.Endpoint{
+				Route: "/profile", Methods: []models.HTTPMethod{models.MethodGET},
+				Authorization: models.AuthorizationInfo{RequiresAuth: true, AuthDependencies: []string{"sessionAuth"}},
+			},
+		},
+		{
+			name: "state-changing GET behind csrf middleware",
+			code: `package main
+
+import (
+	"github.com/gin-contrib/sessions"
+	"github.com/utrack/gin-csrf"
+)
+`,
+			endpoint: &models.Endpoint{
+				Route: "/orders/:id/cancel", Methods: []models.HTTPMethod{models.MethodGET},
+				Middleware:    []string{"sessions.Sessions", "csrf.Middleware", "RequireLogin"},
+				Authorization: models.AuthorizationInfo{RequiresAuth: true, AuthDependencies: []string{"RequireLogin"}},
+				Metadata:      map[string]string{models.MetadataStateChanging: "db.Exec"},
+			},
+			flagged: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := loader.ParseContent("/project/main.go", tt.code)
+			require.NoError(t, err)
+			sources := astutil.NewSourceSet("/project")
+			sources.Add(source)
+
+			tt.endpoint.FilePath = "/project/main.go"
+			result := models.NewScanResult("/project")
+			result.Endpoints = []*models.Endpoint{tt.endpoint}
+
+			findings := rule.EvaluateProject(result, sources)
+			if tt.flagged {
+				require.Len(t, findings, 1)
+				assert.Equal(t, "AP016", findings[0].RuleID)
+			} else {
+				assert.Empty(t, findings)
+			}
+		})
+	}
+}
+
+func TestAP017_MissingRateLimit(t *testing.T) {
+	loader := astutil.NewSourceLoader()
+	source, err := loader.ParseContent("/project/main.go", `package main
+
+import (
+	"github.com/go-chi/httprate"
+	"golang.org/x/time/rate"
+)
+
+var limiter = rate.NewLimiter(1, 5)
+
+func limitLogins(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !limiter.Allow() {
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+`)
+	require.NoError(t, err)
+	sources := astutil.NewSourceSet("/project")
+	sources.Add(source)
+
+	endpoint := func(route string, method models.HTTPMethod, middleware ...string) *models.Endpoint {
+		return &models.Endpoint{
+			Route: route, Methods: []models.HTTPMethod{method}, FilePath: "/project/main.go",
+			Framework: models.FrameworkChi, Middleware: middleware, Authorization: models.NewAuthorizationInfo(),
+		}
+	}
+
+	result := models.NewScanResult("/project")
+	result.Endpoints = []*models.Endpoint{
+		endpoint("/auth/login", models.MethodPOST, "middleware.Logger"),
+		endpoint("/auth/otp/verify", models.MethodPOST, "middleware.Logger"),
+		endpoint("/auth/token", models.MethodPOST, "httprate.LimitByIP"),
+		endpoint("/auth/reset", models.MethodPOST, "limitLogins"),
+		endpoint("/auth/signup", models.MethodPOST, "BruteForceGuard"),
+		endpoint("/magic-link/login", models.MethodGET, "middleware.Logger"),
+		endpoint("/otp/verify", models.MethodGET, "httprate.LimitByIP"),
+		endpoint("/users", models.MethodPOST, "middleware.Logger"),
+		endpoint("/orders/:id/confirm", models.MethodPOST, "middleware.Logger"),
+		endpoint("/payments/:id/verify", models.MethodPOST, "middleware.Logger"),
+		endpoint("/users/:id/password", models.MethodPUT, "middleware.Logger"),
+		endpoint("/devices/:id/reset", models.MethodPOST, "middleware.Logger"),
+		endpoint("/account/password/reset", models.MethodPOST, "middleware.Logger"),
+	}
+
+	findings := NewAP017MissingRateLimit([]string{"BruteForceGuard"}).EvaluateProject(result, sources)
+	require.Len(t, findings, 4)
+
+	severities := make(map[string]models.Severity)
+	for _, f := range findings {
+		assert.Equal(t, "AP017", f.RuleID)
+		severities[f.Endpoint.Route] = f.Severity
+	}
+	assert.Equal(t, models.SeverityMedium, severities["/auth/login"])
+	assert.Equal(t, models.SeverityHigh, severities["/auth/otp/verify"])
+	assert.Equal(t, models.SeverityMedium, severities["/magic-link/login"])
+	assert.Equal(t, models.SeverityMedium, severities["/account/password/reset"])
+
+	// Custom middleware names come from the engine settings
+	engine := NewEngineWithSettings([]string{"AP017"}, Settings{RateLimitMiddleware: []string{"BruteForceGuard"}})
+	assert.Len(t, engine.EvaluateProject(result, sources), 4)
+	assert.Len(t, NewEngine([]string{"AP017"}).EvaluateProject(result, sources), 5)
+}
+
+func TestAP018_HardcodedCredentials(t *testing.T) {
+	rule := NewAP018HardcodedCredentials()
+	loader := astutil.NewSourceLoader()
+
+	tests := []struct {
+		name    string
+		code    string
+		kind    string
+		snippet string
+	}{
+		{
+			name: "gin basic auth accounts",
+			code: `package main
+
+import "github.com/gin-gonic/gin"
+
+func setup(r *gin.Engine) {
+	r.Use(gin.BasicAuth(gin.Accounts{"admin": "s3cr3t"}))
+}`,
+			kind:    "password",
+			snippet: `gin.BasicAuth(gin.Accounts{"admin": "****"})`,
+		},
+		{
+			name: "echojwt config variable",
+			code: `package main
+
+import echojwt "github.com/labstack/echo-jwt/v4"
+
+func setup() {
+	config := echojwt.Config{SigningKey: []byte("changeme"), TokenLookup: "header:Authorization"}
+	e.Use(echojwt.WithConfig(config))
+}`,
+			kind:    "signing key",
+			snippet: `echojwt.WithConfig(config)`,
+		},
+		{
+			name: "jwtauth signing key argument",
+			code: `package main
+
+import "github.com/go-chi/jwtauth/v5"
+
+var tokenAuth = jwtauth.New("HS256", []byte("secret"), nil)`,
+			kind:    "signing key",
+			snippet: `jwtauth.New("HS256", []byte("****"), nil)`,
+		},
+		{
+			name: "fiber basicauth users",
+			code: `package main
+
+import "github.com/gofiber/fiber/v2/middleware/basicauth"
+
+func setup() {
+	app.Use(basicauth.New(basicauth.Config{Users: map[string]string{"john": "doe"}}))
+}`,
+			kind:    "password",
+			snippet: `basicauth.New(basicauth.Config{Users: map[string]string{"john": "****"}})`,
+		},
+		{
+			name: "echo basic auth validator",
+			code: `package main
+
+import "github.com/labstack/echo/v4/middleware"
+
+func setup() {
+	e.Use(middleware.BasicAuth(func(username, password string, c echo.Context) (bool, error) {
+		return username == "joe" && password == "hunter2", nil
+	}))
+}`,
+			kind: "password",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := loader.ParseContent("/project/main.go", tt.code)
+			require.NoError(t, err)
+			sources := astutil.NewSourceSet("/project")
+			sources.Add(source)
+
+			findings := rule.EvaluateProject(models.NewScanResult("/project"), sources)
+			require.Len(t, findings, 1)
+			assert.Equal(t, "AP018", findings[0].RuleID)
+			assert.Contains(t, findings[0].Message, tt.kind)
+			if tt.snippet != "" {
+				assert.Equal(t, tt.snippet, findings[0].Snippet)
+			}
+			assert.NotContains(t, findings[0].Snippet, "hunter2")
+		})
+	}
+
+	// Credentials read from the environment are not reported
+	source, err := loader.ParseContent("/project/env.go", `package main
+
+import "github.com/go-chi/jwtauth/v5"
+
+var tokenAuth = jwtauth.New("HS256", []byte(os.Getenv("JWT_SECRET")), nil)`)
+	require.NoError(t, err)
+	sources := astutil.NewSourceSet("/project")
+	sources.Add(source)
+	assert.Empty(t, rule.EvaluateProject(models.NewScanResult("/project"), sources))
+}
+
+func TestRedactedSnippet_Truncation(t *testing.T) {
+	loader := astutil.NewSourceLoader()
+	source, err := loader.ParseContent("/project/main.go", `package main
+
+var greeting = fmt.Sprintf("%s", "`+strings.Repeat("привет ", 30)+`")
+`)
+	require.NoError(t, err)
+
+	calls := astutil.FindCallExprs(source.AST)
+	require.Len(t, calls, 1)
+
+	snippet := redactedSnippet(source, calls[0], nil)
+	assert.True(t, utf8.ValidString(snippet))
+	assert.Equal(t, maxSnippetLength, utf8.RuneCountInString(snippet))
+	assert.True(t, strings.HasSuffix(snippet, "привет ..."))
+}
+
+func TestAP019_JWTWeakness(t *testing.T) {
+	rule := NewAP019JWTWeakness()
+	loader := astutil.NewSourceLoader()
+
+	tests := []struct {
+		name     string
+		code     string
+		issues   []string
+		severity models.Severity
+	}{
+		{
+			name: "unchecked method and short key",
+			code: `package main
+
+import "github.com/golang-jwt/jwt/v5"
+
+var jwtSecret = []byte("secret")
+
+func JWTAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, err := jwt.Parse(c.GetHeader("Authorization"), func(t *jwt.Token) (interface{}, error) {
+			return jwtSecret, nil
+		})
+		_, _ = token, err
+	}
+}`,
+			issues:   []string{"without checking token.Method", "6-byte signing key"},
+			severity: models.SeverityHigh,
+		},
+		{
+			name: "parse unverified in helper",
+			code: `package main
+
+import "github.com/golang-jwt/jwt/v5"
+
+func parseClaims(raw string) jwt.MapClaims {
+	claims := jwt.MapClaims{}
+	jwt.NewParser().ParseUnverified(raw, claims)
+	return claims
+}
+
+func JWTAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		_ = parseClaims(c.GetHeader("Authorization"))
+	}
+}`,
+			issues:   []string{"ParseUnverified"},
+			severity: models.SeverityCritical,
+		},
+		{
+			name: "none algorithm and skipped claims validation",
+			code: `package main
+
+import "github.com/golang-jwt/jwt/v5"
+
+func JWTAuth() gin.HandlerFunc {
+	parser := jwt.NewParser(jwt.WithoutClaimsValidation())
+	return func(c *gin.Context) {
+		parser.Parse(c.GetHeader("Authorization"), func(t *jwt.Token) (interface{}, error) {
+			return jwt.UnsafeAllowNoneSignatureType, nil
+		})
+	}
+}`,
+			issues:   []string{"'none' algorithm", "skips claims validation"},
+			severity: models.SeverityCritical,
+		},
+		{
+			name: "none listed as a valid method",
+			code: `package main
+
+import "github.com/golang-jwt/jwt/v5"
+
+func JWTAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		jwt.Parse(c.GetHeader("Authorization"), keyFunc, jwt.WithValidMethods([]string{"HS256", "none"}))
+	}
+}`,
+			issues:   []string{"'none' algorithm"},
+			severity: models.SeverityCritical,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := loader.ParseContent("/project/main.go", tt.code)
+			require.NoError(t, err)
+			sources := astutil.NewSourceSet("/project")
+			sources.Add(source)
+
+			protected := &models.Endpoint{
+				Route: "/api/orders", Methods: []models.HTTPMethod{models.MethodGET}, FilePath: "/project/main.go",
+				Authorization: models.AuthorizationInfo{RequiresAuth: true, AuthDependencies: []string{"JWTAuth"}},
+			}
+			public := &models.Endpoint{Route: "/health", Methods: []models.HTTPMethod{models.MethodGET}, FilePath: "/project/main.go"}
+			result := models.NewScanResult("/project")
+			result.Endpoints = []*models.Endpoint{protected, public}
+
+			findings := rule.EvaluateProject(result, sources)
+			require.Len(t, findings, 1)
+			assert.Equal(t, "AP019", findings[0].RuleID)
+			assert.Equal(t, tt.severity, findings[0].Severity)
+			for _, issue := range tt.issues {
+				assert.Contains(t, findings[0].Message, issue)
+			}
+			assert.Equal(t, []*models.Endpoint{protected}, findings[0].RelatedEndpoints)
+		})
+	}
+
+	// Pinned signing method, a key loaded from the environment, a key func rejecting 'none',
+	// and functions that are not reached from auth middleware are not reported
+	source, err := loader.ParseContent("/project/ok.go", `package main
+
+import "github.com/golang-jwt/jwt/v5"
+
+func JWTAuth(raw string) {
+	jwt.Parse(raw, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return []byte(os.Getenv("JWT_SECRET")), nil
+	})
+	jwt.Parse(raw, keyFunc, jwt.WithValidMethods([]string{"HS256"}))
+	jwt.Parse(raw, func(t *jwt.Token) (interface{}, error) {
+		if t.Method == jwt.SigningMethodNone {
+			return nil, errors.New("unsigned tokens are not accepted")
+		}
+		return []byte(os.Getenv("JWT_SECRET")), nil
+	})
+}
+
+func logTokenSubject(raw string) {
+	claims := jwt.MapClaims{}
+	jwt.NewParser().ParseUnverified(raw, claims)
+	log.Println(claims["sub"])
+}`)
+	require.NoError(t, err)
+	sources := astutil.NewSourceSet("/project")
+	sources.Add(source)
+	result := models.NewScanResult("/project")
+	result.Endpoints = []*models.Endpoint{{
+		Route: "/api/orders", Methods: []models.HTTPMethod{models.MethodGET}, FilePath: "/project/ok.go",
+		Authorization: models.AuthorizationInfo{RequiresAuth: true, AuthDependencies: []string{"JWTAuth"}},
+	}}
+	assert.Empty(t, rule.EvaluateProject(result, sources))
+}
+
+func TestAP020_DebugMode(t *testing.T) {
+	rule := NewAP020DebugMode()
+	loader := astutil.NewSourceLoader()
+
+	tests := []struct {
+		name     string
+		path     string
+		code     string
+		severity models.Severity
+		flagged  bool
+	}{
+		{
+			name: "gin debug mode",
+			path: "/project/main.go",
+			code: `package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	gin.SetMode(gin.DebugMode)
+}`,
+			severity: models.SeverityMedium,
+			flagged:  true,
+		},
+		{
+			name: "echo debug behind environment check",
+			path: "/project/main.go",
+			code: `package main
+
+import "github.com/labstack/echo/v4"
+
+func main() {
+	e := echo.New()
+	if os.Getenv("APP_ENV") == "dev" {
+		e.Debug = true
+	}
+}`,
+			severity: models.SeverityLow,
+			flagged:  true,
+		},
+		{
+			name: "echo debug on a struct field",
+			path: "/project/main.go",
+			code: `package main
+
+import "github.com/labstack/echo/v4"
+
+type server struct {
+	echo *echo.Echo
+}
+
+func (s *server) setup() {
+	s.echo.Debug = true
+}`,
+			severity: models.SeverityMedium,
+			flagged:  true,
+		},
+		{
+			name: "debug field of another type in an echo file",
+			path: "/project/main.go",
+			code: `package main
+
+import "github.com/labstack/echo/v4"
+
+type config struct {
+	Debug bool
+}
+
+func main() {
+	e := echo.New()
+	cfg := config{}
+	cfg.Debug = true
+	e.Start(":8080")
+}`,
+		},
+		{
+			name: "gin release mode",
+			path: "/project/main.go",
+			code: `package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	gin.SetMode(gin.ReleaseMode)
+}`,
+		},
+		{
+			name: "debug mode in tests",
+			path: "/project/main_test.go",
+			code: `package main
+
+import "github.com/gin-gonic/gin"
+
+func init() {
+	gin.SetMode(gin.DebugMode)
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := loader.ParseContent(tt.path, tt.code)
+			require.NoError(t, err)
+			sources := astutil.NewSourceSet("/project")
+			sources.Add(source)
+
+			findings := rule.EvaluateProject(models.NewScanResult("/project"), sources)
+			if !tt.flagged {
+				assert.Empty(t, findings)
+				return
+			}
+			require.Len(t, findings, 1)
+			assert.Equal(t, "AP020", findings[0].RuleID)
+			assert.Equal(t, tt.severity, findings[0].Severity)
+		})
+	}
+}
+
+func TestAP021_DirectoryListing(t *testing.T) {
+	rule := NewAP021DirectoryListing()
+
+	listing := &models.Endpoint{
+		Route: "/files/*filepath", Methods: []models.HTTPMethod{models.MethodGET},
+		Metadata: map[string]string{models.MetadataStaticDir: "./uploads", models.MetadataDirectoryListing: "true"},
+	}
+	findings := rule.Evaluate(listing)
+	require.Len(t, findings, 1)
+	assert.Contains(t, findings[0].Message, "./uploads")
+
+	noListing := &models.Endpoint{
+		Route: "/assets/*filepath", Methods: []models.HTTPMethod{models.MethodGET},
+		Metadata: map[string]string{models.MetadataStaticDir: "./public"},
+	}
+	assert.Empty(t, rule.Evaluate(noListing))
+}
+
+func TestAP022_SensitiveStaticDir(t *testing.T) {
+	rule := NewAP022SensitiveStaticDir()
+
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "public"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "config"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "public", "app.js"), nil, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "config", ".env"), nil, 0o644))
+
+	tests := []struct {
+		dir      string
+		expected string
+	}{
+		{dir: ".", expected: "project root"},
+		{dir: "./", expected: "project root"},
+		{dir: "/", expected: "file system root"},
+		{dir: "./config", expected: ".env"},
+		{dir: "./public"},
+		{dir: "staticFiles"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			result := models.NewScanResult(root)
+			result.Endpoints = []*models.Endpoint{{
+				Route: "/static/*", Methods: []models.HTTPMethod{models.MethodGET},
+				FilePath: filepath.Join(root, "main.go"),
+				Metadata: map[string]string{models.MetadataStaticDir: tt.dir},
+			}}
+
+			findings := rule.EvaluateProject(result, astutil.NewSourceSet(root))
+			if tt.expected == "" {
+				assert.Empty(t, findings)
+				return
+			}
+			require.Len(t, findings, 1)
+			assert.Contains(t, findings[0].Message, tt.expected)
+		})
+	}
+}
+
+func TestAP023_UserControlledFilePath(t *testing.T) {
+	rule := NewAP023UserControlledFilePath()
+	loader := astutil.NewSourceLoader()
+
+	tests := []struct {
+		name    string
+		code    string
+		flagged bool
+	}{
+		{
+			name: "route parameter passed to File",
+			code: `package main
+
+func setup(r *gin.Engine) {
+	r.GET("/download/:name", func(c *gin.Context) {
+		c.File(filepath.Join("uploads", c.Param("name")))
+	})
+}`,
+			flagged: true,
+		},
+		{
+			name: "query value through variables",
+			code: `package main
+
+func report(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("file")
+	path := "reports/" + name
+	http.ServeFile(w, r, path)
+}`,
+			flagged: true,
+		},
+		{
+			name: "reduced to a file name",
+			code: `package main
+
+func download(c *fiber.Ctx) error {
+	return c.SendFile(filepath.Join("uploads", filepath.Base(c.Params("name"))))
+}`,
+		},
+		{
+			name: "containment check",
+			code: `package main
+
+func download(c echo.Context) error {
+	path := filepath.Join(root, c.Param("name"))
+	if !strings.HasPrefix(filepath.Clean(path), root) {
+		return echo.ErrForbidden
+	}
+	return c.File(path)
+}`,
+		},
+		{
+			name: "relative path check",
+			code: `package main
+
+func report(w http.ResponseWriter, r *http.Request) {
+	path := filepath.Join(reportsDir, r.URL.Query().Get("file"))
+	rel, err := filepath.Rel(reportsDir, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	http.ServeFile(w, r, path)
+}`,
+		},
+		{
+			name: "local name check around the call",
+			code: `package main
+
+func download(c *fiber.Ctx) error {
+	if name := c.Params("name"); filepath.IsLocal(name) {
+		return c.SendFile(filepath.Join("files", name))
+	}
+	return fiber.ErrNotFound
+}`,
+		},
+		{
+			name: "prefix check against a separator",
+			code: `package main
+
+func download(c *gin.Context) {
+	name := c.Param("name")
+	if strings.HasPrefix(name, "/") {
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+	c.File(filepath.Join("files", name))
+}`,
+			flagged: true,
+		},
+		{
+			name: "prefix check on the uncleaned path",
+			code: `package main
+
+func download(c echo.Context) error {
+	path := root + "/" + c.Param("name")
+	if !strings.HasPrefix(path, root) {
+		return echo.ErrForbidden
+	}
+	return c.File(path)
+}`,
+			flagged: true,
+		},
+		{
+			name: "containment check that does not guard the call",
+			code: `package main
+
+func download(c echo.Context) error {
+	path := filepath.Join(root, c.Param("name"))
+	if strings.HasPrefix(filepath.Clean(path), root) {
+		log.Printf("serving %s", path)
+	}
+	return c.File(path)
+}`,
+			flagged: true,
+		},
+		{
+			name: "constant path",
+			code: `package main
+
+func index(c *gin.Context) {
+	c.File("./public/index.html")
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := loader.ParseContent("/project/main.go", tt.code)
+			require.NoError(t, err)
+			sources := astutil.NewSourceSet("/project")
+			sources.Add(source)
+
+			findings := rule.EvaluateProject(models.NewScanResult("/project"), sources)
+			if !tt.flagged {
+				assert.Empty(t, findings)
+				return
+			}
+			require.Len(t, findings, 1)
+			assert.Equal(t, "AP023", findings[0].RuleID)
+		})
+	}
+}
+
+func TestAP024_UnauthenticatedWebSocket(t *testing.T) {
+	rule := NewAP024UnauthenticatedWebSocket()
+
+	public := &models.Endpoint{
+		Route: "/ws", Methods: []models.HTTPMethod{models.MethodGET},
+		Kind: models.EndpointKindWebSocket, Classification: models.ClassificationPublic,
+	}
+	findings := rule.Evaluate(public)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "AP024", findings[0].RuleID)
+
+	authenticated := &models.Endpoint{
+		Route: "/ws", Methods: []models.HTTPMethod{models.MethodGET},
+		Kind: models.EndpointKindWebSocket, Classification: models.ClassificationAuthenticated,
+	}
+	assert.Empty(t, rule.Evaluate(authenticated))
+
+	plain := &models.Endpoint{
+		Route: "/users", Methods: []models.HTTPMethod{models.MethodGET},
+		Kind: models.EndpointKindHTTP, Classification: models.ClassificationPublic,
+	}
+	assert.Empty(t, rule.Evaluate(plain))
+}
+
+func TestAP025_WebSocketOrigin(t *testing.T) {
+	rule := NewAP025WebSocketOrigin()
+	loader := astutil.NewSourceLoader()
+
+	tests := []struct {
+		name   string
+		code   string
+		reason string
+	}{
+		{
+			name: "gorilla check origin returns true",
+			code: `package main
+
+import "github.com/gorilla/websocket"
+
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}`,
+			reason: "CheckOrigin always returns true",
+		},
+		{
+			name: "gorilla check origin assigned later",
+			code: `package main
+
+import "github.com/gorilla/websocket"
+
+func init() {
+	upgrader.CheckOrigin = allowAll
+}
+
+func allowAll(r *http.Request) bool {
+	return true
+}`,
+			reason: "CheckOrigin always returns true",
+		},
+		{
+			name: "nhooyr insecure skip verify",
+			code: `package main
+
+import "nhooyr.io/websocket"
+
+func serve(w http.ResponseWriter, r *http.Request) {
+	websocket.Accept(w, r, &websocket.AcceptOptions{InsecureSkipVerify: true})
+}`,
+			reason: "InsecureSkipVerify",
+		},
+		{
+			name: "fiber websocket without origins",
+			code: `package main
+
+import "github.com/gofiber/contrib/websocket"
+
+func setup(app *fiber.App) {
+	app.Get("/ws", websocket.New(func(c *websocket.Conn) {}))
+}`,
+			reason: "without Origins",
+		},
+		{
+			name: "gorilla default check origin",
+			code: `package main
+
+import "github.com/gorilla/websocket"
+
+var upgrader = websocket.Upgrader{ReadBufferSize: 1024}`,
+		},
+		{
+			name: "gorilla allow-list",
+			code: `package main
+
+import "github.com/gorilla/websocket"
+
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool {
+		return r.Header.Get("Origin") == "https://example.com"
+	},
+}`,
+		},
+		{
+			name: "fiber websocket with origins",
+			code: `package main
+
+import "github.com/gofiber/contrib/websocket"
+
+func setup(app *fiber.App) {
+	app.Get("/ws", websocket.New(handle, websocket.Config{Origins: []string{"https://example.com"}}))
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := loader.ParseContent("/project/main.go", tt.code)
+			require.NoError(t, err)
+			sources := astutil.NewSourceSet("/project")
+			sources.Add(source)
+
+			result := models.NewScanResult("/project")
+			result.Endpoints = []*models.Endpoint{{
+				Route: "/ws", Methods: []models.HTTPMethod{models.MethodGET}, FilePath: "/project/main.go",
+				Kind: models.EndpointKindWebSocket, Classification: models.ClassificationAuthenticated,
+			}}
+
+			findings := rule.EvaluateProject(result, sources)
+			if tt.reason == "" {
+				assert.Empty(t, findings)
+				return
+			}
+			require.Len(t, findings, 1)
+			assert.Contains(t, findings[0].Message, tt.reason)
+			assert.Equal(t, models.SeverityHigh, findings[0].Severity)
+			assert.Len(t, findings[0].RelatedEndpoints, 1)
+		})
+	}
+}
+
+func TestAP026_PublicProxy(t *testing.T) {
+	rule := NewAP026PublicProxy()
+
+	public := &models.Endpoint{
+		Route: "/api/", Methods: []models.HTTPMethod{models.MethodGET}, Classification: models.ClassificationPublic,
+		Metadata: map[string]string{
+			models.MetadataProxy:       "httputil.NewSingleHostReverseProxy",
+			models.MetadataProxyTarget: "http://internal-api:8080",
+		},
+	}
+	findings := rule.Evaluate(public)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "AP026", findings[0].RuleID)
+	assert.Contains(t, findings[0].Message, "http://internal-api:8080")
+
+	authenticated := &models.Endpoint{
+		Route: "/api/", Methods: []models.HTTPMethod{models.MethodGET}, Classification: models.ClassificationAuthenticated,
+		Metadata: map[string]string{models.MetadataProxy: "httputil.NewSingleHostReverseProxy"},
+	}
+	assert.Empty(t, rule.Evaluate(authenticated))
+
+	plain := &models.Endpoint{
+		Route: "/users", Methods: []models.HTTPMethod{models.MethodGET}, Classification: models.ClassificationPublic,
+	}
+	assert.Empty(t, rule.Evaluate(plain))
+}
+
+func TestAP027_WildcardProxy(t *testing.T) {
+	rule := NewAP027WildcardProxy()
+
+	tests := []struct {
+		route     string
+		framework models.Framework
+		expected  bool
+	}{
+		{"/api/*", models.FrameworkEcho, true},
+		{"/gh/*path", models.FrameworkGin, true},
+		{"/api/{path...}", models.FrameworkNetHTTP, true},
+		{"/api/", models.FrameworkNetHTTP, true},
+		{"/api/", models.FrameworkGin, false},
+		{"/billing", models.FrameworkNetHTTP, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.route, func(t *testing.T) {
+			endpoint := &models.Endpoint{
+				Route: tt.route, Methods: []models.HTTPMethod{models.MethodGET}, Framework: tt.framework,
+				Metadata: map[string]string{models.MetadataProxy: "httputil.NewSingleHostReverseProxy"},
+			}
+			findings := rule.Evaluate(endpoint)
+			if tt.expected {
+				require.Len(t, findings, 1)
+				assert.Equal(t, "AP027", findings[0].RuleID)
+			} else {
+				assert.Empty(t, findings)
+			}
+		})
+	}
+
+	plain := &models.Endpoint{Route: "/files/*", Methods: []models.HTTPMethod{models.MethodGET}, Framework: models.FrameworkEcho}
+	assert.Empty(t, rule.Evaluate(plain))
+}
+
+func TestAP028_ProxyForwardsAuthorization(t *testing.T) {
+	rule := NewAP028ProxyForwardsAuthorization()
+	loader := astutil.NewSourceLoader()
+
+	tests := []struct {
+		name     string
+		code     string
+		expected bool
+	}{
+		{
+			name: "director sets host",
+			code: `package main
+
+import "net/http/httputil"
+
+var proxy = &httputil.ReverseProxy{
+	Director: func(req *http.Request) {
+		req.URL.Scheme = "https"
+		req.URL.Host = "api.partner.com"
+	},
+}`,
+			expected: true,
+		},
+		{
+			name: "director wraps the original director",
+			code: `package main
+
+import "net/http/httputil"
+
+func setup(target *url.URL) *httputil.ReverseProxy {
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	director := proxy.Director
+	proxy.Director = func(req *http.Request) {
+		director(req)
+		req.Header.Set("X-Forwarded-Host", req.Host)
+	}
+	return proxy
+}`,
+			expected: true,
+		},
+		{
+			name: "rewrite sets url",
+			code: `package main
+
+import "net/http/httputil"
+
+var proxy = &httputil.ReverseProxy{
+	Rewrite: func(r *httputil.ProxyRequest) {
+		r.SetURL(target)
+	},
+}`,
+			expected: true,
+		},
+		{
+			name: "director deletes authorization",
+			code: `package main
+
+import "net/http/httputil"
+
+var proxy = &httputil.ReverseProxy{
+	Director: func(req *http.Request) {
+		req.URL.Host = "api.partner.com"
+		req.Header.Del("Authorization")
+	},
+}`,
+		},
+		{
+			name: "director replaces authorization",
+			code: `package main
+
+import "net/http/httputil"
+
+var proxy = &httputil.ReverseProxy{
+	Director: func(req *http.Request) {
+		req.URL.Host = "api.partner.com"
+		req.Header.Set("Authorization", "Bearer "+upstreamToken)
+	},
+}`,
+		},
+		{
+			name: "director keeps the host",
+			code: `package main
+
+import "net/http/httputil"
+
+var proxy = &httputil.ReverseProxy{
+	Director: func(req *http.Request) {
+		req.Header.Set("X-Request-Id", newID())
+	},
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := loader.ParseContent("/project/main.go", tt.code)
+			require.NoError(t, err)
+			sources := astutil.NewSourceSet("/project")
+			sources.Add(source)
+
+			result := models.NewScanResult("/project")
+			result.Endpoints = []*models.Endpoint{{
+				Route: "/api/", Methods: []models.HTTPMethod{models.MethodGET}, FilePath: "/project/main.go",
+				Metadata: map[string]string{models.MetadataProxy: "httputil.ReverseProxy"},
+			}}
+
+			findings := rule.EvaluateProject(result, sources)
+			if !tt.expected {
+				assert.Empty(t, findings)
+				return
+			}
+			require.Len(t, findings, 1)
+			assert.Equal(t, "AP028", findings[0].RuleID)
+			assert.Len(t, findings[0].RelatedEndpoints, 1)
+		})
+	}
+}
+
+func TestAP029_UnauthenticatedUpload(t *testing.T) {
+	rule := NewAP029UnauthenticatedUpload()
+
+	public := &models.Endpoint{
+		Route: "/avatar", Methods: []models.HTTPMethod{models.MethodPOST}, Classification: models.ClassificationPublic,
+		Metadata: map[string]string{models.MetadataUpload: "c.FormFile"},
+	}
+	findings := rule.Evaluate(public)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "AP029", findings[0].RuleID)
+	assert.Contains(t, findings[0].Message, "c.FormFile")
+
+	authenticated := &models.Endpoint{
+		Route: "/avatar", Methods: []models.HTTPMethod{models.MethodPOST}, Classification: models.ClassificationAuthenticated,
+		Metadata: map[string]string{models.MetadataUpload: "c.FormFile"},
+	}
+	assert.Empty(t, rule.Evaluate(authenticated))
+
+	plain := &models.Endpoint{
+		Route: "/users", Methods: []models.HTTPMethod{models.MethodPOST}, Classification: models.ClassificationPublic,
+	}
+	assert.Empty(t, rule.Evaluate(plain))
+}
+
+func TestAP030_UploadSizeLimit(t *testing.T) {
+	rule := NewAP030UploadSizeLimit()
+
+	unlimited := &models.Endpoint{
+		Route: "/avatar", Methods: []models.HTTPMethod{models.MethodPOST},
+		Metadata: map[string]string{models.MetadataUpload: "c.FormFile"},
+	}
+	findings := rule.Evaluate(unlimited)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "AP030", findings[0].RuleID)
+
+	limited := &models.Endpoint{
+		Route: "/avatar", Methods: []models.HTTPMethod{models.MethodPOST},
+		Metadata: map[string]string{
+			models.MetadataUpload:    "c.FormFile",
+			models.MetadataBodyLimit: "http.MaxBytesReader",
+		},
+	}
+	assert.Empty(t, rule.Evaluate(limited))
+
+	plain := &models.Endpoint{Route: "/users", Methods: []models.HTTPMethod{models.MethodPOST}}
+	assert.Empty(t, rule.Evaluate(plain))
+}
+
+func TestAP031_UploadClientFilename(t *testing.T) {
+	rule := NewAP031UploadClientFilename()
+	loader := astutil.NewSourceLoader()
+
+	tests := []struct {
+		name     string
+		code     string
+		expected bool
+	}{
+		{
+			name: "gin save with client filename",
+			code: `package main
+
+func upload(c *gin.Context) {
+	file, _ := c.FormFile("avatar")
+	dst := "./uploads/" + file.Filename
+	c.SaveUploadedFile(file, dst)
+}`,
+			expected: true,
+		},
+		{
+			name: "os create with client filename",
+			code: `package main
+
+func upload(w http.ResponseWriter, r *http.Request) {
+	f, header, _ := r.FormFile("file")
+	out, _ := os.Create(filepath.Join("/data", header.Filename))
+	io.Copy(out, f)
+}`,
+			expected: true,
+		},
+		{
+			name: "fiber save with client filename",
+			code: `package main
+
+func upload(c *fiber.Ctx) error {
+	file, _ := c.FormFile("doc")
+	return c.SaveFile(file, fmt.Sprintf("./uploads/%s", file.Filename))
+}`,
+			expected: true,
+		},
+		{
+			name: "base name",
+			code: `package main
+
+func upload(c *gin.Context) {
+	file, _ := c.FormFile("avatar")
+	c.SaveUploadedFile(file, filepath.Join("uploads", filepath.Base(file.Filename)))
+}`,
+		},
+		{
+			name: "server generated name",
+			code: `package main
+
+func upload(c *gin.Context) {
+	file, _ := c.FormFile("avatar")
+	c.SaveUploadedFile(file, "./uploads/"+uuid.NewString())
+}`,
+		},
+		{
+			name: "destination checked against the upload directory",
+			code: `package main
+
+func upload(c *gin.Context) {
+	file, _ := c.FormFile("avatar")
+	dst := filepath.Join(uploadDir, file.Filename)
+	if !strings.HasPrefix(dst, uploadDir+string(os.PathSeparator)) {
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+	c.SaveUploadedFile(file, dst)
+}`,
+		},
+		{
+			name: "client filename checked for a leading slash",
+			code: `package main
+
+func upload(c *gin.Context) {
+	file, _ := c.FormFile("avatar")
+	if strings.HasPrefix(file.Filename, "/") {
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+	c.SaveUploadedFile(file, "./uploads/"+file.Filename)
+}`,
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := loader.ParseContent("/project/main.go", tt.code)
+			require.NoError(t, err)
+			sources := astutil.NewSourceSet("/project")
+			sources.Add(source)
+
+			result := models.NewScanResult("/project")
+			result.Endpoints = []*models.Endpoint{{
+				Route: "/upload", Methods: []models.HTTPMethod{models.MethodPOST}, FilePath: "/project/main.go", FunctionName: "upload",
+			}}
+
+			findings := rule.EvaluateProject(result, sources)
+			if !tt.expected {
+				assert.Empty(t, findings)
+				return
+			}
+			require.Len(t, findings, 1)
+			assert.Equal(t, "AP031", findings[0].RuleID)
+			assert.Len(t, findings[0].RelatedEndpoints, 1)
+		})
+	}
+}
+
+func TestAP032_MassAssignment(t *testing.T) {
+	loader := astutil.NewSourceLoader()
+
+	userModel, err := loader.ParseContent("/project/models/user.go", `package models
+
+type Base struct {
+	ID       int
+	TenantID int `+"`json:\"tenant_id\"`"+`
+}
+
+type User struct {
+	Base
+	Name        string `+"`json:\"name\"`"+`
+	Role        string `+"`json:\"role\"`"+`
+	IsAdmin     bool   `+"`json:\"-\"`"+`
+	BillingPlan string `+"`json:\"plan\"`"+`
+}
+`)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		code     string
+		custom   []string
+		expected string
+	}{
+		{
+			name: "gin bind into model",
+			code: `package api
+
+import "example.com/app/models"
+
+func handler(c *gin.Context) {
+	var user models.User
+	c.ShouldBindJSON(&user)
+}`,
+			expected: "Role, TenantID",
+		},
+		{
+			name: "json decoder into pointer",
+			code: `package api
+
+import "example.com/app/models"
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	u := &models.User{}
+	json.NewDecoder(r.Body).Decode(u)
+}`,
+			expected: "Role, TenantID",
+		},
+		{
+			name: "fiber body parser into local struct",
+			code: `package api
+
+type account struct {
+	Email   string
+	OwnerID int
+}
+
+func handler(c *fiber.Ctx) error {
+	var in account
+	return c.BodyParser(&in)
+}`,
+			expected: "OwnerID",
+		},
+		{
+			name: "configured field",
+			code: `package api
+
+import "example.com/app/models"
+
+func handler(c *gin.Context) {
+	var user models.User
+	c.Bind(&user)
+}`,
+			custom:   []string{"BillingPlan"},
+			expected: "BillingPlan, Role, TenantID",
+		},
+		{
+			name: "request DTO",
+			code: `package api
+
+type updateProfile struct {
+	Name string
+}
+
+func handler(c *gin.Context) {
+	var in updateProfile
+	c.ShouldBindJSON(&in)
+}`,
+		},
+		{
+			name: "decoder not reading a request body",
+			code: `package api
+
+import "example.com/app/models"
+
+func handler(c *gin.Context) {
+	var user models.User
+	json.NewDecoder(file).Decode(&user)
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := loader.ParseContent("/project/api/handler.go", tt.code)
+			require.NoError(t, err)
+			sources := astutil.NewSourceSet("/project")
+			sources.Add(userModel)
+			sources.Add(source)
+
+			result := models.NewScanResult("/project")
+			result.Endpoints = []*models.Endpoint{{
+				Route: "/users/:id", Methods: []models.HTTPMethod{models.MethodPUT},
+				FilePath: "/project/api/handler.go", FunctionName: "handler",
+			}}
+
+			findings := NewAP032MassAssignment(tt.custom).EvaluateProject(result, sources)
+			if tt.expected == "" {
+				assert.Empty(t, findings)
+				return
+			}
+			require.Len(t, findings, 1)
+			assert.Equal(t, "AP032", findings[0].RuleID)
+			assert.Equal(t, result.Endpoints[0], findings[0].Endpoint)
+			assert.Contains(t, findings[0].Message, "privileged fields: "+tt.expected)
+		})
+	}
+}
+
+func TestAP032_MassAssignment_SharedMethodName(t *testing.T) {
+	loader := astutil.NewSourceLoader()
+
+	source, err := loader.ParseContent("/project/main.go", `package main
+
+import "github.com/gin-gonic/gin"
+
+type Order struct {
+	Item     string
+	Quantity int
+}
+
+type User struct {
+	Name string
+	Role string
+}
+
+type OrderHandler struct{}
+type UserHandler struct{}
+
+func (h *OrderHandler) Create(c *gin.Context) {
+	var order Order
+	c.ShouldBindJSON(&order)
+}
+
+func (h *UserHandler) Create(c *gin.Context) {
+	var user User
+	c.ShouldBindJSON(&user)
+}
+
+func register(r *gin.Engine, oh *OrderHandler, uh *UserHandler) {
+	r.POST("/orders", oh.Create)
+	r.POST("/users", uh.Create)
+}
+`)
+	require.NoError(t, err)
+	sources := astutil.NewSourceSet("/project")
+	sources.Add(source)
+
+	result := models.NewScanResult("/project")
+	result.Endpoints = []*models.Endpoint{
+		{
+			Route: "/orders", Methods: []models.HTTPMethod{models.MethodPOST},
+			FilePath: "/project/main.go", LineNumber: 29, FunctionName: "oh.Create",
+		},
+		{
+			Route: "/users", Methods: []models.HTTPMethod{models.MethodPOST},
+			FilePath: "/project/main.go", LineNumber: 30, FunctionName: "uh.Create",
+		},
+	}
+
+	findings := NewAP032MassAssignment(nil).EvaluateProject(result, sources)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "/users", findings[0].Endpoint.Route)
+	assert.Contains(t, findings[0].Message, "'main.User' with privileged fields: Role")
+}
+
+func TestAP033_SensitiveResponseFields(t *testing.T) {
+	rule := NewAP033SensitiveResponseFields()
+	loader := astutil.NewSourceLoader()
+
+	typesSource, err := loader.ParseContent("/project/types.go", `package main
+
+type Profile struct {
+	SSN         string
+	DateOfBirth string
+}
+
+type User struct {
+	ID                int
+	Email             string
+	PasswordHash      string
+	PasswordChangedAt string
+	APIKey            string `+"`json:\"-\"`"+`
+	Profile           Profile
+}
+
+type LoginResponse struct {
+	AccessToken string
+	TokenExpiry int
+}
+
+type UserView struct {
+	ID          int
+	DisplayName string
+}
+
+type Customer struct {
+	ID          int
+	Email       string
+	Phone       string
+	DateOfBirth string
+	Address     string
+	IPAddress   string
+}
+`)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		route          string
+		classification models.SecurityClassification
+		code           string
+		expected       string
+		severity       models.Severity
+	}{
+		{
+			name:           "gin JSON of a model",
+			route:          "/users/:id",
+			classification: models.ClassificationAuthenticated,
+			code: `package main
+
+func handler(c *gin.Context) {
+	c.JSON(200, &User{})
+}`,
+			expected: "main.Profile.SSN, main.User.PasswordHash",
+			severity: models.SeverityMedium,
+		},
+		{
+			name:           "encoder of a slice on a public route",
+			route:          "/users",
+			classification: models.ClassificationPublic,
+			code: `package main
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	var users []User
+	json.NewEncoder(w).Encode(users)
+}`,
+			expected: "main.Profile.DateOfBirth, main.Profile.SSN, main.User.Email, main.User.PasswordHash",
+			severity: models.SeverityHigh,
+		},
+		{
+			name:           "model inside gin.H",
+			route:          "/me",
+			classification: models.ClassificationAuthenticated,
+			code: `package main
+
+func handler(c *gin.Context) {
+	var u User
+	c.JSON(200, gin.H{"user": u})
+}`,
+			expected: "main.Profile.SSN, main.User.PasswordHash",
+			severity: models.SeverityMedium,
+		},
+		{
+			name:           "fiber JSON with a single argument",
+			route:          "/api/keys",
+			classification: models.ClassificationAuthenticated,
+			code: `package main
+
+type Key struct {
+	Name      string
+	SecretKey string
+}
+
+func handler(c *fiber.Ctx) error {
+	return c.JSON(Key{})
+}`,
+			expected: "main.Key.SecretKey",
+			severity: models.SeverityMedium,
+		},
+		{
+			name:           "token on a login route",
+			route:          "/login",
+			classification: models.ClassificationPublic,
+			code: `package main
+
+func handler(c *gin.Context) {
+	c.JSON(200, LoginResponse{})
+}`,
+		},
+		{
+			name:           "token on another route",
+			route:          "/session",
+			classification: models.ClassificationAuthenticated,
+			code: `package main
+
+func handler(c *gin.Context) {
+	c.JSON(200, LoginResponse{})
+}`,
+			expected: "main.LoginResponse.AccessToken",
+			severity: models.SeverityMedium,
+		},
+		{
+			name:           "response DTO",
+			route:          "/users/:id",
+			classification: models.ClassificationPublic,
+			code: `package main
+
+func handler(c *gin.Context) {
+	c.JSON(200, UserView{})
+}`,
+		},
+		{
+			name:           "personal data on a public route",
+			route:          "/customers/:id",
+			classification: models.ClassificationPublic,
+			code: `package main
+
+func handler(c *gin.Context) {
+	c.JSON(200, Customer{})
+}`,
+			expected: "main.Customer.Address, main.Customer.DateOfBirth, main.Customer.Email, main.Customer.Phone",
+			severity: models.SeverityHigh,
+		},
+		{
+			name:           "personal data on an authenticated route",
+			route:          "/customers/:id",
+			classification: models.ClassificationAuthenticated,
+			code: `package main
+
+func handler(c *gin.Context) {
+	c.JSON(200, Customer{})
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := loader.ParseContent("/project/handler.go", tt.code)
+			require.NoError(t, err)
+			sources := astutil.NewSourceSet("/project")
+			sources.Add(typesSource)
+			sources.Add(source)
+
+			result := models.NewScanResult("/project")
+			result.Endpoints = []*models.Endpoint{{
+				Route: tt.route, Methods: []models.HTTPMethod{models.MethodGET}, FilePath: "/project/handler.go",
+				FunctionName: "handler", Classification: tt.classification,
+			}}
+
+			findings := rule.EvaluateProject(result, sources)
+			if tt.expected == "" {
+				assert.Empty(t, findings)
+				return
+			}
+			require.Len(t, findings, 1)
+			assert.Equal(t, "AP033", findings[0].RuleID)
+			assert.Contains(t, findings[0].Message, "response: "+tt.expected)
+			assert.Equal(t, tt.severity, findings[0].Severity)
+		})
+	}
+}
+
+func TestIsSensitiveFieldName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"Password", true},
+		{"PasswordHash", true},
+		{"HashedPassword", true},
+		{"password_salt", true},
+		{"AccessToken", true},
+		{"ClientSecret", true},
+		{"SSN", true},
+		{"SocialSecurityNumber", true},
+		{"APIKey", true},
+		{"ApiKey", true},
+		{"PrivateKey", true},
+		{"PasswordChangedAt", false},
+		{"TokenExpiry", false},
+		{"PublicKey", false},
+		{"ClassName", false},
+		{"Email", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isSensitiveFieldName(tt.name))
+		})
+	}
+}
+
+func TestIsPersonalFieldName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"Email", true},
+		{"EmailAddress", true},
+		{"PhoneNumber", true},
+		{"mobile", true},
+		{"DateOfBirth", true},
+		{"BirthDate", true},
+		{"DOB", true},
+		{"Address", true},
+		{"NationalID", true},
+		{"NationalIDNumber", true},
+		{"TaxID", true},
+		{"EmailVerified", false},
+		{"IPAddress", false},
+		{"RemoteAddress", false},
+		{"OrderNumber", false},
+		{"UserID", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isPersonalFieldName(tt.name))
+		})
+	}
+}
+
+func TestAP034_ObjectLevelAuthorization(t *testing.T) {
+	loader := astutil.NewSourceLoader()
+
+	tests := []struct {
+		name           string
+		route          string
+		classification models.SecurityClassification
+		custom         []string
+		code           string
+		expected       bool
+	}{
+		{
+			name:           "repository lookup by route id",
+			route:          "/orders/:orderId",
+			classification: models.ClassificationAuthenticated,
+			code: `package main
+
+func handler(c *gin.Context) {
+	id := c.Param("orderId")
+	order, err := orderRepo.FindByID(c, id)
+	if err != nil {
+		return
+	}
+	c.JSON(200, order)
+}`,
+			expected: true,
+		},
+		{
+			name:           "chi url param into db query",
+			route:          "/invoices/{invoiceID}",
+			classification: models.ClassificationAuthenticated,
+			code: `package main
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.Atoi(chi.URLParam(r, "invoiceID"))
+	row := db.QueryRow("SELECT * FROM invoices WHERE id = $1", id)
+	_ = row
+}`,
+			expected: true,
+		},
+		{
+			name:           "gorilla mux vars into repository",
+			route:          "/accounts/{accountID}",
+			classification: models.ClassificationAuthenticated,
+			code: `package main
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	account, _ := accounts.FindByID(r.Context(), vars["accountID"])
+	json.NewEncoder(w).Encode(account)
+}`,
+			expected: true,
+		},
+		{
+			name:           "query value without the route id",
+			route:          "/orders/:orderId",
+			classification: models.ClassificationAuthenticated,
+			code: `package main
+
+func handler(c *gin.Context) {
+	orders, _ := orderRepo.FindByStatus(c.Query("status"))
+	c.JSON(200, orders)
+}`,
+		},
+		{
+			name:           "owner compared with current user",
+			route:          "/invoices/:id",
+			classification: models.ClassificationAuthenticated,
+			code: `package main
+
+func handler(c *gin.Context) {
+	user := c.MustGet("user").(*User)
+	inv, _ := db.GetInvoice(c.Param("id"))
+	if inv.OwnerID != user.ID {
+		c.AbortWithStatus(403)
+	}
+}`,
+		},
+		{
+			name:           "query scoped to current user",
+			route:          "/notes/:id",
+			classification: models.ClassificationAuthenticated,
+			code: `package main
+
+func handler(c *gin.Context) {
+	uid := c.GetString("userID")
+	db.Where("id = ? AND user_id = ?", c.Param("id"), uid).Delete(&Note{})
+}`,
+		},
+		{
+			name:           "configured principal accessor",
+			route:          "/notes/:id",
+			classification: models.ClassificationAuthenticated,
+			custom:         []string{"session.Viewer"},
+			code: `package main
+
+func handler(c *gin.Context) {
+	viewer := session.Viewer(c)
+	notes.FindByID(c.Param("id"), viewer.ID)
+}`,
+		},
+		{
+			name:           "access check function",
+			route:          "/notes/:id",
+			classification: models.ClassificationAuthenticated,
+			code: `package main
+
+func handler(c *gin.Context) {
+	note, _ := noteRepo.Get(c.Param("id"))
+	if !canAccessNote(c, note) {
+		return
+	}
+}`,
+		},
+		{
+			name:           "role restricted route",
+			route:          "/orders/:id",
+			classification: models.ClassificationRoleRestricted,
+			code: `package main
+
+func handler(c *gin.Context) {
+	orderRepo.FindByID(c.Param("id"))
+}`,
+		},
+		{
+			name:           "route without object id",
+			route:          "/orders/:slug",
+			classification: models.ClassificationAuthenticated,
+			code: `package main
+
+func handler(c *gin.Context) {
+	orderRepo.FindBySlug(c.Param("slug"))
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := loader.ParseContent("/project/handler.go", tt.code)
+			require.NoError(t, err)
+			sources := astutil.NewSourceSet("/project")
+			sources.Add(source)
+
+			result := models.NewScanResult("/project")
+			result.Endpoints = []*models.Endpoint{{
+				Route: tt.route, Methods: []models.HTTPMethod{models.MethodGET}, FilePath: "/project/handler.go",
+				FunctionName: "handler", Classification: tt.classification,
+			}}
+
+			findings := NewAP034ObjectLevelAuthorization(tt.custom).EvaluateProject(result, sources)
+			if !tt.expected {
+				assert.Empty(t, findings)
+				return
+			}
+			require.Len(t, findings, 1)
+			assert.Equal(t, "AP034", findings[0].RuleID)
+			assert.Contains(t, findings[0].Message, "medium confidence")
+			assert.Equal(t, result.Endpoints[0], findings[0].Endpoint)
+		})
+	}
+}
+
+func TestAP035_StateChangingGet(t *testing.T) {
+	rule := NewAP035StateChangingGet()
+
+	stateChanging := &models.Endpoint{
+		Route: "/users/:id/delete", Methods: []models.HTTPMethod{models.MethodGET},
+		Classification: models.ClassificationPublic,
+		Metadata:       map[string]string{models.MetadataStateChanging: "db.Delete"},
+	}
+	findings := rule.Evaluate(stateChanging)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "AP035", findings[0].RuleID)
+	assert.Contains(t, findings[0].Message, "db.Delete")
+
+	// The endpoint counts as a write endpoint, so a public one is also missing authentication
+	authFindings := NewAP004MissingAuthWrites().Evaluate(stateChanging)
+	require.Len(t, authFindings, 1)
+	assert.Contains(t, authFindings[0].Message, "changes state")
+
+	readOnly := &models.Endpoint{Route: "/users", Methods: []models.HTTPMethod{models.MethodGET}}
+	assert.Empty(t, rule.Evaluate(readOnly))
+}
+
+func TestAP036_InsecureCookie(t *testing.T) {
+	rule := NewAP036InsecureCookie()
+	loader := astutil.NewSourceLoader()
+
+	tests := []struct {
+		name     string
+		code     string
+		expected string
+	}{
+		{
+			name: "gin session cookie without flags",
+			code: `package main
+
+func login(c *gin.Context) {
+	c.SetCookie("session_id", token, 3600, "/", "", false, false)
+}`,
+			expected: "'session_id' without Secure, without HttpOnly",
+		},
+		{
+			name: "gin secure cookie",
+			code: `package main
+
+func login(c *gin.Context) {
+	c.SetCookie("session_id", token, 3600, "/", "", true, true)
+}`,
+		},
+		{
+			name: "net/http cookie with SameSite None",
+			code: `package main
+
+import "net/http"
+
+func login(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{Name: "auth_token", Value: token, HttpOnly: true, SameSite: http.SameSiteNoneMode})
+}`,
+			expected: "'auth_token' with SameSite=None without Secure",
+		},
+		{
+			name: "echo cookie variable with flags assigned later",
+			code: `package main
+
+func login(c echo.Context) error {
+	cookie := new(http.Cookie)
+	cookie.Name = "jwt"
+	cookie.Value = token
+	cookie.Secure = true
+	c.SetCookie(cookie)
+	return nil
+}`,
+			expected: "'jwt' without HttpOnly",
+		},
+		{
+			name: "fiber cookie",
+			code: `package main
+
+func login(c *fiber.Ctx) error {
+	c.Cookie(&fiber.Cookie{Name: "refresh_token", Value: token, Secure: true, HTTPOnly: true})
+	return nil
+}`,
+		},
+		{
+			name: "preference cookie",
+			code: `package main
+
+func login(c *gin.Context) {
+	c.SetCookie("theme", "dark", 3600, "/", "", false, false)
+}`,
+		},
+		{
+			name: "csrf cookie readable by scripts",
+			code: `package main
+
+func login(c *gin.Context) {
+	c.SetCookie("csrf_token", token, 3600, "/", "", true, false)
+}`,
+		},
+		{
+			name: "gorilla session options",
+			code: `package main
+
+import "github.com/gorilla/sessions"
+
+func init() {
+	store.Options = &sessions.Options{Path: "/", HttpOnly: true}
+}
+
+func login(w http.ResponseWriter, r *http.Request) {}`,
+			expected: "Session store sets its cookie without Secure",
+		},
+		{
+			name: "fiber session with default options",
+			code: `package main
+
+import "github.com/gofiber/fiber/v2/middleware/session"
+
+var store = session.New()
+
+func login(c *fiber.Ctx) error { return nil }`,
+			expected: "with default options",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := loader.ParseContent("/project/handler.go", tt.code)
+			require.NoError(t, err)
+			sources := astutil.NewSourceSet("/project")
+			sources.Add(source)
+
+			result := models.NewScanResult("/project")
+			result.Endpoints = []*models.Endpoint{{
+				Route: "/login", Methods: []models.HTTPMethod{models.MethodPOST}, FilePath: "/project/handler.go",
+				FunctionName: "login",
+			}}
+
+			findings := rule.EvaluateProject(result, sources)
+			if tt.expected == "" {
+				assert.Empty(t, findings)
+				return
+			}
+			require.Len(t, findings, 1)
+			assert.Equal(t, "AP036", findings[0].RuleID)
+			assert.Contains(t, findings[0].Message, tt.expected)
+		})
+	}
+
+	// A helper setting the cookie is reported on the endpoints of the handlers calling it
+	source, err := loader.ParseContent("/project/auth.go", `package main
+
+func login(c *gin.Context) {
+	setSessionCookie(c, issueToken())
+}
+
+func setSessionCookie(c *gin.Context, token string) {
+	c.SetCookie("sid", token, 3600, "/", "", false, true)
+}`)
+	require.NoError(t, err)
+	sources := astutil.NewSourceSet("/project")
+	sources.Add(source)
+
+	login := &models.Endpoint{Route: "/login", Methods: []models.HTTPMethod{models.MethodPOST}, FilePath: "/project/auth.go", FunctionName: "login"}
+	result := models.NewScanResult("/project")
+	result.Endpoints = []*models.Endpoint{login}
+
+	findings := rule.EvaluateProject(result, sources)
+	require.Len(t, findings, 1)
+	assert.Same(t, login, findings[0].Endpoint)
+	assert.Contains(t, findings[0].Message, "'sid' without Secure")
+}
+
+func TestAP037_SpoofableIPAllowlist(t *testing.T) {
+	rule := NewAP037SpoofableIPAllowlist()
+	loader := astutil.NewSourceLoader()
+
+	tests := []struct {
+		name     string
+		code     string
+		clientIP string
+		expected string
+	}{
+		{
+			name: "gin without trusted proxies",
+			code: `package main
+
+func main() {
+	r := gin.Default()
+	r.Run()
+}`,
+			clientIP: "c.ClientIP",
+			expected: "SetTrustedProxies",
+		},
+		{
+			name: "gin with trusted proxies",
+			code: `package main
+
+func main() {
+	r := gin.Default()
+	r.SetTrustedProxies([]string{"10.0.0.0/8"})
+	r.Run()
+}`,
+			clientIP: "c.ClientIP",
+		},
+		{
+			name: "echo RealIP without extractor",
+			code: `package main
+
+func main() {
+	e := echo.New()
+	e.Start(":8080")
+}`,
+			clientIP: "c.RealIP",
+			expected: "IPExtractor",
+		},
+		{
+			name: "echo with direct extractor",
+			code: `package main
+
+func main() {
+	e := echo.New()
+	e.IPExtractor = echo.ExtractIPDirect()
+}`,
+			clientIP: "c.RealIP",
+		},
+		{
+			name: "fiber proxy header without trusted proxy check",
+			code: `package main
+
+import "github.com/gofiber/fiber/v2"
+
+func main() {
+	app := fiber.New(fiber.Config{ProxyHeader: fiber.HeaderXForwardedFor})
+	app.Listen(":3000")
+}`,
+			clientIP: "c.IP",
+			expected: "EnableTrustedProxyCheck",
+		},
+		{
+			name: "forwarded header read directly",
+			code: `package main
+`,
+			clientIP: "X-Forwarded-For",
+			expected: "client-supplied X-Forwarded-For header",
+		},
+		{
+			name: "connection address",
+			code: `package main
+`,
+			clientIP: "r.RemoteAddr",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := loader.ParseContent("/project/main.go", tt.code)
+			require.NoError(t, err)
+			sources := astutil.NewSourceSet("/project")
+			sources.Add(source)
+
+			result := models.NewScanResult("/project")
+			result.Endpoints = []*models.Endpoint{{
+				Route: "/admin/reset", Methods: []models.HTTPMethod{models.MethodPOST}, FilePath: "/project/main.go",
+				Metadata: map[string]string{models.MetadataIPAllowlist: "officeOnly", models.MetadataClientIP: tt.clientIP},
+			}}
+
+			findings := rule.EvaluateProject(result, sources)
+			if tt.expected == "" {
+				assert.Empty(t, findings)
+				return
+			}
+			require.Len(t, findings, 1)
+			assert.Equal(t, "AP037", findings[0].RuleID)
+			assert.Contains(t, findings[0].Message, tt.expected)
+		})
+	}
+
+	// chi's RealIP middleware makes RemoteAddr spoofable
+	source, err := loader.ParseContent("/project/main.go", "package main\n")
+	require.NoError(t, err)
+	sources := astutil.NewSourceSet("/project")
+	sources.Add(source)
+	result := models.NewScanResult("/project")
+	result.Endpoints = []*models.Endpoint{{
+		Route: "/admin/reset", Methods: []models.HTTPMethod{models.MethodPOST}, FilePath: "/project/main.go",
+		Middleware: []string{"middleware.RealIP", "officeOnly"},
+		Metadata:   map[string]string{models.MetadataIPAllowlist: "officeOnly", models.MetadataClientIP: "r.RemoteAddr"},
+	}}
+	findings := rule.EvaluateProject(result, sources)
+	require.Len(t, findings, 1)
+	assert.Contains(t, findings[0].Message, "middleware.RealIP")
+}
+
+func TestAP038_ForwardedIPAccessControl(t *testing.T) {
+	rule := NewAP038ForwardedIPAccessControl()
+	loader := astutil.NewSourceLoader()
+
+	tests := []struct {
+		name     string
+		code     string
+		expected bool
+	}{
+		{
+			name: "handler rejecting on X-Forwarded-For",
+			code: `package main
+
+func handler(c *gin.Context) {
+	ip := strings.Split(c.GetHeader("X-Forwarded-For"), ",")[0]
+	if !strings.HasPrefix(ip, "10.") {
+		c.AbortWithStatus(403)
+		return
+	}
+	c.JSON(200, stats())
+}`,
+			expected: true,
+		},
+		{
+			name: "middleware admitting on X-Real-IP",
+			code: `package main
+
+func internalOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if allowed[r.Header.Get("X-Real-IP")] {
+			next.ServeHTTP(w, r)
+		}
+	})
+}`,
+			expected: true,
+		},
+		{
+			name: "header only logged",
+			code: `package main
+
+func handler(c *gin.Context) {
+	ip := c.GetHeader("X-Forwarded-For")
+	if ip != "" {
+		log.Println("request from", ip)
+	}
+	c.JSON(200, stats())
+}`,
+		},
+		{
+			name: "decision on connection address",
+			code: `package main
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.RemoteAddr, "10.") {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := loader.ParseContent("/project/handler.go", tt.code)
+			require.NoError(t, err)
+			sources := astutil.NewSourceSet("/project")
+			sources.Add(source)
+
+			findings := rule.EvaluateProject(models.NewScanResult("/project"), sources)
+			if tt.expected {
+				require.Len(t, findings, 1)
+				assert.Equal(t, "AP038", findings[0].RuleID)
+			} else {
+				assert.Empty(t, findings)
+			}
+		})
+	}
+}
+
+func TestAP039_TimingUnsafeComparison(t *testing.T) {
+	loader := astutil.NewSourceLoader()
+
+	tests := []struct {
+		name          string
+		code          string
+		authFunctions []string
+		expected      string
+	}{
+		{
+			name: "api key compared with ==",
+			code: `package main
+
+func APIKeyAuth(cfg Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("X-API-Key") != cfg.APIKey {
+			c.AbortWithStatus(401)
+			return
+		}
+		c.Next()
+	}
+}`,
+			expected: "using !=",
+		},
+		{
+			name: "hmac signature compared with bytes.Equal",
+			code: `package main
+
+func APIKeyAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sig, _ := hex.DecodeString(r.Header.Get("X-Signature"))
+		mac := hmac.New(sha256.New, secret)
+		expectedMAC := mac.Sum(nil)
+		if !bytes.Equal(sig, expectedMAC) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}`,
+			expected: "using bytes.Equal",
+		},
+		{
+			name: "header compared with literal key",
+			code: `package main
+
+func APIKeyAuth(c *fiber.Ctx) error {
+	if c.Get("X-API-Key") == "s3cr3t-k3y" {
+		return c.Next()
+	}
+	return fiber.ErrUnauthorized
+}`,
+			expected: "using ==",
+		},
+		{
+			name: "constant-time comparison",
+			code: `package main
+
+func APIKeyAuth(cfg Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("X-API-Key")
+		if subtle.ConstantTimeCompare([]byte(key), []byte(cfg.APIKey)) != 1 {
+			c.AbortWithStatus(401)
+		}
+	}
+}`,
+		},
+		{
+			name: "non-secret header compared with literal",
+			code: `package main
+
+func APIKeyAuth(c *gin.Context) {
+	if c.GetHeader("X-Requested-With") != "XMLHttpRequest" || c.GetHeader("X-API-Key") == "" {
+		c.AbortWithStatus(401)
+	}
+}`,
+		},
+		{
+			name: "configured validator comparing its parameter",
+			code: `package main
+
+func APIKeyAuth(c *gin.Context) {
+	if !keys.Validate(c.GetHeader("X-API-Key")) {
+		c.AbortWithStatus(401)
+	}
+}
+
+func Validate(provided string) bool {
+	return provided == os.Getenv("API_KEY")
+}`,
+			authFunctions: []string{"keys.Validate"},
+			expected:      "Auth function 'Validate'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := loader.ParseContent("/project/auth.go", tt.code)
+			require.NoError(t, err)
+			sources := astutil.NewSourceSet("/project")
+			sources.Add(source)
+
+			result := models.NewScanResult("/project")
+			result.Endpoints = []*models.Endpoint{{
+				Route: "/admin", Methods: []models.HTTPMethod{models.MethodGET}, FilePath: "/project/auth.go",
+				Authorization: models.AuthorizationInfo{RequiresAuth: true, AuthDependencies: []string{"APIKeyAuth"}},
+			}}
+
+			findings := NewAP039TimingUnsafeComparison(tt.authFunctions).EvaluateProject(result, sources)
+			if tt.expected == "" {
+				assert.Empty(t, findings)
+				return
+			}
+			require.Len(t, findings, 1)
+			assert.Equal(t, "AP039", findings[0].RuleID)
+			assert.Contains(t, findings[0].Message, tt.expected)
+		})
+	}
+}
+
+func TestAP040_UnsignedWebhook(t *testing.T) {
+	rule := NewAP040UnsignedWebhook()
+
+	unsigned := &models.Endpoint{
+		Route: "/webhooks/stripe", Methods: []models.HTTPMethod{models.MethodPOST},
+		Classification: models.ClassificationPublic,
+		Metadata:       map[string]string{models.MetadataWebhook: "true"},
+	}
+	findings := rule.Evaluate(unsigned)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "AP040", findings[0].RuleID)
+
+	// The generic missing-authentication rules leave unsigned webhooks to AP040
+	assert.Empty(t, NewAP001PublicWithoutIntent().Evaluate(unsigned))
+	assert.Empty(t, NewAP004MissingAuthWrites().Evaluate(unsigned))
+	assert.Empty(t, NewAP008EndpointWithoutAuth().Evaluate(unsigned))
+
+	signed := &models.Endpoint{
+		Route: "/webhooks/stripe", Methods: []models.HTTPMethod{models.MethodPOST},
+		Classification: models.ClassificationAuthenticated,
+		Authorization:  models.AuthorizationInfo{RequiresAuth: true, AuthDependencies: []string{"webhook.ConstructEvent"}},
+		Metadata:       map[string]string{models.MetadataWebhook: "true", models.MetadataWebhookVerifier: "webhook.ConstructEvent"},
+	}
+	assert.Empty(t, rule.Evaluate(signed))
+
+	authenticated := &models.Endpoint{
+		Route: "/webhooks/internal", Methods: []models.HTTPMethod{models.MethodPOST},
+		Classification: models.ClassificationAuthenticated,
+		Authorization:  models.AuthorizationInfo{RequiresAuth: true, AuthDependencies: []string{"BasicAuth"}},
+		Metadata:       map[string]string{models.MetadataWebhook: "true"},
+	}
+	assert.Empty(t, rule.Evaluate(authenticated))
+}
+
+func TestAP041_ErrorDetailLeak(t *testing.T) {
+	rule := NewAP041ErrorDetailLeak()
+	loader := astutil.NewSourceLoader()
+
+	tests := []struct {
+		name           string
+		code           string
+		classification models.SecurityClassification
+		expected       string
+		severity       models.Severity
+	}{
+		{
+			name: "gin JSON with err.Error on public endpoint",
+			code: `package main
+
+func handler(c *gin.Context) {
+	user, err := db.FindUser(c.Param("id"))
+	if err != nil {
+		c.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, user)
+}`,
+			classification: models.ClassificationPublic,
+			expected:       "Handler 'handler' sends error details",
+			severity:       models.SeverityHigh,
+		},
+		{
+			name: "http.Error with formatted error on authenticated endpoint",
+			code: `package main
+
+import "net/http"
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	if err := save(r); err != nil {
+		msg := fmt.Sprintf("save failed: %v", err)
+		http.Error(w, msg, http.StatusInternalServerError)
+	}
+}`,
+			classification: models.ClassificationAuthenticated,
+			expected:       "http.Error",
+			severity:       models.SeverityMedium,
+		},
+		{
+			name: "gin String formatting err",
+			code: `package main
+
+func handler(c *gin.Context) {
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.String(400, "invalid request: %v", err)
+	}
+}`,
+			classification: models.ClassificationAuthenticated,
+			expected:       "c.String",
+			severity:       models.SeverityMedium,
+		},
+		{
+			name: "fiber NewError with error message",
+			code: `package main
+
+func handler(c *fiber.Ctx) error {
+	if err := process(c); err != nil {
+		return fiber.NewError(500, err.Error())
+	}
+	return nil
+}`,
+			classification: models.ClassificationAuthenticated,
+			expected:       "fiber.NewError",
+			severity:       models.SeverityMedium,
+		},
+		{
+			name: "recovered panic value",
+			code: `package main
+
+func handler(c *gin.Context) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.JSON(500, gin.H{"panic": r})
+		}
+	}()
+	process(c)
+}`,
+			classification: models.ClassificationAuthenticated,
+			expected:       "c.JSON",
+			severity:       models.SeverityMedium,
+		},
+		{
+			name: "error logged, generic message returned",
+			code: `package main
+
+func handler(c *gin.Context) {
+	if err := process(c); err != nil {
+		log.Printf("process: %v", err)
+		c.JSON(500, gin.H{"error": "internal error"})
+	}
+}`,
+			classification: models.ClassificationPublic,
+		},
+		{
+			name: "echo error handler returning raw errors",
+			code: `package main
+
+import "github.com/labstack/echo/v4"
+
+func main() {
+	e := echo.New()
+	e.HTTPErrorHandler = func(err error, c echo.Context) {
+		c.JSON(500, map[string]string{"message": err.Error()})
+	}
+	e.GET("/users/:id", handler)
+}
+
+func handler(c echo.Context) error { return nil }`,
+			classification: models.ClassificationPublic,
+			expected:       "Echo HTTPErrorHandler returns raw error details",
+			severity:       models.SeverityHigh,
+		},
+		{
+			name: "fiber error handler function",
+			code: `package main
+
+import "github.com/gofiber/fiber/v2"
+
+func main() {
+	app := fiber.New(fiber.Config{ErrorHandler: errorHandler})
+	app.Get("/users/:id", handler)
+}
+
+func errorHandler(c *fiber.Ctx, err error) error {
+	return c.Status(500).SendString(err.Error())
+}
+
+func handler(c *fiber.Ctx) error { return nil }`,
+			classification: models.ClassificationAuthenticated,
+			expected:       "Fiber ErrorHandler returns raw error details",
+			severity:       models.SeverityMedium,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := loader.ParseContent("/project/handler.go", tt.code)
+			require.NoError(t, err)
+			sources := astutil.NewSourceSet("/project")
+			sources.Add(source)
+
+			result := models.NewScanResult("/project")
+			result.Endpoints = []*models.Endpoint{{
+				Route: "/users/:id", Methods: []models.HTTPMethod{models.MethodGET}, FilePath: "/project/handler.go",
+				FunctionName: "handler", Classification: tt.classification,
+			}}
+
+			findings := rule.EvaluateProject(result, sources)
+			if tt.expected == "" {
+				assert.Empty(t, findings)
+				return
+			}
+			require.Len(t, findings, 1)
+			assert.Equal(t, "AP041", findings[0].RuleID)
+			assert.Contains(t, findings[0].Message, tt.expected)
+			assert.Equal(t, tt.severity, findings[0].Severity)
+		})
+	}
+}
+
+func TestAP042_OpenRedirect(t *testing.T) {
+	rule := NewAP042OpenRedirect()
+	loader := astutil.NewSourceLoader()
+
+	tests := []struct {
+		name     string
+		route    string
+		code     string
+		expected bool
+		severity models.Severity
+	}{
+		{
+			name:  "gin redirect to query parameter",
+			route: "/logout",
+			code: `package main
+
+func handler(c *gin.Context) {
+	c.Redirect(http.StatusFound, c.Query("next"))
+}`,
+			expected: true,
+			severity: models.SeverityMedium,
+		},
+		{
+			name:  "oauth callback redirecting to state value",
+			route: "/oauth/callback",
+			code: `package main
+
+import "net/http"
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	returnTo := r.URL.Query().Get("return_to")
+	exchange(r.URL.Query().Get("code"))
+	http.Redirect(w, r, returnTo, http.StatusFound)
+}`,
+			expected: true,
+			severity: models.SeverityHigh,
+		},
+		{
+			name:  "fiber redirect to header",
+			route: "/switch-language",
+			code: `package main
+
+func handler(c *fiber.Ctx) error {
+	return c.Redirect(c.Get("X-Return-To"))
+}`,
+			expected: true,
+			severity: models.SeverityMedium,
+		},
+		{
+			name:  "location header from form value",
+			route: "/continue",
+			code: `package main
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Location", r.FormValue("url"))
+	w.WriteHeader(302)
+}`,
+			expected: true,
+			severity: models.SeverityMedium,
+		},
+		{
+			name:  "host checked against fixed host",
+			route: "/logout",
+			code: `package main
+
+func handler(c echo.Context) error {
+	next := c.QueryParam("next")
+	u, err := url.Parse(next)
+	if err != nil || u.Host != "app.example.com" {
+		return c.NoContent(400)
+	}
+	return c.Redirect(302, next)
+}`,
+		},
+		{
+			name:  "host checked on another branch",
+			route: "/logout",
+			code: `package main
+
+func handler(c echo.Context) error {
+	next := c.QueryParam("next")
+	u, _ := url.Parse(next)
+	if u.Host == "app.example.com" {
+		log.Println("internal redirect")
+	}
+	return c.Redirect(302, next)
+}`,
+			expected: true,
+			severity: models.SeverityMedium,
+		},
+		{
+			name:  "switch on the host",
+			route: "/logout",
+			code: `package main
+
+func handler(c echo.Context) error {
+	next := c.QueryParam("next")
+	u, err := url.Parse(next)
+	if err != nil {
+		return c.NoContent(400)
+	}
+	switch u.Hostname() {
+	case "app.example.com", "admin.example.com":
+		return c.Redirect(302, next)
+	}
+	return c.NoContent(400)
+}`,
+		},
+		{
+			name:  "relative path fallback that allows protocol-relative targets",
+			route: "/oauth/callback",
+			code: `package main
+
+func handler(c *gin.Context) {
+	next := c.Query("next")
+	if !strings.HasPrefix(next, "/") {
+		next = "/"
+	}
+	c.Redirect(302, next)
+}`,
+			expected: true,
+			severity: models.SeverityHigh,
+		},
+		{
+			name:  "scheme prefix check",
+			route: "/oauth/callback",
+			code: `package main
+
+func handler(c *gin.Context) {
+	next := c.Query("next")
+	if strings.HasPrefix(next, "https://") {
+		c.Redirect(302, next)
+	}
+}`,
+			expected: true,
+			severity: models.SeverityHigh,
+		},
+		{
+			name:  "relative path fallback rejecting // and backslashes",
+			route: "/oauth/callback",
+			code: `package main
+
+func handler(c *gin.Context) {
+	next := c.Query("next")
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.HasPrefix(next, "/\\") {
+		next = "/"
+	}
+	c.Redirect(302, next)
+}`,
+		},
+		{
+			name:  "allow-list lookup",
+			route: "/oauth/authorize",
+			code: `package main
+
+func handler(c *gin.Context) {
+	redirectURI := c.Query("redirect_uri")
+	if _, ok := allowedRedirects[redirectURI]; !ok {
+		c.AbortWithStatus(400)
+		return
+	}
+	c.Redirect(302, redirectURI)
+}`,
+		},
+		{
+			name:  "validation helper",
+			route: "/login",
+			code: `package main
+
+func handler(c *gin.Context) {
+	c.Redirect(302, safeRedirectTarget(c.Query("next")))
+}`,
+		},
+		{
+			name:  "fixed redirect",
+			route: "/old",
+			code: `package main
+
+func handler(c *gin.Context) {
+	c.Redirect(301, "/new")
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := loader.ParseContent("/project/handler.go", tt.code)
+			require.NoError(t, err)
+			sources := astutil.NewSourceSet("/project")
+			sources.Add(source)
+
+			result := models.NewScanResult("/project")
+			result.Endpoints = []*models.Endpoint{{
+				Route: tt.route, Methods: []models.HTTPMethod{models.MethodGET}, FilePath: "/project/handler.go",
+				FunctionName: "handler",
+			}}
+
+			findings := rule.EvaluateProject(result, sources)
+			if !tt.expected {
+				assert.Empty(t, findings)
+				return
+			}
+			require.Len(t, findings, 1)
+			assert.Equal(t, "AP042", findings[0].RuleID)
+			assert.Equal(t, tt.route, findings[0].Endpoint.Route)
+			assert.Equal(t, tt.severity, findings[0].Severity)
+		})
+	}
+}
+
+func TestHandlerFunc_Endpoints_ReceiverType(t *testing.T) {
+	loader := astutil.NewSourceLoader()
+
+	source, err := loader.ParseContent("/project/main.go", `package main
+
+import "github.com/gin-gonic/gin"
+
+type OrderHandler struct{}
+type UserHandler struct{}
+
+func (h *OrderHandler) Create(c *gin.Context) {}
+func (h *UserHandler) Create(c *gin.Context)  {}
+
+func register(r *gin.Engine, oh *OrderHandler, uh *UserHandler) {
+	r.POST("/orders", oh.Create)
+	r.POST("/users", uh.Create)
+}
+`)
+	require.NoError(t, err)
+	sources := astutil.NewSourceSet("/project")
+	sources.Add(source)
+
+	endpoints := []*models.Endpoint{
+		{Route: "/orders", FunctionName: "oh.Create", FilePath: "/project/main.go", LineNumber: 12},
+		{Route: "/users", FunctionName: "uh.Create", FilePath: "/project/main.go", LineNumber: 13},
+	}
+
+	served := make(map[string][]string)
+	for _, h := range findHandlerFuncs(source) {
+		if h.decl == nil || h.decl.Recv == nil {
+			continue
+		}
+		receiver := astutil.ExprString(h.decl.Recv.List[0].Type)
+		for _, e := range h.Endpoints(endpoints, sources) {
+			served[receiver] = append(served[receiver], e.Route)
+		}
+	}
+	assert.Equal(t, []string{"/orders"}, served["*OrderHandler"])
+	assert.Equal(t, []string{"/users"}, served["*UserHandler"])
+}
+
 func TestKnownPublicRoutes(t *testing.T) {
 	tests := []struct {
 		route    string
@@ -339,6 +3906,8 @@ func TestKnownPublicRoutes(t *testing.T) {
 		{"/api/users", false},
 		{"/admin/dashboard", false},
 		{"/customers", false},
+		{"/webhooks/stripe", false},
+		{"/api/github-webhook", false},
 		{"/", false},
 		{"", false},
 	}
@@ -465,3 +4034,19 @@ func TestEngine_WithEnabledRules(t *testing.T) {
 			"Unexpected rule %s in findings", f.RuleID)
 	}
 }
+
+func TestEngine_EvaluateProject(t *testing.T) {
+	result := models.NewScanResult("/project")
+	result.Endpoints = []*models.Endpoint{
+		{Route: "/users", Methods: []models.HTTPMethod{models.MethodGET}, FilePath: "a.go", LineNumber: 1, Framework: models.FrameworkGin},
+		{Route: "/users", Methods: []models.HTTPMethod{models.MethodGET}, FilePath: "b.go", LineNumber: 2, Framework: models.FrameworkGin},
+	}
+	sources := astutil.NewSourceSet("/project")
+
+	findings := NewEngine([]string{"AP010"}).EvaluateProject(result, sources)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "AP010", findings[0].RuleID)
+
+	// Project-level rules respect rule enablement like endpoint rules
+	assert.Empty(t, NewEngine([]string{"AP001"}).EvaluateProject(result, sources))
+}
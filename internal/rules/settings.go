@@ -0,0 +1,17 @@
+package rules
+
+// Settings contains project-specific knowledge that rules use to recognise
+// custom middleware and helper functions.
+type Settings struct {
+	// RateLimitMiddleware contains additional names of rate-limiting middleware.
+	RateLimitMiddleware []string
+
+	// PrivilegedFields contains additional names of struct fields that must not be bound from request bodies.
+	PrivilegedFields []string
+
+	// PrincipalAccessors contains additional names of functions returning the current user.
+	PrincipalAccessors []string
+
+	// AuthFunctions contains additional names of functions that check credentials.
+	AuthFunctions []string
+}